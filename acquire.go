@@ -0,0 +1,42 @@
+package casso
+
+import "sync"
+
+// solvers holds solvers released via ReleaseSolver, to be handed out again by AcquireSolver.
+var solvers = sync.Pool{
+	New: func() interface{} { return NewSolver() },
+}
+
+// AcquireSolver returns a solver configured as though it were created using NewSolver with no
+// options, reusing a solver released via ReleaseSolver should one be available, such that
+// applications that rebuild their constraints from scratch on every frame do not allocate a new
+// solver and its storage on every frame. The solver is to be released via ReleaseSolver once it is
+// no longer used.
+func AcquireSolver() *Solver {
+	s := solvers.Get().(*Solver)
+	s.pooled = true
+	return s
+}
+
+// ReleaseSolver returns a solver acquired via AcquireSolver to the pool AcquireSolver draws from.
+// The solver is reset via Reset, and is stripped of its subscribers, its observers, and its history
+// should it have been enabled via EnableHistory, such that whoever acquires it next receives a solver
+// indistinguishable from one newly created but for the storage it retains. Subscribers and observers
+// are not notified of the reset. The solver, and any Variable or Handle referring to it, must not be
+// used once released. Solvers not acquired via AcquireSolver, including clones and forks of acquired
+// solvers, are left untouched, as they may have been created with options. Releasing a solver more
+// than once has no effect past the first time.
+func ReleaseSolver(s *Solver) {
+	if !s.pooled {
+		return
+	}
+	s.pooled = false
+
+	s.subs, s.observers, s.values, s.fetched = nil, nil, nil, nil
+	s.tabs.vals = nil
+	s.history = nil
+
+	s.Reset()
+
+	solvers.Put(s)
+}
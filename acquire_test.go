@@ -0,0 +1,59 @@
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestAcquireReleaseSolver(t *testing.T) {
+	s := casso.AcquireSolver()
+
+	ch := make(chan []casso.Change, 8)
+	s.Subscribe(ch)
+	s.EnableHistory(4)
+
+	x := casso.New()
+	_, err := s.AddConstraint(x.EQ(10))
+	require.NoError(t, err)
+	require.NoError(t, s.Edit(casso.New(), casso.Strong))
+	require.Len(t, ch, 1)
+
+	// released solvers are reset without notifying their subscribers, and with history disabled
+
+	casso.ReleaseSolver(s)
+	require.Len(t, ch, 1)
+
+	// solvers acquired afterwards behave as though newly created
+
+	s = casso.AcquireSolver()
+	defer casso.ReleaseSolver(s)
+
+	require.Empty(t, s.Constraints())
+	require.Equal(t, casso.ErrNothingToUndo, s.Undo())
+
+	_, err = s.AddConstraint(x.EQ(20))
+	require.NoError(t, err)
+	require.EqualValues(t, 20, s.Val(x))
+	require.Len(t, ch, 1)
+
+	// solvers not acquired via AcquireSolver are left untouched
+
+	other := s.Clone()
+	casso.ReleaseSolver(other)
+	require.Len(t, other.Constraints(), 1)
+	require.EqualValues(t, 20, other.Val(x))
+}
+
+func TestReleaseSolverTwice(t *testing.T) {
+	s := casso.AcquireSolver()
+	casso.ReleaseSolver(s)
+	casso.ReleaseSolver(s)
+
+	// the solver is only handed out once
+
+	a, b := casso.AcquireSolver(), casso.AcquireSolver()
+	defer casso.ReleaseSolver(a)
+	defer casso.ReleaseSolver(b)
+	require.NotSame(t, a, b)
+}
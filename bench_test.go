@@ -0,0 +1,297 @@
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"testing"
+)
+
+// widgetLayout is a layout of widgets laid out in rows of ten within a window whose width is an edit
+// variable, as built by buildWidgetLayout.
+type widgetLayout struct {
+	window  casso.Symbol
+	markers []casso.Symbol
+}
+
+// buildWidgetLayout lays out n widgets in rows of ten within a window 800 wide. See addWidgets.
+func buildWidgetLayout(s *casso.Solver, n int) widgetLayout {
+	l := widgetLayout{window: casso.New()}
+
+	if err := s.Edit(l.window, casso.Strong); err != nil {
+		panic(err)
+	}
+	if err := s.Suggest(l.window, 800); err != nil {
+		panic(err)
+	}
+
+	l.markers = addWidgets(s, l.window, n)
+
+	return l
+}
+
+// addWidgets lays out n widgets in rows of ten, and returns the markers of their constraints.
+// Widgets within a row are placed left to right with a gap between them, prefer to be 40 wide but
+// may shrink to 10, and must fit within the window. Rows are stacked top to bottom, each being 20
+// high.
+func addWidgets(s *casso.Solver, window casso.Symbol, n int) []casso.Symbol {
+	var markers []casso.Symbol
+
+	add := func(priority casso.Priority, cell casso.Constraint) {
+		marker, err := s.AddConstraintWithPriority(priority, cell)
+		if err != nil {
+			panic(err)
+		}
+		markers = append(markers, marker)
+	}
+
+	var top casso.Symbol
+	var prevLeft, prevWidth casso.Symbol
+
+	for i := 0; i < n; i++ {
+		left, width := casso.New(), casso.New()
+
+		if i%10 == 0 {
+			next := casso.New()
+			if top.Zero() {
+				add(casso.Required, next.EQ(0))
+			} else {
+				add(casso.Required, casso.NewConstraint(casso.EQ, -20, next.T(1), top.T(-1)))
+			}
+			top = next
+			add(casso.Required, left.GTE(0))
+		} else {
+			add(casso.Required, casso.NewConstraint(casso.GTE, -4, left.T(1), prevLeft.T(-1), prevWidth.T(-1)))
+		}
+
+		add(casso.Required, width.GTE(10))
+		add(casso.Weak, width.EQ(40))
+		add(casso.Required, casso.NewConstraint(casso.LTE, 0, left.T(1), width.T(1), window.T(-1)))
+
+		prevLeft, prevWidth = left, width
+	}
+
+	return markers
+}
+
+func BenchmarkLayout500(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		buildWidgetLayout(casso.NewSolver(), 500)
+	}
+}
+
+// BenchmarkRebuildHinted500 re-creates the layout of BenchmarkLayout500 the way it would be restored
+// from a serialized layout, with the window registered as an edit variable only once all widgets are
+// laid out. Hinting the width of the window beforehand has the widgets laid out against it rather
+// than against a window of zero width, which takes over a hundred times as long.
+func BenchmarkRebuildHinted500(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		s := casso.NewSolver()
+		window := casso.New()
+		if err := s.Hint(window, 800); err != nil {
+			b.Fatal(err)
+		}
+		addWidgets(s, window, 500)
+		if err := s.Edit(window, casso.Strong); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkResize500(b *testing.B) {
+	s := casso.NewSolver()
+	l := buildWidgetLayout(s, 500)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := s.Suggest(l.window, float64(300+i%900)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkTeardownHalf500(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		s := casso.NewSolver()
+		l := buildWidgetLayout(s, 500)
+		b.StartTimer()
+
+		for j := len(l.markers) - 1; j >= len(l.markers)/2; j-- {
+			if err := s.RemoveConstraint(l.markers[j]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkTeardownHalf500Batched(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		s := casso.NewSolver()
+		l := buildWidgetLayout(s, 500)
+		b.StartTimer()
+
+		s.BeginRemoval()
+		for j := len(l.markers) - 1; j >= len(l.markers)/2; j-- {
+			if err := s.RemoveConstraint(l.markers[j]); err != nil {
+				b.Fatal(err)
+			}
+		}
+		if err := s.EndRemoval(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAddRemoveConstraint(b *testing.B) {
+	s := casso.NewSolver()
+	l := buildWidgetLayout(s, 100)
+	x := casso.New()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		marker, err := s.AddConstraintWithPriority(casso.Medium, casso.NewConstraint(casso.LTE, 0, x.T(1), l.window.T(-1)))
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err := s.RemoveConstraint(marker); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkSubstituteDense lays out 200 widgets in a flow, with the offset of each widget being the
+// sum of the widths of all widgets before it. Rows thus span many terms, and every width pinned down
+// afterwards is substituted into the rows of all offsets past it.
+func BenchmarkSubstituteDense(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		s := casso.NewSolver()
+
+		widths := make([]casso.Symbol, 200)
+		terms := make([]casso.Term, 0, len(widths)+1)
+		for j := range widths {
+			offset := casso.New()
+			terms = append(terms[:0], offset.T(1))
+			for _, width := range widths[:j] {
+				terms = append(terms, width.T(-1))
+			}
+			if _, err := s.AddConstraint(casso.NewConstraint(casso.EQ, 0, terms...)); err != nil {
+				b.Fatal(err)
+			}
+			widths[j] = casso.New()
+		}
+
+		for _, width := range widths {
+			if _, err := s.AddConstraintWithPriority(casso.Weak, width.EQ(40)); err != nil {
+				b.Fatal(err)
+			}
+			if _, err := s.AddConstraint(width.GTE(10)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// gridLayout is a grid of cells whose columns and rows share the width and height of a window
+// between them, as built by buildGrid.
+type gridLayout struct {
+	width, height casso.Symbol
+}
+
+// buildGrid lays out a grid of cols by rows cells within a window of 100 by 40, such as that of a
+// terminal UI. Columns prefer to share the width of the window equally but are at least 1 wide, and
+// likewise for rows. Every cell is pinned to the lines of its column and row, amounting to two
+// constraints per cell.
+func buildGrid(s *casso.Solver, cols, rows int) gridLayout {
+	l := gridLayout{width: casso.New(), height: casso.New()}
+
+	for _, window := range [...]casso.Symbol{l.width, l.height} {
+		if err := s.Edit(window, casso.Strong); err != nil {
+			panic(err)
+		}
+	}
+	if err := s.Suggest(l.width, 100); err != nil {
+		panic(err)
+	}
+	if err := s.Suggest(l.height, 40); err != nil {
+		panic(err)
+	}
+
+	add := func(priority casso.Priority, cell casso.Constraint) {
+		if _, err := s.AddConstraintWithPriority(priority, cell); err != nil {
+			panic(err)
+		}
+	}
+
+	tracks := func(window casso.Symbol, n int) []casso.Symbol {
+		lines := make([]casso.Symbol, n+1)
+		for i := range lines {
+			lines[i] = casso.New()
+		}
+		add(casso.Required, lines[0].EQ(0))
+		for i := 0; i < n; i++ {
+			add(casso.Required, casso.NewConstraint(casso.GTE, -1, lines[i+1].T(1), lines[i].T(-1)))
+			add(casso.Weak, casso.NewConstraint(casso.EQ, 0, lines[i+1].T(float64(n)), lines[i].T(-float64(n)), window.T(-1)))
+		}
+		add(casso.Required, casso.NewConstraint(casso.EQ, 0, lines[n].T(1), window.T(-1)))
+		return lines
+	}
+
+	xs, ys := tracks(l.width, cols), tracks(l.height, rows)
+
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			left, top := casso.New(), casso.New()
+			add(casso.Required, casso.NewConstraint(casso.EQ, 0, left.T(1), xs[c].T(-1)))
+			add(casso.Required, casso.NewConstraint(casso.EQ, 0, top.T(1), ys[r].T(-1)))
+		}
+	}
+
+	return l
+}
+
+func BenchmarkGrid100x40(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		buildGrid(casso.NewSolver(), 100, 40)
+	}
+}
+
+// BenchmarkResizeGrid100x40 resizes the window of the grid of BenchmarkGrid100x40 in both dimensions,
+// moving every cell of the grid. The window is at times shrunk past the size the cells may shrink
+// to, such that edits that cannot be satisfied are pivoted away. Resizing is to take no more than a
+// few milliseconds.
+func BenchmarkResizeGrid100x40(b *testing.B) {
+	s := casso.NewSolver()
+	l := buildGrid(s, 100, 40)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := s.Suggest(l.width, float64(80+i%120)); err != nil {
+			b.Fatal(err)
+		}
+		if err := s.Suggest(l.height, float64(24+i%40)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
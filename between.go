@@ -0,0 +1,44 @@
+package casso
+
+// Between returns the pair of constraints low <= x and x <= high, clamping x to a range.
+func Between(x Symbol, low, high float64) (Constraint, Constraint) {
+	return BetweenExpr(x.Expr(), low, high)
+}
+
+// BetweenExpr returns the pair of constraints low <= e and e <= high, clamping e to a range.
+func BetweenExpr(e Expr, low, high float64) (Constraint, Constraint) {
+	return e.GTE(low), e.LTE(high)
+}
+
+// AddBetween clamps e to the range [low, high] by adding the constraints returned by BetweenExpr
+// with the given priority, and returns the markers of the lower and upper bound. Should either
+// constraint fail to be added, neither is left installed.
+func (s *Solver) AddBetween(priority Priority, e Expr, low, high float64) (Symbol, Symbol, error) {
+	lc, hc := BetweenExpr(e, low, high)
+
+	lm, err := s.AddConstraintWithPriority(priority, lc)
+	if err != nil {
+		return zero, zero, err
+	}
+	hm, err := s.AddConstraintWithPriority(priority, hc)
+	if err != nil {
+		_ = s.RemoveConstraint(lm)
+		return zero, zero, err
+	}
+	return lm, hm, nil
+}
+
+// AddBetweenToGroup clamps e to the range [low, high] as AddBetween does, adding both constraints
+// under a group.
+func (s *Solver) AddBetweenToGroup(g Group, priority Priority, e Expr, low, high float64) (Symbol, Symbol, error) {
+	grp, exists := s.groups[g]
+	if !exists {
+		return zero, zero, ErrBadGroup
+	}
+	lm, hm, err := s.AddBetween(priority, e, low, high)
+	if err != nil {
+		return zero, zero, err
+	}
+	grp.markers = append(grp.markers, lm, hm)
+	return lm, hm, nil
+}
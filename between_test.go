@@ -0,0 +1,47 @@
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestBetween(t *testing.T) {
+	s := casso.NewSolver()
+
+	x := casso.New()
+	require.NoError(t, s.Edit(x, casso.Strong))
+
+	lo, hi, err := s.AddBetween(casso.Required, x.Expr(), 10, 20)
+	require.NoError(t, err)
+	require.True(t, s.HasConstraint(lo))
+	require.True(t, s.HasConstraint(hi))
+
+	require.NoError(t, s.Suggest(x, 50))
+	require.EqualValues(t, 20, s.Val(x))
+	require.NoError(t, s.Suggest(x, -50))
+	require.EqualValues(t, 10, s.Val(x))
+
+	// Ranges conflicting with installed ranges leave no constraint behind.
+
+	_, _, err = s.AddBetween(casso.Required, x.Expr(), 30, 40)
+	require.Error(t, err)
+	require.Len(t, s.Constraints(), 3)
+
+	// Ranges may be removed together by adding them under a group.
+
+	y := casso.New()
+	g := s.NewGroup()
+	_, _, err = s.AddBetweenToGroup(g, casso.Required, x.Plus(y), 0, 100)
+	require.NoError(t, err)
+	require.NoError(t, s.RemoveGroup(g))
+
+	require.NoError(t, s.RemoveConstraint(lo))
+	require.NoError(t, s.RemoveConstraint(hi))
+	require.NoError(t, s.Suggest(x, 50))
+	require.EqualValues(t, 50, s.Val(x))
+
+	low, high := casso.Between(x, 1, 2)
+	require.Equal(t, x.GTE(1), low)
+	require.Equal(t, x.LTE(2), high)
+}
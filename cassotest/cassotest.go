@@ -0,0 +1,44 @@
+// Package cassotest provides helpers for testing code built on top of casso.
+package cassotest
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// Tolerance is the absolute difference under which a solved value is considered equal to the value
+// it was expected to be.
+const Tolerance = 1e-6
+
+// Add installs constraints into s with the given priority, failing the test if any of them could
+// not be installed. It returns the markers of the installed constraints.
+func Add(t testing.TB, s *casso.Solver, priority casso.Priority, cs ...casso.Constraint) []casso.Symbol {
+	t.Helper()
+
+	markers := make([]casso.Symbol, 0, len(cs))
+	for i, c := range cs {
+		marker, err := s.AddConstraintWithPriority(priority, c)
+		require.NoErrorf(t, err, "failed to add constraint %d", i)
+		markers = append(markers, marker)
+	}
+	return markers
+}
+
+// Suggest registers id as an edit variable with the given priority, and suggests a value for it,
+// failing the test if either step fails.
+func Suggest(t testing.TB, s *casso.Solver, id casso.Symbol, priority casso.Priority, val float64) {
+	t.Helper()
+
+	require.NoError(t, s.Edit(id, priority))
+	require.NoError(t, s.Suggest(id, val))
+}
+
+// RequireVals fails the test if any of the variables in want did not solve to its expected value.
+func RequireVals(t testing.TB, s *casso.Solver, want map[casso.Symbol]float64) {
+	t.Helper()
+
+	for id, val := range want {
+		require.InDeltaf(t, val, s.Val(id), Tolerance, "unexpected value for symbol %d", id)
+	}
+}
@@ -0,0 +1,25 @@
+package cassotest_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/lithdew/casso/cassotest"
+	"testing"
+)
+
+func TestHelpers(t *testing.T) {
+	s := casso.NewSolver()
+
+	x := casso.New()
+	y := casso.New()
+
+	cassotest.Suggest(t, s, x, casso.Strong, 10)
+	markers := cassotest.Add(t, s, casso.Required,
+		casso.NewConstraint(casso.EQ, 0, y.T(3), x.T(-1)),
+		casso.NewConstraint(casso.GTE, 0, y.T(1)),
+	)
+	if len(markers) != 2 {
+		t.Fatalf("expected 2 markers, got %d", len(markers))
+	}
+
+	cassotest.RequireVals(t, s, map[casso.Symbol]float64{x: 10, y: 10.0 / 3})
+}
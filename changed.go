@@ -0,0 +1,26 @@
+package casso
+
+// Changed reports whether the value of a variable changed since it was last read via Val, Val32, or
+// Vals32, such that render loops may skip redrawing whatever depends on variables whose values did
+// not change:
+//
+//	for _, id := range ids {
+//		if s.Changed(id) {
+//			draw(id, s.Val(id))
+//		}
+//	}
+//
+// Variables never read are reported as changed. Changed compares against the values cached by Val
+// without modifying the cache, such that it keeps reporting a variable as changed until its value is
+// read. Values whose rows did not change since they were read are reported as unchanged without
+// their rows being looked up.
+func (s *Solver) Changed(id Symbol) bool {
+	if s.dirty && s.opts.lazy {
+		_ = s.Solve()
+	}
+	c, read := s.tabs.vals[id]
+	if !read {
+		return true
+	}
+	return c.stale && !s.eqz(s.val(id)-c.val)
+}
@@ -0,0 +1,154 @@
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestChanged(t *testing.T) {
+	s := casso.NewSolver()
+
+	left, width, right := casso.New(), casso.New(), casso.New()
+
+	_, err := s.AddConstraint(casso.NewConstraint(casso.EQ, 0, right.T(1), left.T(-1), width.T(-1)))
+	require.NoError(t, err)
+	_, err = s.AddConstraint(left.EQ(10))
+	require.NoError(t, err)
+	require.NoError(t, s.Edit(width, casso.Strong))
+	require.NoError(t, s.Suggest(width, 100))
+
+	// variables never read are reported as changed, and checking does not count as reading
+
+	require.True(t, s.Changed(left))
+	require.True(t, s.Changed(left))
+
+	require.EqualValues(t, 10, s.Val(left))
+	require.EqualValues(t, 110, s.Val(right))
+	require.False(t, s.Changed(left))
+	require.False(t, s.Changed(right))
+
+	// only variables whose values changed since last read are reported as changed, until read
+
+	require.NoError(t, s.Suggest(width, 50))
+	require.False(t, s.Changed(left))
+	require.True(t, s.Changed(right))
+	require.True(t, s.Changed(right))
+
+	require.EqualValues(t, 60, s.Val(right))
+	require.False(t, s.Changed(right))
+
+	require.NoError(t, s.Suggest(width, 80))
+	require.Equal(t, []float32{90}, s.Vals32(nil, []casso.Symbol{right}))
+	require.False(t, s.Changed(right))
+
+	// values read via Vals, or by the solver on its own behalf, are not read
+
+	require.NoError(t, s.Suggest(width, 70))
+	require.EqualValues(t, 80, s.Vals()[right])
+	require.NoError(t, s.AddStay(right, casso.Weak))
+	require.True(t, s.Changed(right))
+	require.EqualValues(t, 80, s.Val(right))
+
+	// a value changing back to the value last read is not a change
+
+	require.NoError(t, s.Suggest(width, 20))
+	require.NoError(t, s.Suggest(width, 70))
+	require.False(t, s.Changed(right))
+	require.EqualValues(t, 80, s.Val(right))
+
+	s.Reset()
+	require.True(t, s.Changed(left))
+	require.True(t, s.Changed(right))
+	require.True(t, s.Changed(width)) // never read
+	require.EqualValues(t, 0, s.Val(right))
+	require.False(t, s.Changed(right))
+}
+
+func TestChangedLazySolve(t *testing.T) {
+	s := casso.NewSolver(casso.WithLazySolve())
+
+	x := casso.New()
+	require.NoError(t, s.Edit(x, casso.Strong))
+	require.EqualValues(t, 0, s.Val(x))
+	require.False(t, s.Changed(x))
+
+	require.NoError(t, s.Suggest(x, 10))
+	require.True(t, s.Changed(x))
+	require.EqualValues(t, 10, s.Val(x))
+	require.False(t, s.Changed(x))
+}
+
+func TestChangedRollback(t *testing.T) {
+	s := casso.NewSolver()
+
+	x := casso.New()
+	require.NoError(t, s.Edit(x, casso.Strong))
+	require.NoError(t, s.Suggest(x, 10))
+	require.EqualValues(t, 10, s.Val(x))
+
+	// values read within a transaction that is rolled back are compared against the values rolled
+	// back to
+
+	tx := s.Begin()
+	require.NoError(t, s.Suggest(x, 20))
+	require.EqualValues(t, 20, s.Val(x))
+	require.False(t, s.Changed(x))
+	require.NoError(t, tx.Rollback())
+
+	require.True(t, s.Changed(x))
+	require.EqualValues(t, 10, s.Val(x))
+	require.False(t, s.Changed(x))
+}
+
+func TestValCache(t *testing.T) {
+	s := casso.NewSolver()
+
+	x, y := casso.New(), casso.New()
+	_, err := s.AddConstraint(casso.NewConstraint(casso.EQ, 0, y.T(1), x.T(-2)))
+	require.NoError(t, err)
+	require.NoError(t, s.Edit(x, casso.Strong))
+
+	// cached values follow the rows of their variables as they are pivoted, substituted, and shifted
+
+	for _, val := range []float64{10, 20, 20, -5} {
+		require.NoError(t, s.Suggest(x, val))
+		require.EqualValues(t, val, s.Val(x))
+		require.EqualValues(t, 2*val, s.Val(y))
+	}
+
+	c := s.Clone()
+	require.False(t, c.Changed(y))
+	require.NoError(t, c.Suggest(x, 1))
+	require.True(t, c.Changed(y))
+	require.False(t, s.Changed(y))
+
+	s.Compact()
+	require.False(t, s.Changed(y))
+	require.EqualValues(t, -10, s.Val(y))
+
+	require.NoError(t, s.RemoveEdit(x))
+	require.EqualValues(t, 0, s.Val(x))
+	require.EqualValues(t, 0, s.Val(y))
+}
+
+func BenchmarkChanged(b *testing.B) {
+	s := casso.NewSolver()
+	buildWidgetLayout(s, 500)
+
+	ids := make([]casso.Symbol, 0, len(s.Vals()))
+	for id := range s.Vals() {
+		ids = append(ids, id)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, id := range ids {
+			if s.Changed(id) {
+				_ = s.Val(id)
+			}
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package casso
+
+// Clone returns a deep copy of the solver, such that constraints may be added to, removed from, or
+// suggested to either solver without affecting the other. Constraint markers and symbols remain
+// valid for both solvers. Symbol data is copied shallowly, and subscribers and observers are not
+// carried over to the clone.
+func (s *Solver) Clone() *Solver {
+	c := s.copyState()
+	for _, tab := range s.tabs.entries {
+		row := tab.row
+		row.expr.terms = append(c.slab.carve(len(row.expr.terms)), row.expr.terms...)
+		c.tabs.set(tab.basic, row)
+	}
+	c.tabs.vals = s.tabs.copyVals()
+	c.indexColumns()
+	return c
+}
+
+// copyState returns a copy of all state of the solver but the rows of its tableau, which are left
+// for Clone and Fork to fill in along with the column index.
+func (s *Solver) copyState() *Solver {
+	c := &Solver{
+		tabs:       newTabs(s.tabs.len()),
+		edits:      make(map[Symbol]Edit, len(s.edits)),
+		tags:       make(map[Symbol]Tag, len(s.tags)),
+		infeasible: make([]Symbol, 0, cap(s.infeasible)),
+		objective:  s.objective.clone(),
+		artificial: s.artificial.clone(),
+		opts:       s.opts,
+		suggested:  s.suggested,
+		count:      s.count,
+		zeroed:     s.zeroed,
+		dirty:      s.dirty,
+		removing:   s.removing,
+		lastGroup:  s.lastGroup,
+	}
+
+	for _, symbol := range s.infeasible {
+		c.markInfeasible(symbol)
+	}
+	for symbol, edit := range s.edits {
+		edit.tag.cell = edit.tag.cell.clone()
+		c.edits[symbol] = edit
+	}
+	if s.stays != nil {
+		c.stays = make(map[Symbol]Edit, len(s.stays))
+		for symbol, stay := range s.stays {
+			stay.tag.cell = stay.tag.cell.clone()
+			c.stays[symbol] = stay
+		}
+	}
+	for symbol, tag := range s.tags {
+		tag.cell = tag.cell.clone()
+		c.tags[symbol] = tag
+	}
+
+	if s.data != nil {
+		c.data = make(map[Symbol]interface{}, len(s.data))
+		for symbol, v := range s.data {
+			c.data[symbol] = v
+		}
+	}
+	if s.names != nil {
+		c.names = make(map[Symbol]string, len(s.names))
+		for symbol, name := range s.names {
+			c.names[symbol] = name
+		}
+	}
+	if s.groups != nil {
+		c.groups = make(map[Group]*group, len(s.groups))
+		for g, grp := range s.groups {
+			c.groups[g] = &group{
+				markers: append([]Symbol(nil), grp.markers...),
+				edits:   append([]Symbol(nil), grp.edits...),
+			}
+		}
+	}
+	if s.hints != nil {
+		c.hints = make(map[Symbol]struct{}, len(s.hints))
+		for id := range s.hints {
+			c.hints[id] = struct{}{}
+		}
+	}
+	if s.pending != nil {
+		c.pending = make(map[Symbol]float64, len(s.pending))
+		for id, val := range s.pending {
+			c.pending[id] = val
+		}
+	}
+	if s.fetched != nil {
+		c.fetched = make(map[Symbol]float64, len(s.fetched))
+		for id, val := range s.fetched {
+			c.fetched[id] = val
+		}
+	}
+	if s.defs != nil {
+		c.defs = make(map[string]definition, len(s.defs))
+		for name, def := range s.defs {
+			c.defs[name] = def
+		}
+	}
+
+	if s.strict != nil {
+		c.strict = s.strict.clone(c)
+	}
+	if s.history != nil {
+		c.history = &history{
+			depth: s.history.depth,
+			undo:  append([]op(nil), s.history.undo...),
+			redo:  append([]op(nil), s.history.redo...),
+		}
+	}
+
+	return c
+}
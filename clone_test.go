@@ -0,0 +1,50 @@
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	s := casso.NewSolver()
+
+	x := casso.New()
+	y := casso.New()
+
+	_, err := s.AddConstraint(casso.NewConstraint(casso.EQ, -10, y.T(1), x.T(-1)))
+	require.NoError(t, err)
+	marker, err := s.AddConstraintWithPriority(casso.Weak, y.LTE(40))
+	require.NoError(t, err)
+	require.NoError(t, s.Edit(x, casso.Strong))
+	require.NoError(t, s.Suggest(x, 20))
+	s.SetSymbolData(x, "x")
+
+	c := s.Clone()
+	require.Equal(t, s.Tableau().Rows(), c.Tableau().Rows())
+	require.Equal(t, s.Constraints(), c.Constraints())
+	requireSameReport(t, s, c)
+	require.Equal(t, "x", c.SymbolData(x))
+
+	// mutating the clone does not affect the original
+
+	require.NoError(t, c.Suggest(x, 100))
+	require.NoError(t, c.RemoveConstraint(marker))
+	_, err = c.AddConstraint(y.GTE(200))
+	require.NoError(t, err)
+	c.SetSymbolData(x, "clone")
+
+	require.EqualValues(t, 190, c.Val(x))
+	require.EqualValues(t, 200, c.Val(y))
+
+	require.EqualValues(t, 20, s.Val(x))
+	require.EqualValues(t, 30, s.Val(y))
+	require.Equal(t, "x", s.SymbolData(x))
+
+	// and vice versa
+
+	require.NoError(t, s.Suggest(x, 35))
+	require.EqualValues(t, 35, s.Val(x))
+	require.EqualValues(t, 190, c.Val(x))
+	require.NoError(t, s.RemoveConstraint(marker))
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/lithdew/casso"
+	"github.com/lithdew/casso/encode"
+	"github.com/lithdew/casso/internal/ident"
+	"go/format"
+	"go/token"
+	"strconv"
+	"text/template"
+)
+
+var ops = map[string]string{
+	casso.EQ.String():  "casso.EQ",
+	casso.GTE.String(): "casso.GTE",
+	casso.LTE.String(): "casso.LTE",
+}
+
+var priorities = map[casso.Priority]string{
+	casso.Required: "casso.Required",
+	casso.Strong:   "casso.Strong",
+	casso.Medium:   "casso.Medium",
+	casso.Weak:     "casso.Weak",
+}
+
+func float(val float64) string {
+	return strconv.FormatFloat(val, 'g', -1, 64)
+}
+
+func priority(p casso.Priority) string {
+	if name, ok := priorities[p]; ok {
+		return name
+	}
+	return "casso.Priority(" + float(float64(p)) + ")"
+}
+
+type compiledVar struct {
+	Field string
+	Name  string
+}
+
+type compiledRule struct {
+	Priority string
+	Op       string
+	Constant string
+	Terms    []string
+}
+
+type compiledEdit struct {
+	Field    string
+	Priority string
+	Value    string
+}
+
+func compile(spec encode.Spec, pkg, name string) ([]byte, error) {
+	if !token.IsIdentifier(pkg) {
+		return nil, fmt.Errorf("invalid package name %q", pkg)
+	}
+	if !token.IsIdentifier(name) {
+		return nil, fmt.Errorf("invalid name %q", name)
+	}
+
+	// resolve the spec against placeholder symbols to validate it, and to resolve its priorities
+
+	symbols := make(map[string]casso.Symbol, len(spec.Variables))
+	fields := make(map[string]string, len(spec.Variables))
+
+	vars := make([]compiledVar, 0, len(spec.Variables))
+	taken := make(map[string]string, len(spec.Variables))
+
+	for _, v := range spec.Variables {
+		if _, exists := symbols[v]; exists {
+			return nil, fmt.Errorf("variable %q is declared more than once", v)
+		}
+		field := ident.Exported(v)
+		if !token.IsIdentifier(field) {
+			return nil, fmt.Errorf("variable %q does not map to a valid Go identifier", v)
+		}
+		if prev, exists := taken[field]; exists {
+			return nil, fmt.Errorf("variables %q and %q both map to the Go identifier %q", prev, v, field)
+		}
+		taken[field] = v
+
+		symbols[v] = casso.New()
+		fields[v] = field
+		vars = append(vars, compiledVar{Field: field, Name: v})
+	}
+
+	rules := make([]compiledRule, 0, len(spec.Constraints))
+	for _, c := range spec.Constraints {
+		rule, err := c.Rule(symbols)
+		if err != nil {
+			return nil, err
+		}
+
+		compiled := compiledRule{
+			Priority: priority(rule.Priority),
+			Op:       ops[c.Op],
+			Constant: float(c.Constant),
+		}
+		for _, term := range c.Terms {
+			compiled.Terms = append(compiled.Terms, "v."+fields[term.Var]+".T("+float(term.Coeff)+")")
+		}
+
+		rules = append(rules, compiled)
+	}
+
+	edits := make([]compiledEdit, 0, len(spec.Edits))
+	for _, e := range spec.Edits {
+		if _, ok := symbols[e.Var]; !ok {
+			return nil, fmt.Errorf("edit references undeclared variable %q", e.Var)
+		}
+
+		p, err := casso.ParsePriority(e.Priority)
+		if err != nil {
+			return nil, err
+		}
+
+		edits = append(edits, compiledEdit{
+			Field:    fields[e.Var],
+			Priority: priority(p),
+			Value:    float(e.Value),
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, struct {
+		Package string
+		Name    string
+		Vars    []compiledVar
+		Rules   []compiledRule
+		Edits   []compiledEdit
+	}{pkg, name, vars, rules, edits}); err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+var tmpl = template.Must(template.New("").Parse(`// Code generated by cassoc. DO NOT EDIT.
+
+package {{.Package}}
+
+import "github.com/lithdew/casso"
+
+// {{.Name}}Vars holds the variables of the compiled spec.
+type {{.Name}}Vars struct {
+{{- range .Vars}}
+	{{.Field}} casso.Symbol // {{printf "%q" .Name}}
+{{- end}}
+}
+
+// New{{.Name}}Vars allocates the variables of the compiled spec.
+func New{{.Name}}Vars() {{.Name}}Vars {
+	return {{.Name}}Vars{
+{{- range .Vars}}
+		{{.Field}}: casso.New(),
+{{- end}}
+	}
+}
+
+// Vars returns the variables of the compiled spec keyed by name.
+func (v {{.Name}}Vars) Vars() map[string]casso.Symbol {
+	return map[string]casso.Symbol{
+{{- range .Vars}}
+		{{printf "%q" .Name}}: v.{{.Field}},
+{{- end}}
+	}
+}
+
+// Rules returns the constraints of the compiled spec.
+func (v {{.Name}}Vars) Rules() []casso.Rule {
+	return []casso.Rule{
+{{- range .Rules}}
+		{Priority: {{.Priority}}, Constraint: casso.NewConstraint({{.Op}}, {{.Constant}}{{range .Terms}}, {{.}}{{end}})},
+{{- end}}
+	}
+}
+
+// Install installs the constraints and edit variables of the compiled spec into s.
+func (v {{.Name}}Vars) Install(s *casso.Solver) error {
+	for _, rule := range v.Rules() {
+		if _, err := s.AddConstraintWithPriority(rule.Priority, rule.Constraint); err != nil {
+			return err
+		}
+	}
+{{- range .Edits}}
+	if err := s.Edit(v.{{.Field}}, {{.Priority}}); err != nil {
+		return err
+	}
+	if err := s.Suggest(v.{{.Field}}, {{.Value}}); err != nil {
+		return err
+	}
+{{- end}}
+	return nil
+}
+`))
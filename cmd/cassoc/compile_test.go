@@ -0,0 +1,47 @@
+package main
+
+import (
+	"github.com/lithdew/casso/encode"
+	"github.com/stretchr/testify/require"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestCompile(t *testing.T) {
+	spec := encode.Spec{
+		Variables: []string{"sidebar.left", "sidebar.width"},
+		Constraints: []encode.SpecConstraint{
+			{Terms: []encode.SpecTerm{{Var: "sidebar.left", Coeff: 1}}, Constant: -10, Op: ">="},
+			{Terms: []encode.SpecTerm{{Var: "sidebar.width", Coeff: 1}, {Var: "sidebar.left", Coeff: -0.5}}, Op: "=", Priority: "weak"},
+			{Terms: []encode.SpecTerm{{Var: "sidebar.width", Coeff: 1}}, Constant: -300, Op: "<=", Priority: "12.5"},
+		},
+		Edits: []encode.SpecEdit{{Var: "sidebar.width", Priority: "strong", Value: 200}},
+	}
+
+	src, err := compile(spec, "ui", "Layout")
+	require.NoError(t, err)
+
+	_, err = parser.ParseFile(token.NewFileSet(), "layout_gen.go", src, 0)
+	require.NoError(t, err)
+
+	require.Contains(t, string(src), "SidebarWidth casso.Symbol // \"sidebar.width\"")
+	require.Contains(t, string(src), "{Priority: casso.Required, Constraint: casso.NewConstraint(casso.GTE, -10, v.SidebarLeft.T(1))},")
+	require.Contains(t, string(src), "{Priority: casso.Weak, Constraint: casso.NewConstraint(casso.EQ, 0, v.SidebarWidth.T(1), v.SidebarLeft.T(-0.5))},")
+	require.Contains(t, string(src), "{Priority: casso.Priority(12.5), Constraint: casso.NewConstraint(casso.LTE, -300, v.SidebarWidth.T(1))},")
+	require.Contains(t, string(src), "if err := s.Edit(v.SidebarWidth, casso.Strong); err != nil {")
+	require.Contains(t, string(src), "if err := s.Suggest(v.SidebarWidth, 200); err != nil {")
+}
+
+func TestCompileInvalidSpec(t *testing.T) {
+	_, err := compile(encode.Spec{
+		Variables: []string{"x"},
+		Constraints: []encode.SpecConstraint{
+			{Terms: []encode.SpecTerm{{Var: "x", Coeff: 1}}, Op: "=="},
+		},
+	}, "ui", "Layout")
+	require.Error(t, err)
+
+	_, err = compile(encode.Spec{Variables: []string{"a-b", "a_b"}}, "ui", "Layout")
+	require.Error(t, err)
+}
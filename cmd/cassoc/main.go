@@ -0,0 +1,59 @@
+// Command cassoc compiles a declarative encode.Spec into Go source, such that the constraints of the
+// spec may be installed without parsing the spec at runtime.
+//
+// For a spec compiled with -name Layout, a LayoutVars struct is generated with one casso.Symbol field
+// per variable in the spec, alongside a constructor allocating its variables, a Rules method
+// returning the constraints of the spec, and an Install method installing the constraints and edit
+// variables of the spec into a solver. Typical usage is via go:generate:
+//
+//	//go:generate cassoc -spec layout.json -name Layout -o layout_gen.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/lithdew/casso/encode"
+	"io/ioutil"
+	"os"
+)
+
+func main() {
+	specPath := flag.String("spec", "", "path to the spec to compile")
+	outPath := flag.String("o", "", "path to write generated code to (default: stdout)")
+	pkg := flag.String("package", os.Getenv("GOPACKAGE"), "package name of the generated code")
+	name := flag.String("name", "Spec", "prefix of the names of generated declarations")
+	flag.Parse()
+
+	if err := run(*specPath, *outPath, *pkg, *name); err != nil {
+		fmt.Fprintln(os.Stderr, "cassoc:", err)
+		os.Exit(1)
+	}
+}
+
+func run(specPath, outPath, pkg, name string) error {
+	if specPath == "" {
+		return fmt.Errorf("no spec specified")
+	}
+
+	f, err := os.Open(specPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	spec, err := encode.ReadSpec(f)
+	if err != nil {
+		return err
+	}
+
+	src, err := compile(spec, pkg, name)
+	if err != nil {
+		return err
+	}
+
+	if outPath == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return ioutil.WriteFile(outPath, src, 0644)
+}
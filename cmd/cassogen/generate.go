@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/lithdew/casso/internal/ident"
+	"go/format"
+	"go/token"
+	"text/template"
+)
+
+type Schema struct {
+	Package string `json:"package"`
+	Views   []View `json:"views"`
+}
+
+type View struct {
+	Name       string   `json:"name"`
+	Attributes []string `json:"attributes"`
+}
+
+func parseSchema(buf []byte) (Schema, error) {
+	var schema Schema
+	if err := json.Unmarshal(buf, &schema); err != nil {
+		return schema, err
+	}
+	return schema, nil
+}
+
+type genField struct {
+	Name  string // exported Go identifier
+	Label string // "view.attribute"
+}
+
+type genView struct {
+	Name   string // exported Go identifier
+	Label  string
+	Fields []genField
+}
+
+func generate(schema Schema) ([]byte, error) {
+	if !token.IsIdentifier(schema.Package) {
+		return nil, fmt.Errorf("invalid package name %q", schema.Package)
+	}
+
+	views := make([]genView, 0, len(schema.Views))
+	seen := make(map[string]string)
+
+	for _, v := range schema.Views {
+		view := genView{Name: ident.Exported(v.Name), Label: v.Name}
+		if !token.IsIdentifier(view.Name) {
+			return nil, fmt.Errorf("view %q does not map to a valid Go identifier", v.Name)
+		}
+		if prev, exists := seen[view.Name]; exists {
+			return nil, fmt.Errorf("views %q and %q both map to the Go identifier %q", prev, v.Name, view.Name)
+		}
+		seen[view.Name] = v.Name
+
+		fields := make(map[string]string)
+		for _, attr := range v.Attributes {
+			field := genField{Name: ident.Exported(attr), Label: v.Name + "." + attr}
+			if !token.IsIdentifier(field.Name) {
+				return nil, fmt.Errorf("attribute %q of view %q does not map to a valid Go identifier", attr, v.Name)
+			}
+			if prev, exists := fields[field.Name]; exists {
+				return nil, fmt.Errorf("attributes %q and %q of view %q both map to the Go identifier %q", prev, attr, v.Name, field.Name)
+			}
+			fields[field.Name] = attr
+			view.Fields = append(view.Fields, field)
+		}
+
+		views = append(views, view)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, struct {
+		Package string
+		Views   []genView
+	}{schema.Package, views}); err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+var tmpl = template.Must(template.New("").Parse(`// Code generated by cassogen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/lithdew/casso/encode"
+)
+{{range .Views}}{{$view := .}}
+// {{.Name}} holds the solver variables of the {{printf "%q" .Label}} view.
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Name}} casso.Symbol
+{{- end}}
+}
+
+// New{{.Name}} allocates the solver variables of the {{printf "%q" .Label}} view.
+func New{{.Name}}() {{.Name}} {
+	return {{.Name}}{
+{{- range .Fields}}
+		{{.Name}}: casso.New(),
+{{- end}}
+	}
+}
+
+// Register names the solver variables of the {{printf "%q" .Label}} view on r.
+func (v {{.Name}}) Register(r *encode.Recorder) {
+{{- range .Fields}}
+	r.Name(v.{{.Name}}, {{printf "%q" .Label}})
+{{- end}}
+}
+
+// Vars returns the solver variables of the {{printf "%q" .Label}} view keyed by name.
+func (v {{.Name}}) Vars() map[string]casso.Symbol {
+	return map[string]casso.Symbol{
+{{- range .Fields}}
+		{{printf "%q" .Label}}: v.{{.Name}},
+{{- end}}
+	}
+}
+{{end}}`))
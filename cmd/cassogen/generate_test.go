@@ -0,0 +1,39 @@
+package main
+
+import (
+	"github.com/stretchr/testify/require"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	schema, err := parseSchema([]byte(`{
+		"package": "ui",
+		"views": [
+			{"name": "sidebar", "attributes": ["left", "top", "content-width"]},
+			{"name": "main_panel", "attributes": ["width"]}
+		]
+	}`))
+	require.NoError(t, err)
+
+	src, err := generate(schema)
+	require.NoError(t, err)
+
+	_, err = parser.ParseFile(token.NewFileSet(), "views_gen.go", src, 0)
+	require.NoError(t, err)
+
+	require.Contains(t, string(src), "type Sidebar struct {")
+	require.Contains(t, string(src), "ContentWidth casso.Symbol")
+	require.Contains(t, string(src), "func NewMainPanel() MainPanel {")
+	require.Contains(t, string(src), `r.Name(v.ContentWidth, "sidebar.content-width")`)
+	require.Contains(t, string(src), `"main_panel.width": v.Width,`)
+}
+
+func TestGenerateInvalidIdentifier(t *testing.T) {
+	_, err := generate(Schema{Package: "ui", Views: []View{{Name: "1st"}}})
+	require.Error(t, err)
+
+	_, err = generate(Schema{Package: "ui", Views: []View{{Name: "a", Attributes: []string{"x-y", "x_y"}}}})
+	require.Error(t, err)
+}
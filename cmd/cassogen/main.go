@@ -0,0 +1,70 @@
+// Command cassogen generates Go structs holding typed solver variables for a schema of views.
+//
+// A schema is a JSON document listing views and the attributes of each view:
+//
+//	{
+//	  "package": "ui",
+//	  "views": [
+//	    {"name": "sidebar", "attributes": ["left", "top", "width", "height"]}
+//	  ]
+//	}
+//
+// For every view, a struct is generated with one casso.Symbol field per attribute, alongside a
+// constructor allocating its variables and helpers registering its variables under the names
+// "view.attribute". Typical usage is via go:generate:
+//
+//	//go:generate cassogen -schema views.json -o views_gen.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+func main() {
+	schemaPath := flag.String("schema", "", "path to the schema of views")
+	outPath := flag.String("o", "", "path to write generated code to (default: stdout)")
+	pkg := flag.String("package", "", "package name of the generated code (default: from schema, or $GOPACKAGE)")
+	flag.Parse()
+
+	if err := run(*schemaPath, *outPath, *pkg); err != nil {
+		fmt.Fprintln(os.Stderr, "cassogen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(schemaPath, outPath, pkg string) error {
+	if schemaPath == "" {
+		return fmt.Errorf("no schema specified")
+	}
+
+	buf, err := ioutil.ReadFile(schemaPath)
+	if err != nil {
+		return err
+	}
+
+	schema, err := parseSchema(buf)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case pkg != "":
+		schema.Package = pkg
+	case schema.Package == "":
+		schema.Package = os.Getenv("GOPACKAGE")
+	}
+
+	src, err := generate(schema)
+	if err != nil {
+		return err
+	}
+
+	if outPath == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return ioutil.WriteFile(outPath, src, 0644)
+}
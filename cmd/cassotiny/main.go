@@ -0,0 +1,127 @@
+// Command cassotiny checks that the solver builds, solves, and stays within its documented memory
+// ceiling on the toolchain it is built with. It lays out rows of widgets within a window, resizes
+// the window a hundred times while checking the solutions, and reports the heap held by the solver.
+// It exits with a non-zero status should a solution be wrong, or should the heap held exceed the
+// ceiling documented in the README of 4 KiB plus 2.5 KiB per installed constraint. It is run using
+// both the standard Go toolchain and TinyGo in CI:
+//
+//	go run -tags casso_tiny ./cmd/cassotiny
+//	tinygo run ./cmd/cassotiny
+package main
+
+import (
+	"fmt"
+	"github.com/lithdew/casso"
+	"os"
+	"runtime"
+)
+
+func main() {
+	failed := false
+	for _, n := range [...]int{10, 100} {
+		if err := run(n); err != nil {
+			fmt.Fprintln(os.Stderr, "cassotiny:", err)
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// run lays out n widgets in rows of ten within a window, resizes the window, and checks the heap
+// held by the solver against the ceiling.
+func run(n int) error {
+	before := heap()
+
+	s := casso.NewSolver()
+
+	window := casso.New()
+	if err := s.Edit(window, casso.Strong); err != nil {
+		return err
+	}
+
+	lefts, widths, constraints, err := addWidgets(s, window, n)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < 100; i++ {
+		size := float64(400 + 4*i)
+		if err := s.Suggest(window, size); err != nil {
+			return err
+		}
+		for j := range lefts {
+			if right := s.Val(lefts[j]) + s.Val(widths[j]); right > size+1e-6 {
+				return fmt.Errorf("%d widgets: widget %d ends at %g past a window %g wide", n, j, right, size)
+			}
+			if width := s.Val(widths[j]); width < 10-1e-6 {
+				return fmt.Errorf("%d widgets: widget %d is %g wide, narrower than 10", n, j, width)
+			}
+		}
+	}
+
+	held := heap() - before
+	ceiling := uint64(4096 + 2560*constraints)
+
+	fmt.Printf("%d widgets: %d constraints, %d KiB held, %d KiB ceiling\n", n, constraints, held/1024, ceiling/1024)
+
+	runtime.KeepAlive(s)
+
+	if held > ceiling {
+		return fmt.Errorf("%d widgets: %d bytes held exceed the ceiling of %d bytes", n, held, ceiling)
+	}
+	return nil
+}
+
+// addWidgets lays out n widgets in rows of ten. Widgets within a row are placed left to right with a
+// gap between them, prefer to be 40 wide but may shrink to 10, and must fit within the window. It
+// returns the left edges and widths of the widgets, and the number of constraints installed.
+func addWidgets(s *casso.Solver, window casso.Symbol, n int) ([]casso.Symbol, []casso.Symbol, int, error) {
+	var lefts, widths []casso.Symbol
+	var constraints int
+
+	add := func(priority casso.Priority, cell casso.Constraint) error {
+		constraints++
+		_, err := s.AddConstraintWithPriority(priority, cell)
+		return err
+	}
+
+	for i := 0; i < n; i++ {
+		left, width := casso.New(), casso.New()
+
+		var cells []casso.Rule
+		if i%10 == 0 {
+			cells = append(cells, casso.Rule{Priority: casso.Required, Constraint: left.GTE(0)})
+		} else {
+			prevLeft, prevWidth := lefts[i-1], widths[i-1]
+			cells = append(cells, casso.Rule{
+				Priority:   casso.Required,
+				Constraint: casso.NewConstraint(casso.GTE, -4, left.T(1), prevLeft.T(-1), prevWidth.T(-1)),
+			})
+		}
+		cells = append(cells,
+			casso.Rule{Priority: casso.Required, Constraint: width.GTE(10)},
+			casso.Rule{Priority: casso.Weak, Constraint: width.EQ(40)},
+			casso.Rule{Priority: casso.Required, Constraint: casso.NewConstraint(casso.LTE, 0, left.T(1), width.T(1), window.T(-1))},
+		)
+
+		for _, rule := range cells {
+			if err := add(rule.Priority, rule.Constraint); err != nil {
+				return nil, nil, 0, err
+			}
+		}
+
+		lefts, widths = append(lefts, left), append(widths, width)
+	}
+
+	return lefts, widths, constraints, nil
+}
+
+// heap returns the number of bytes allocated on the heap that are still live.
+func heap() uint64 {
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.HeapAlloc
+}
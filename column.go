@@ -0,0 +1,248 @@
+package casso
+
+import "sort"
+
+// column is the set of basic symbols of the rows of the tableau that reference a parametric symbol.
+// Most columns of layouts hold a handful of symbols, which are kept unordered in a slice and found by
+// a linear scan. A column that grows past maxListedColumn symbols is kept in a map instead, such
+// that symbols are found in constant time while a map is only allocated for the few columns wide
+// enough to need one. The slice of a column kept in a map caches the symbols of the map for
+// iterating over them.
+type column struct {
+	basics []Symbol            // basic symbols of the rows referencing the symbol, unordered
+	set    map[Symbol]struct{} // basic symbols of the rows referencing the symbol, should there be many
+	stale  bool                // whether basics no longer holds the symbols of set
+}
+
+// maxListedColumn is the number of symbols past which a column is kept in a map. Scanning a slice
+// is faster up to around this many symbols; see BenchmarkColumn.
+const maxListedColumn = 128
+
+// len returns the number of symbols in the column, which may be nil.
+func (c *column) len() int {
+	switch {
+	case c == nil:
+		return 0
+	case c.set != nil:
+		return len(c.set)
+	}
+	return len(c.basics)
+}
+
+// symbols returns the symbols in the column, which may be nil. The symbols may not be modified, and
+// are valid only until the column is next modified.
+func (c *column) symbols() []Symbol {
+	if c == nil {
+		return nil
+	}
+	if c.stale {
+		c.basics = c.basics[:0]
+		for basic := range c.set {
+			c.basics = append(c.basics, basic)
+		}
+		c.stale = false
+	}
+	return c.basics
+}
+
+// add adds basic to the column, which basic may not be in already.
+func (c *column) add(basic Symbol) {
+	if c.set != nil {
+		c.set[basic] = struct{}{}
+		c.stale = true
+		return
+	}
+	c.basics = append(c.basics, basic)
+	if len(c.basics) > maxListedColumn {
+		c.set = make(map[Symbol]struct{}, 2*len(c.basics))
+		for _, symbol := range c.basics {
+			c.set[symbol] = struct{}{}
+		}
+	}
+}
+
+// remove removes basic from the column. A column kept in a slice has its last symbol moved into the
+// place of basic.
+func (c *column) remove(basic Symbol) {
+	if c.set != nil {
+		delete(c.set, basic)
+		c.stale = true
+		return
+	}
+	for i, symbol := range c.basics {
+		if symbol == basic {
+			last := len(c.basics) - 1
+			c.basics[i] = c.basics[last]
+			c.basics = c.basics[:last]
+			return
+		}
+	}
+}
+
+// reset empties the column for reuse. Its map is kept should it have one, such that a wide column
+// emptied by a pivot and filled again by the next does not allocate a map every time.
+func (c *column) reset() {
+	c.basics = c.basics[:0]
+	for basic := range c.set {
+		delete(c.set, basic)
+	}
+	c.stale = false
+}
+
+// clone returns a copy of the column with room for extra more symbols.
+func (c *column) clone(extra int) *column {
+	res := &column{basics: make([]Symbol, len(c.basics), len(c.basics)+extra), stale: c.stale}
+	copy(res.basics, c.basics)
+	if c.set != nil {
+		res.set = make(map[Symbol]struct{}, len(c.set)+extra)
+		for symbol := range c.set {
+			res.set[symbol] = struct{}{}
+		}
+	}
+	return res
+}
+
+// minColumn is the number of symbols a new column has room for upfront.
+const minColumn = 8
+
+// maxSpareColumns is the maximum number of emptied columns a solver holds on to for reuse.
+const maxSpareColumns = 256
+
+// newColumn returns an empty column, reusing a column emptied beforehand should one be spare. Pivots
+// empty and fill columns at a steady rate, which would otherwise have the solver allocate a column,
+// and the garbage collector reclaim one, for every symbol entering and leaving the tableau.
+func (s *Solver) newColumn() *column {
+	if n := len(s.spareCols); n > 0 {
+		col := s.spareCols[n-1]
+		s.spareCols[n-1] = nil
+		s.spareCols = s.spareCols[:n-1]
+		return col
+	}
+	return &column{basics: make([]Symbol, 0, minColumn)}
+}
+
+// dropColumn removes the column of id from the index, emptying it for reuse should it not be shared
+// with a fork.
+func (s *Solver) dropColumn(id Symbol) {
+	col, exists := s.cols[id]
+	if !exists {
+		return
+	}
+	delete(s.cols, id)
+	if _, borrowed := s.borrowedCols[id]; borrowed {
+		delete(s.borrowedCols, id)
+		return
+	}
+	if len(s.spareCols) >= maxSpareColumns {
+		return
+	}
+	col.reset()
+	s.spareCols = append(s.spareCols, col)
+}
+
+// insertRow installs row into the tableau as the row of basic, replacing any row basic already
+// has, and indexes the symbols it references.
+func (s *Solver) insertRow(basic Symbol, row Constraint) {
+	s.removeRow(basic)
+	s.tabs.set(basic, row)
+	delete(s.borrowed, basic)
+	for _, term := range row.expr.terms {
+		s.link(basic, term.id)
+	}
+}
+
+// removeRow removes the row of basic from the tableau, and unindexes the symbols it references. The
+// removed row has a term buffer of its own, which the caller may modify.
+func (s *Solver) removeRow(basic Symbol) (Constraint, bool) {
+	row, exists := s.tabs.get(basic)
+	if !exists {
+		return row, false
+	}
+	s.tabs.delete(basic)
+	for _, term := range row.expr.terms {
+		s.unlink(basic, term.id)
+	}
+	return s.ownRow(basic, row, 0), true
+}
+
+// column returns the basic symbols of the rows referencing id, ordered by symbol should the solver
+// be created using WithDeterministic.
+func (s *Solver) column(id Symbol) []Symbol {
+	res := append([]Symbol(nil), s.cols[id].symbols()...)
+	if s.opts.deterministic {
+		sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	}
+	return res
+}
+
+// referenced appends to dst whether row references each symbol of expr, in the order of the terms
+// of expr. As both row and expr are canonical, their terms are walked together in a single pass,
+// unless expr is small enough next to row that its terms are binary searched for instead.
+func referenced(dst []bool, row, expr Expr) []bool {
+	if len(expr.terms)*mergeRatio < len(row.terms) {
+		for _, term := range expr.terms {
+			i := row.search(term.id)
+			dst = append(dst, i < len(row.terms) && row.terms[i].id == term.id)
+		}
+		return dst
+	}
+
+	i := 0
+	for _, term := range expr.terms {
+		for i < len(row.terms) && row.terms[i].id < term.id {
+			i++
+		}
+		dst = append(dst, i < len(row.terms) && row.terms[i].id == term.id)
+	}
+	return dst
+}
+
+// relink updates the column index once expr was added into row, the row of basic, such that the
+// symbols of expr are indexed only if their terms did not cancel out. The symbols of expr row
+// referenced beforehand are given by before, as reported by referenced, such that only symbols whose
+// terms were added to or cancelled out of row are linked or unlinked.
+func (s *Solver) relink(basic Symbol, row, expr Expr, before []bool) {
+	after := referenced(before[len(before):], row, expr)
+	for i, term := range expr.terms {
+		switch {
+		case after[i] && !before[i]:
+			s.link(basic, term.id)
+		case before[i] && !after[i]:
+			s.unlink(basic, term.id)
+		}
+	}
+}
+
+func (s *Solver) link(basic, id Symbol) {
+	col, exists := s.cols[id]
+	if !exists {
+		col = s.newColumn()
+		s.cols[id] = col
+	} else {
+		col = s.ownColumn(id, col)
+	}
+	col.add(basic)
+}
+
+func (s *Solver) unlink(basic, id Symbol) {
+	col, exists := s.cols[id]
+	if !exists {
+		return
+	}
+	col = s.ownColumn(id, col)
+	col.remove(basic)
+	if col.len() == 0 {
+		s.dropColumn(id)
+	}
+}
+
+// indexColumns rebuilds the column index from the rows of the tableau.
+func (s *Solver) indexColumns() {
+	s.cols = make(map[Symbol]*column, s.tabs.len())
+	s.borrowedCols = nil
+	for _, tab := range s.tabs.entries {
+		for _, term := range tab.row.expr.terms {
+			s.link(tab.basic, term.id)
+		}
+	}
+}
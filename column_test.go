@@ -0,0 +1,190 @@
+package casso
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"math/rand"
+	"testing"
+)
+
+func requireColumnsIndexed(t *testing.T, s *Solver) {
+	t.Helper()
+
+	expected := &Solver{tabs: s.tabs}
+	expected.indexColumns()
+	sets := columnSets(s)
+	require.Equal(t, columnSets(expected), sets)
+
+	for id, col := range s.cols {
+		require.Len(t, sets[id], col.len())
+		if col.set == nil {
+			require.LessOrEqual(t, col.len(), maxListedColumn)
+		}
+	}
+}
+
+// columnSets returns the symbols of each column of s as a set, as the order of symbols within a
+// column depends on the order they were added and removed in.
+func columnSets(s *Solver) map[Symbol]map[Symbol]struct{} {
+	res := make(map[Symbol]map[Symbol]struct{}, len(s.cols))
+	for id, col := range s.cols {
+		set := make(map[Symbol]struct{}, col.len())
+		for _, basic := range col.symbols() {
+			set[basic] = struct{}{}
+		}
+		res[id] = set
+	}
+	return res
+}
+
+func TestColumnIndex(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	s := NewSolver()
+
+	vars := make([]Symbol, 16)
+	for i := range vars {
+		vars[i] = New()
+		require.NoError(t, s.Edit(vars[i], Weak))
+	}
+
+	var markers []Symbol
+	for i := 0; i < 500; i++ {
+		switch rng.Intn(4) {
+		case 0, 1:
+			a, b := vars[rng.Intn(len(vars))], vars[rng.Intn(len(vars))]
+			op := Op(rng.Intn(3))
+			priority := [...]Priority{Required, Strong, Medium}[rng.Intn(3)]
+			marker, err := s.AddConstraintWithPriority(priority, NewConstraint(op, -rng.Float64()*100, a.T(1), b.T(-rng.Float64())))
+			if err == nil {
+				markers = append(markers, marker)
+			}
+		case 2:
+			if len(markers) == 0 {
+				continue
+			}
+			idx := rng.Intn(len(markers))
+			require.NoError(t, s.RemoveConstraint(markers[idx]))
+			markers = append(markers[:idx], markers[idx+1:]...)
+		case 3:
+			require.NoError(t, s.Suggest(vars[rng.Intn(len(vars))], rng.Float64()*100))
+		}
+		requireColumnsIndexed(t, s)
+	}
+
+	requireColumnsIndexed(t, s.Clone())
+}
+
+func TestColumnIndexWideRows(t *testing.T) {
+	s := NewSolver()
+
+	// rows wide enough for substitutions to walk many terms of both rows at once
+
+	vars := make([]Symbol, 96)
+	for i := range vars {
+		vars[i] = New()
+	}
+
+	for i := 0; i < 8; i++ {
+		terms := make([]Term, 0, len(vars))
+		for j, id := range vars[i:] {
+			terms = append(terms, id.T(float64((i+j)%7+1)))
+		}
+		_, err := s.AddConstraintWithPriority(Strong, NewConstraint(EQ, -float64(i), terms...))
+		require.NoError(t, err)
+		requireColumnsIndexed(t, s)
+	}
+}
+
+func TestColumnIndexWideColumns(t *testing.T) {
+	s := NewSolver()
+
+	// rows enough referencing the same symbol for its column to be kept in a map
+
+	window := New()
+	require.NoError(t, s.Edit(window, Strong))
+	require.NoError(t, s.Suggest(window, 100))
+
+	markers := make([]Symbol, 0, 2*maxListedColumn)
+	for i := 0; i < cap(markers); i++ {
+		marker, err := s.AddConstraint(NewConstraint(LTE, float64(i%10), New().T(1), window.T(-1)))
+		require.NoError(t, err)
+		markers = append(markers, marker)
+	}
+	requireColumnsIndexed(t, s)
+
+	wide := false
+	for _, col := range s.cols {
+		wide = wide || col.set != nil
+	}
+	require.True(t, wide)
+
+	for i := 0; i < len(markers); i += 2 {
+		require.NoError(t, s.RemoveConstraint(markers[i]))
+	}
+	requireColumnsIndexed(t, s)
+	requireColumnsIndexed(t, s.Clone())
+}
+
+func TestColumnGrowAndShrink(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	var col column
+	expected := make(map[Symbol]struct{})
+
+	for i := 0; i < 2000; i++ {
+		basic := Symbol(rng.Intn(4*maxListedColumn) + 1)
+		if _, ok := expected[basic]; ok {
+			col.remove(basic)
+			delete(expected, basic)
+		} else {
+			col.add(basic)
+			expected[basic] = struct{}{}
+		}
+
+		require.Equal(t, len(expected), col.len())
+		if i%100 != 0 {
+			continue
+		}
+		actual := make(map[Symbol]struct{}, col.len())
+		for _, basic := range col.symbols() {
+			actual[basic] = struct{}{}
+		}
+		require.Equal(t, expected, actual)
+	}
+	require.NotNil(t, col.set)
+}
+
+// BenchmarkColumn removes and adds back symbols of a column of a given size, with the symbols kept
+// in a slice and in a map. The slice is faster up to around maxListedColumn symbols.
+func BenchmarkColumn(b *testing.B) {
+	for _, size := range [...]int{4, 16, 64, 128, 256} {
+		for _, kept := range [...]string{"listed", "mapped"} {
+			b.Run(fmt.Sprintf("size=%d/%s", size, kept), func(b *testing.B) {
+				col := column{basics: make([]Symbol, 0, size)}
+				for i := 1; i <= size; i++ {
+					col.basics = append(col.basics, Symbol(i))
+				}
+				if kept == "mapped" {
+					col.set = make(map[Symbol]struct{}, size)
+					for _, basic := range col.basics {
+						col.set[basic] = struct{}{}
+					}
+				}
+
+				b.ReportAllocs()
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					basic := Symbol(i%size + 1)
+					col.remove(basic)
+					if kept == "mapped" {
+						col.add(basic)
+					} else {
+						col.basics = append(col.basics, basic) // as add does, but never moving to a map
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package casso
+
+// Compact rebuilds the storage of the solver to fit the constraints, edit variables, and stays it
+// currently holds. Maps never shrink as entries are deleted from them, and rows keep the capacity
+// they once grew to, such that long-running solvers that add and remove many constraints may call
+// Compact during idle periods to reclaim memory. Markers, symbols, and values are unaffected.
+func (s *Solver) Compact() {
+	// lay rows out anew one after another, ordered by basic symbol
+
+	s.slab = slab{}
+	tabs := newTabs(s.tabs.len())
+	for _, symbol := range s.basics() {
+		row, _ := s.tabs.get(symbol)
+		row.expr.terms = append(s.slab.carve(len(row.expr.terms)), row.expr.terms...)
+		tabs.set(symbol, row)
+	}
+	tabs.vals = s.tabs.vals
+	s.tabs, s.borrowed = tabs, nil
+	s.indexColumns()
+
+	tags := make(map[Symbol]Tag, len(s.tags))
+	for marker, tag := range s.tags {
+		tags[marker] = tag
+	}
+	s.tags = tags
+
+	s.edits = compactEdits(s.edits)
+	if s.stays != nil {
+		s.stays = compactEdits(s.stays)
+	}
+
+	if s.data != nil {
+		data := make(map[Symbol]interface{}, len(s.data))
+		for symbol, v := range s.data {
+			data[symbol] = v
+		}
+		s.data = data
+	}
+	if s.names != nil {
+		names := make(map[Symbol]string, len(s.names))
+		for symbol, name := range s.names {
+			names[symbol] = name
+		}
+		s.names = names
+	}
+	if s.pending != nil {
+		pending := make(map[Symbol]float64, len(s.pending))
+		for id, val := range s.pending {
+			pending[id] = val
+		}
+		s.pending = pending
+	}
+
+	s.byValue, s.partition = nil, nil // rebuilt upon next use
+
+	s.sweepObjective()
+	s.objective = s.objective.clone()
+	s.artificial = s.artificial.clone()
+	infeasible := s.infeasible
+	s.infeasible, s.queued = make([]Symbol, 0, minInfeasible), nil
+	for _, symbol := range infeasible {
+		s.markInfeasible(symbol)
+	}
+
+	s.spare, s.spareCols = nil, nil
+	s.scratch = make([]Term, 0, minScratch)
+	s.negative = nil
+}
+
+func compactEdits(edits map[Symbol]Edit) map[Symbol]Edit {
+	res := make(map[Symbol]Edit, len(edits))
+	for id, edit := range edits {
+		res[id] = edit
+	}
+	return res
+}
@@ -0,0 +1,43 @@
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCompact(t *testing.T) {
+	s := casso.NewSolver()
+
+	x := casso.New()
+	y := casso.New()
+
+	_, err := s.AddConstraint(casso.NewConstraint(casso.EQ, -10, y.T(1), x.T(-1)))
+	require.NoError(t, err)
+	width, err := s.AddConstraintWithPriority(casso.Weak, x.GTE(100))
+	require.NoError(t, err)
+	require.NoError(t, s.Edit(x, casso.Strong))
+	require.NoError(t, s.Suggest(x, 50))
+
+	// churn through many constraints, leaving the storage of the solver oversized
+
+	for i := 0; i < 1000; i++ {
+		v := casso.New()
+		marker, err := s.AddConstraint(casso.NewConstraint(casso.EQ, 0, v.T(1), x.T(-1), y.T(-1)))
+		require.NoError(t, err)
+		require.NoError(t, s.RemoveConstraint(marker))
+	}
+
+	before := s.Tableau().Rows()
+	s.Compact()
+	require.Equal(t, before, s.Tableau().Rows())
+	require.EqualValues(t, 50, s.Val(x))
+	require.EqualValues(t, 60, s.Val(y))
+
+	require.NoError(t, s.Suggest(x, 20))
+	require.EqualValues(t, 30, s.Val(y))
+	require.NoError(t, s.RemoveConstraint(width))
+	require.NoError(t, s.RemoveEdit(x))
+	require.EqualValues(t, 10, s.Val(y)-s.Val(x))
+	require.EqualValues(t, 0, s.ObjectiveValue())
+}
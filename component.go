@@ -0,0 +1,231 @@
+package casso
+
+import (
+	"sort"
+	"sync"
+)
+
+// Components partitions the constraints installed in the solver into connected components: sets of
+// constraints that are related to one another through the external variables they reference,
+// directly or through other constraints. Each component is returned as the markers of its
+// constraints in the order they were installed, and components are ordered by their first
+// constraint. The constraints of edit variables and stays belong to the components of their
+// variables.
+//
+// The first call to Components partitions all installed constraints, after which the partition is
+// maintained as constraints are installed and removed; see partition.
+func (s *Solver) Components() [][]Symbol {
+	if s.partition == nil {
+		s.indexComponents()
+	}
+
+	groups := make(map[Symbol][]Symbol)
+	for marker := range s.tags {
+		root := s.partition.find(marker)
+		groups[root] = append(groups[root], marker)
+	}
+
+	res := make([][]Symbol, 0, len(groups))
+	for _, markers := range groups {
+		sort.Slice(markers, func(i, j int) bool { return markers[i].ID() < markers[j].ID() })
+		res = append(res, markers)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i][0].ID() < res[j][0].ID() })
+
+	return res
+}
+
+// indexComponents partitions all installed constraints, joining them in the order they were
+// installed such that removing the constraints installed last is cheapest.
+func (s *Solver) indexComponents() {
+	markers := make([]Symbol, 0, len(s.tags))
+	for marker := range s.tags {
+		markers = append(markers, marker)
+	}
+	sort.Slice(markers, func(i, j int) bool { return markers[i].ID() < markers[j].ID() })
+
+	s.partition = newPartition()
+	for _, marker := range markers {
+		s.joinComponent(marker, s.tags[marker].cell)
+	}
+}
+
+// joinComponent joins the constraint of marker into the partition, relating it to the external
+// variables it references.
+func (s *Solver) joinComponent(marker Symbol, cell Constraint) {
+	vars := make([]Symbol, 0, len(cell.expr.terms))
+	for _, term := range cell.expr.terms {
+		if term.id.External() && !s.eqz(term.coeff) {
+			vars = append(vars, term.id)
+		}
+	}
+	s.partition.join(marker, vars)
+}
+
+// partition maintains the connected components of the constraints installed in a solver as they are
+// installed and removed, via a union-find whose unions may be rolled back. Trees are united by size
+// without compressing paths, such that unions may be undone in the reverse order they were made in,
+// and symbols standing alone are not stored, such that markers and variables are forgotten once the
+// unions relating them are undone.
+// Removing the constraint joined last undoes its unions, while removing any other constraint rolls
+// the partition back to before the constraint was joined and replays the constraints joined after
+// it, such that constraints removed in about the reverse order they were installed in, as they are
+// when layouts are torn down and rebuilt, are removed cheaply.
+type partition struct {
+	parent map[Symbol]Symbol // symbol -> parent, being absent for roots
+	size   map[Symbol]int    // root -> number of symbols in its tree, being absent for lone symbols
+	unions []Symbol          // roots made children of other roots, in the order they were made
+	joined []joined          // constraints joined, in the order they were joined
+	at     map[Symbol]int    // marker -> index of its constraint in joined
+}
+
+// joined is a constraint joined into a partition.
+type joined struct {
+	marker Symbol
+	vars   []Symbol // external variables referenced by the constraint
+	unions int      // number of unions made before the constraint was joined
+}
+
+func newPartition() *partition {
+	return &partition{
+		parent: make(map[Symbol]Symbol),
+		size:   make(map[Symbol]int),
+		at:     make(map[Symbol]int),
+	}
+}
+
+// find returns the root of the tree of id.
+func (p *partition) find(id Symbol) Symbol {
+	for {
+		parent, ok := p.parent[id]
+		if !ok {
+			return id
+		}
+		id = parent
+	}
+}
+
+// union unites the trees of a and b, making the root of the smaller tree a child of the other.
+func (p *partition) union(a, b Symbol) {
+	a, b = p.find(a), p.find(b)
+	if a == b {
+		return
+	}
+	if p.sizeOf(a) < p.sizeOf(b) {
+		a, b = b, a
+	}
+	p.size[a] = p.sizeOf(a) + p.sizeOf(b)
+	p.parent[b] = a
+	p.unions = append(p.unions, b)
+}
+
+func (p *partition) sizeOf(root Symbol) int {
+	if size, ok := p.size[root]; ok {
+		return size
+	}
+	return 1
+}
+
+// undo undoes unions until only n remain.
+func (p *partition) undo(n int) {
+	for len(p.unions) > n {
+		child := p.unions[len(p.unions)-1]
+		p.unions = p.unions[:len(p.unions)-1]
+
+		root := p.parent[child]
+		delete(p.parent, child)
+		if p.size[root] -= p.sizeOf(child); p.size[root] == 1 {
+			delete(p.size, root)
+		}
+	}
+}
+
+// join joins the constraint of marker, relating it to vars. A constraint joined already is left
+// first, as constraints reinstalled in place are joined again.
+func (p *partition) join(marker Symbol, vars []Symbol) {
+	if _, ok := p.at[marker]; ok {
+		p.leave(marker)
+	}
+	p.link(joined{marker: marker, vars: vars})
+}
+
+// link makes the unions relating the constraint of j to its variables.
+func (p *partition) link(j joined) {
+	j.unions = len(p.unions)
+	p.at[j.marker] = len(p.joined)
+	p.joined = append(p.joined, j)
+	for _, id := range j.vars {
+		p.union(j.marker, id)
+	}
+}
+
+// leave removes the constraint of marker from the partition.
+func (p *partition) leave(marker Symbol) {
+	i, ok := p.at[marker]
+	if !ok {
+		return
+	}
+	j := p.joined[i]
+
+	p.undo(j.unions)
+
+	rest := append([]joined(nil), p.joined[i+1:]...)
+	p.joined = p.joined[:i]
+	delete(p.at, marker)
+
+	for _, j := range rest {
+		p.link(j)
+	}
+}
+
+// Split returns a new solver for every connected component of the solver as returned by Components,
+// holding the constraints, edit variables, and stays of the component. The solvers share no state
+// with one another nor with s, such that changes to one component never touch the rows of another,
+// and each solver may be modified on a goroutine of its own; see Parallel. s is left unmodified.
+func (s *Solver) Split() ([]*Solver, error) {
+	components := s.Components()
+
+	res := make([]*Solver, 0, len(components))
+	for _, markers := range components {
+		keep := make(map[Symbol]struct{}, len(markers))
+		for _, marker := range markers {
+			keep[marker] = struct{}{}
+		}
+
+		part := newSolver(s.opts)
+		if _, err := mergeMarkers(part, s, keep); err != nil {
+			return nil, err
+		}
+		if part.history != nil {
+			part.history = &history{depth: part.history.depth}
+		}
+
+		res = append(res, part)
+	}
+
+	return res, nil
+}
+
+// Parallel calls fn with each of the given solvers on a goroutine of its own, and returns once all
+// calls have returned. The first error returned by fn, in the order the solvers are given, is
+// returned. The solvers must be distinct, such as the solvers returned by Split.
+func Parallel(solvers []*Solver, fn func(s *Solver) error) error {
+	errs := make([]error, len(solvers))
+
+	var wg sync.WaitGroup
+	wg.Add(len(solvers))
+	for i, s := range solvers {
+		go func(i int, s *Solver) {
+			defer wg.Done()
+			errs[i] = fn(s)
+		}(i, s)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
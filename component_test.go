@@ -0,0 +1,113 @@
+package casso_test
+
+import (
+	"errors"
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"math/rand"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	s := casso.NewSolver()
+
+	// two panels whose widths are edited independently, and a label related to nothing else
+
+	left, leftChild := casso.New(), casso.New()
+	right, rightChild := casso.New(), casso.New()
+	label := casso.New()
+
+	a1, err := s.AddConstraint(casso.NewConstraint(casso.EQ, 10, leftChild.T(1), left.T(-1)))
+	require.NoError(t, err)
+	b1, err := s.AddConstraint(casso.NewConstraint(casso.EQ, 0, rightChild.T(2), right.T(-1)))
+	require.NoError(t, err)
+	a2, err := s.AddConstraintWithPriority(casso.Weak, left.GTE(100))
+	require.NoError(t, err)
+	c1, err := s.AddConstraint(label.EQ(5))
+	require.NoError(t, err)
+
+	require.NoError(t, s.Edit(left, casso.Strong))
+	require.NoError(t, s.Edit(right, casso.Strong))
+	require.NoError(t, s.Suggest(left, 200))
+	require.NoError(t, s.Suggest(right, 300))
+
+	components := s.Components()
+	require.Len(t, components, 3)
+	require.Equal(t, []casso.Symbol{a1}, components[0][:1])
+	require.Contains(t, components[0], a2)
+	require.Len(t, components[0], 3) // a1, a2, and the edit of left
+	require.Equal(t, []casso.Symbol{b1}, components[1][:1])
+	require.Len(t, components[1], 2) // b1, and the edit of right
+	require.Equal(t, [][]casso.Symbol{{c1}}, components[2:])
+
+	parts, err := s.Split()
+	require.NoError(t, err)
+	require.Len(t, parts, 3)
+
+	require.EqualValues(t, 190, parts[0].Val(leftChild))
+	require.EqualValues(t, 150, parts[1].Val(rightChild))
+	require.EqualValues(t, 5, parts[2].Val(label))
+
+	// components are suggested values concurrently without touching one another or s
+
+	vals := []float64{400, 600}
+	require.NoError(t, casso.Parallel(parts[:2], func(part *casso.Solver) error {
+		for i, id := range []casso.Symbol{left, right} {
+			if part.HasEdit(id) {
+				return part.Suggest(id, vals[i])
+			}
+		}
+		return nil
+	}))
+
+	require.EqualValues(t, 390, parts[0].Val(leftChild))
+	require.EqualValues(t, 300, parts[1].Val(rightChild))
+	require.EqualValues(t, 190, s.Val(leftChild))
+	require.EqualValues(t, 150, s.Val(rightChild))
+
+	bad := errors.New("bad")
+	require.Equal(t, bad, casso.Parallel(parts, func(part *casso.Solver) error {
+		if part == parts[1] {
+			return bad
+		}
+		return nil
+	}))
+}
+
+func TestComponentsChurn(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	// the partition is maintained as degenerate constraints are churned through the solver
+
+	s := casso.NewSolver()
+
+	vars := make([]casso.Symbol, 40)
+	for i := range vars {
+		vars[i] = s.New()
+	}
+
+	var markers []casso.Symbol
+	for i := 0; i < 500; i++ {
+		switch op := rng.Intn(10); {
+		case op < 5 || len(markers) == 0:
+			a, b := vars[rng.Intn(len(vars))], vars[rng.Intn(len(vars))]
+			marker, err := s.AddConstraintWithPriority(casso.Weak, casso.NewConstraint(casso.EQ, 0, a.T(1), b.T(-1)))
+			require.NoError(t, err)
+			markers = append(markers, marker)
+		case op < 9:
+			// remove recently installed constraints more often than not, as layouts are torn down
+
+			j := len(markers) - 1 - rng.Intn(len(markers))/(1+rng.Intn(4))
+			require.NoError(t, s.RemoveConstraint(markers[j]))
+			markers = append(markers[:j], markers[j+1:]...)
+		default:
+			marker := markers[rng.Intn(len(markers))]
+			require.NoError(t, s.UpdateCoefficient(marker, vars[rng.Intn(len(vars))], float64(rng.Intn(3))))
+		}
+
+		// the partition maintained matches the partition computed by a clone from scratch
+
+		require.Equal(t, s.Clone().Components(), s.Components())
+	}
+	require.NotEmpty(t, s.Components())
+}
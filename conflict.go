@@ -0,0 +1,83 @@
+package casso
+
+import "sort"
+
+// conflicts returns the markers of an irreducible set of required constraints installed in the
+// solver that cell, a required constraint, is unsatisfiable alongside: the constraints of the set
+// and cell may not all hold at once, though they may should any one of them be left out. Markers
+// are ordered by the order their constraints were installed in.
+//
+// Only constraints in the components of the variables of cell are considered, as constraints of
+// other components share no variables with cell. Constraints are added one by one, in the order
+// they were installed, to a scratch solver holding cell until one fails to be added. The constraint
+// that failed must take part in the conflict, while every constraint added before it is then left
+// out in turn, and dropped for good should the rest remain unsatisfiable without it. Computing the
+// set thus takes a number of scratch solves linear in the number of constraints considered.
+func (s *Solver) conflicts(cell Constraint) []Symbol {
+	candidates := s.conflictCandidates(cell)
+
+	scratch := newSolver(options{pivot: s.opts.pivot, epsilon: s.opts.epsilon, manual: true})
+
+	// add adds cell and then the constraints of markers to the scratch solver until one fails to be
+	// added, returning its index, -1 should cell fail to be added on its own, or len(markers) should
+	// all be added
+
+	add := func(markers []Symbol) int {
+		scratch.Reset()
+		if _, err := scratch.addConstraint(Required, cell); err != nil {
+			return -1
+		}
+		for i, marker := range markers {
+			if _, err := scratch.addConstraint(Required, s.tags[marker].cell); err != nil {
+				return i
+			}
+		}
+		return len(markers)
+	}
+
+	n := add(candidates)
+	if n < 0 || n == len(candidates) {
+		return nil
+	}
+
+	set := append([]Symbol(nil), candidates[:n+1]...)
+	for i := 0; i < len(set)-1; {
+		trial := append(append(make([]Symbol, 0, len(set)-1), set[:i]...), set[i+1:]...)
+		if add(trial) == len(trial) {
+			i++
+			continue
+		}
+		set = trial
+	}
+
+	return set
+}
+
+// conflictCandidates returns the markers of the required constraints installed in the solver that
+// belong to the components of the external variables of cell, ordered by the order they were
+// installed in.
+func (s *Solver) conflictCandidates(cell Constraint) []Symbol {
+	if s.partition == nil {
+		s.indexComponents()
+	}
+
+	roots := make(map[Symbol]struct{}, len(cell.expr.terms))
+	for _, term := range cell.expr.terms {
+		if term.id.External() && !s.eqz(term.coeff) {
+			roots[s.partition.find(term.id)] = struct{}{}
+		}
+	}
+
+	var candidates []Symbol
+	for marker, tag := range s.tags {
+		if tag.priority < Required {
+			continue
+		}
+		if _, ok := roots[s.partition.find(marker)]; ok {
+			candidates = append(candidates, marker)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].ID() < candidates[j].ID() })
+
+	return candidates
+}
@@ -0,0 +1,72 @@
+package casso_test
+
+import (
+	"errors"
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestConflicts(t *testing.T) {
+	s := casso.NewSolver(casso.WithConflicts())
+	x, y, z, w := s.New(), s.New(), s.New(), s.New()
+
+	add := func(priority casso.Priority, cell casso.Constraint) casso.Symbol {
+		marker, err := s.AddConstraintWithPriority(priority, cell)
+		require.NoError(t, err)
+		return marker
+	}
+
+	a := add(casso.Required, x.GTE(10))
+	b := add(casso.Required, casso.NewConstraint(casso.GTE, 0, y.T(1), x.T(-1)))
+	add(casso.Required, y.LTE(50))
+	add(casso.Required, casso.NewConstraint(casso.EQ, 0, z.T(1), y.T(-1)))
+	add(casso.Required, w.LTE(100))
+	add(casso.Strong, x.EQ(100))
+
+	// y <= 5 conflicts with x >= 10 and y >= x, but neither with y <= 50, z == y, nor with the
+	// constraints of w, which are in a component of their own
+
+	_, err := s.AddConstraint(y.LTE(5))
+	require.True(t, errors.Is(err, casso.ErrUnsatisfiable))
+
+	var e *casso.ConstraintError
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, []casso.Symbol{a, b}, e.Conflicts)
+	require.Contains(t, err.Error(), "conflicts with")
+
+	require.Len(t, s.Constraints(), 6)
+}
+
+func TestConflictsIrreducible(t *testing.T) {
+	s := casso.NewSolver(casso.WithConflicts())
+	x, y := s.New(), s.New()
+
+	// x == y + 1 and y >= 20 imply x >= 21, such that x <= 5 conflicts with either x >= 10 or the
+	// pair of them, but only one irreducible set of the two is reported
+
+	a, err := s.AddConstraint(x.GTE(10))
+	require.NoError(t, err)
+	_, err = s.AddConstraint(y.GTE(20))
+	require.NoError(t, err)
+	_, err = s.AddConstraint(casso.NewConstraint(casso.EQ, -1, x.T(1), y.T(-1)))
+	require.NoError(t, err)
+
+	_, err = s.AddConstraint(x.LTE(5))
+	var e *casso.ConstraintError
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, []casso.Symbol{a}, e.Conflicts)
+
+	// required equalities are reported as inequalities are
+
+	_, err = s.AddConstraint(x.EQ(3))
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, []casso.Symbol{a}, e.Conflicts)
+
+	s = casso.NewSolver()
+	_, err = s.AddConstraint(x.GTE(10))
+	require.NoError(t, err)
+	_, err = s.AddConstraint(x.LTE(5))
+	require.True(t, errors.As(err, &e))
+	require.Nil(t, e.Conflicts)
+}
@@ -0,0 +1,71 @@
+package casso
+
+// definition is a named expression managed by a solver, whose value is held by a proxy variable.
+type definition struct {
+	id     Symbol // proxy variable constrained to equal the expression
+	marker Symbol // marker of the constraint defining the proxy variable
+}
+
+// Define names an expression, and returns a variable that is constrained to equal it which may be
+// referenced by other constraints. Redefining a name replaces the expression the variable is
+// constrained to equal, such that constraints referencing the variable need not be reinstalled when
+// the form of a shared quantity changes. The variable is named after the expression within the
+// solver; see SetName.
+//
+// Should the new expression fail to be installed, the name is left undefined and the error is
+// returned.
+func (s *Solver) Define(name string, expr Expr) (Symbol, error) {
+	def, exists := s.defs[name]
+	if !exists {
+		def.id = New()
+	}
+
+	cell := Constraint{op: EQ, expr: expr.Sub(def.id.Expr())}
+
+	if exists {
+		if err := s.RemoveConstraint(def.marker); err != nil {
+			return zero, err
+		}
+		delete(s.defs, name)
+	}
+
+	marker, err := s.AddConstraint(cell)
+	if err != nil {
+		return zero, err
+	}
+	def.marker = marker
+
+	if s.defs == nil {
+		s.defs = make(map[string]definition)
+	}
+	s.defs[name] = def
+
+	if s.Name(def.id) == "" {
+		s.SetName(def.id, name)
+	}
+
+	return def.id, nil
+}
+
+// Definition returns the variable constrained to equal the expression defined under the given name.
+func (s *Solver) Definition(name string) (Symbol, bool) {
+	def, exists := s.defs[name]
+	return def.id, exists
+}
+
+// Undefine removes the constraint relating the variable of a named expression to the expression.
+// Constraints referencing the variable are left installed, though the variable becomes free.
+func (s *Solver) Undefine(name string) error {
+	def, exists := s.defs[name]
+	if !exists {
+		return ErrBadDefinition
+	}
+	if err := s.RemoveConstraint(def.marker); err != nil {
+		return err
+	}
+	delete(s.defs, name)
+	if s.Name(def.id) == name {
+		s.SetName(def.id, "")
+	}
+	return nil
+}
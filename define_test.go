@@ -0,0 +1,51 @@
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDefine(t *testing.T) {
+	s := casso.NewSolver()
+
+	width := casso.New()
+	padding := casso.New()
+
+	_, err := s.AddConstraint(width.EQ(300))
+	require.NoError(t, err)
+	_, err = s.AddConstraint(padding.EQ(10))
+	require.NoError(t, err)
+
+	content, err := s.Define("contentWidth", casso.NewExpr(0, width.T(1), padding.T(-2)))
+	require.NoError(t, err)
+	require.Equal(t, "contentWidth", s.Name(content))
+	require.Nil(t, s.SymbolData(content))
+
+	column := casso.New()
+	_, err = s.AddConstraint(casso.NewConstraint(casso.EQ, 0, column.T(2), content.T(-1)))
+	require.NoError(t, err)
+
+	require.EqualValues(t, 280, s.Val(content))
+	require.EqualValues(t, 140, s.Val(column))
+
+	// redefine the expression; the column constraint follows without being reinstalled
+
+	redefined, err := s.Define("contentWidth", casso.NewExpr(-20, width.T(1), padding.T(-4)))
+	require.NoError(t, err)
+	require.Equal(t, content, redefined)
+
+	require.EqualValues(t, 240, s.Val(content))
+	require.EqualValues(t, 120, s.Val(column))
+
+	id, ok := s.Definition("contentWidth")
+	require.True(t, ok)
+	require.Equal(t, content, id)
+
+	require.NoError(t, s.Undefine("contentWidth"))
+	require.Equal(t, casso.ErrBadDefinition, s.Undefine("contentWidth"))
+	require.Empty(t, s.Name(content))
+
+	_, ok = s.Definition("contentWidth")
+	require.False(t, ok)
+}
@@ -0,0 +1,37 @@
+//go:build !tinygo && !casso_tiny
+// +build !tinygo,!casso_tiny
+
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+// requireSameReport asserts that a and b report the same variables, values, and constraints.
+func requireSameReport(t *testing.T, a, b *casso.Solver) {
+	require.Equal(t, a.Report(), b.Report())
+}
+
+// requireNoDrift asserts that the tableau of s has not drifted from its constraints.
+func requireNoDrift(t *testing.T, s *casso.Solver) {
+	drift, err := s.AuditDrift()
+	require.NoError(t, err)
+	require.InDelta(t, 0, drift.Max, 1e-9)
+}
+
+// requireReportContains asserts that the text report of s contains all of substrs.
+func requireReportContains(t *testing.T, s *casso.Solver, substrs ...string) {
+	var b strings.Builder
+	require.NoError(t, s.WriteReport(&b, casso.ReportText))
+	for _, substr := range substrs {
+		require.Contains(t, b.String(), substr)
+	}
+}
+
+// requireDumpContains asserts that the dump of the tableau of s contains substr.
+func requireDumpContains(t *testing.T, s *casso.Solver, substr string) {
+	require.Contains(t, s.String(), substr)
+}
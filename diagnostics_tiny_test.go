@@ -0,0 +1,23 @@
+//go:build tinygo || casso_tiny
+// +build tinygo casso_tiny
+
+package casso_test
+
+import (
+	"errors"
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// Builds for small devices leave out reports and dumps, such that there is nothing to assert on.
+
+func requireSameReport(t *testing.T, a, b *casso.Solver)                     {}
+func requireReportContains(t *testing.T, s *casso.Solver, substrs ...string) {}
+func requireDumpContains(t *testing.T, s *casso.Solver, substr string)       {}
+
+// requireNoDrift asserts that drift audits are reported as unsupported.
+func requireNoDrift(t *testing.T, s *casso.Solver) {
+	_, err := s.AuditDrift()
+	require.True(t, errors.Is(err, casso.ErrUnsupported))
+}
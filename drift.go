@@ -0,0 +1,24 @@
+package casso
+
+// Drift summarizes how far the row constants of a solver's tableau have diverged from their exact
+// values due to accumulated floating-point error.
+type Drift struct {
+	Rows   int     // number of rows audited
+	Max    float64 // largest absolute divergence of any row constant
+	Symbol Symbol  // basic symbol of the row with the largest divergence
+	Sum    float64 // sum of absolute divergences across all rows
+}
+
+// auditDrift is called after every suggestion made to a solver created using WithDriftAudit, and
+// reports drift to the audit callback once every configured number of suggestions.
+func (s *Solver) auditDrift() {
+	s.suggested++
+	if s.suggested%s.opts.driftEvery != 0 {
+		return
+	}
+	drift, err := s.AuditDrift()
+	if err != nil {
+		return
+	}
+	s.opts.driftFn(drift)
+}
@@ -0,0 +1,135 @@
+//go:build !tinygo && !casso_tiny
+// +build !tinygo,!casso_tiny
+
+package casso
+
+import (
+	"math"
+	"math/big"
+)
+
+// AuditDrift compares the row constants of the solver's tableau against their exact values. Exact
+// values are computed by solving the constraints installed into the solver, together with the values
+// last suggested for its edit variables, for the solver's current basis using rational arithmetic.
+//
+// Auditing takes time cubic in the number of installed constraints, and is thus intended to be run
+// periodically rather than after every modification to the solver.
+func (s *Solver) AuditDrift() (Drift, error) {
+	basic := s.basics()
+
+	if len(basic) != len(s.tags) {
+		return Drift{}, ErrSingularBasis
+	}
+
+	cols := make(map[Symbol]int, len(basic))
+	for i, symbol := range basic {
+		cols[symbol] = i
+	}
+
+	vals := make(map[Symbol]float64, len(s.edits)+len(s.stays)) // marker id -> suggested value
+	for _, edit := range s.edits {
+		vals[edit.tag.marker] = edit.val
+	}
+	for _, stay := range s.stays {
+		vals[stay.tag.marker] = stay.val
+	}
+
+	// build the augmented matrix [A | b], with a row per installed constraint in augmented simplex
+	// form and a column per basic symbol, as parametric symbols take on a value of zero
+
+	n := len(basic)
+	matrix := make([][]*big.Rat, 0, n)
+
+	for marker, tag := range s.tags {
+		row := make([]*big.Rat, n+1)
+		for i := range row {
+			row[i] = new(big.Rat)
+		}
+
+		add := func(coeff float64, id Symbol) {
+			if i, ok := cols[id]; ok {
+				row[i].Add(row[i], new(big.Rat).SetFloat64(coeff))
+			}
+		}
+
+		for _, term := range tag.cell.expr.terms {
+			if !s.eqz(term.coeff) {
+				add(term.coeff, term.id)
+			}
+		}
+
+		switch tag.cell.op {
+		case LTE, GTE:
+			coeff := 1.0
+			if tag.cell.op == GTE {
+				coeff = -1.0
+			}
+			add(coeff, tag.marker)
+			if !tag.other.Zero() {
+				add(-coeff, tag.other)
+			}
+		case EQ:
+			if tag.other.Zero() {
+				add(1.0, tag.marker)
+			} else {
+				add(-1.0, tag.marker)
+				add(1.0, tag.other)
+			}
+		}
+
+		row[n].SetFloat64(-(tag.cell.expr.constant - vals[marker]))
+		matrix = append(matrix, row)
+	}
+
+	if err := solveRat(matrix, n); err != nil {
+		return Drift{}, err
+	}
+
+	drift := Drift{Rows: n}
+	for i, symbol := range basic {
+		exact, _ := matrix[i][n].Float64()
+		row, _ := s.tabs.get(symbol)
+		diff := math.Abs(row.expr.constant - exact)
+		if diff > drift.Max || drift.Symbol.Zero() {
+			drift.Max, drift.Symbol = diff, symbol
+		}
+		drift.Sum += diff
+	}
+
+	return drift, nil
+}
+
+// solveRat reduces the n x (n+1) augmented matrix in place using Gauss-Jordan elimination, such that
+// the last column of row i holds the value of the i-th unknown.
+func solveRat(matrix [][]*big.Rat, n int) error {
+	tmp := new(big.Rat)
+	for col := 0; col < n; col++ {
+		pivot := -1
+		for i := col; i < n; i++ {
+			if matrix[i][col].Sign() != 0 {
+				pivot = i
+				break
+			}
+		}
+		if pivot == -1 {
+			return ErrSingularBasis
+		}
+		matrix[col], matrix[pivot] = matrix[pivot], matrix[col]
+
+		inv := new(big.Rat).Inv(matrix[col][col])
+		for j := col; j <= n; j++ {
+			matrix[col][j].Mul(matrix[col][j], inv)
+		}
+
+		for i := 0; i < n; i++ {
+			if i == col || matrix[i][col].Sign() == 0 {
+				continue
+			}
+			factor := new(big.Rat).Set(matrix[i][col])
+			for j := col; j <= n; j++ {
+				matrix[i][j].Sub(matrix[i][j], tmp.Mul(factor, matrix[col][j]))
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+//go:build !tinygo && !casso_tiny
+// +build !tinygo,!casso_tiny
+
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestAuditDrift(t *testing.T) {
+	var audits []casso.Drift
+
+	s := casso.NewSolver(casso.WithDriftAudit(10, func(drift casso.Drift) { audits = append(audits, drift) }))
+
+	left := casso.New()
+	mid := casso.New()
+	right := casso.New()
+
+	_, err := s.AddConstraint(casso.NewConstraint(casso.EQ, 0, mid.T(3), left.T(-1), right.T(-2)))
+	require.NoError(t, err)
+	_, err = s.AddConstraint(casso.NewConstraint(casso.GTE, -0.1, right.T(1), left.T(-1)))
+	require.NoError(t, err)
+	_, err = s.AddConstraint(left.GTE(0))
+	require.NoError(t, err)
+	_, err = s.AddConstraintWithPriority(casso.Weak, right.LTE(1000))
+	require.NoError(t, err)
+
+	drift, err := s.AuditDrift()
+	require.NoError(t, err)
+	require.EqualValues(t, s.Tableau().Len(), drift.Rows)
+	require.InDelta(t, 0, drift.Max, 1e-12)
+
+	require.NoError(t, s.Edit(left, casso.Strong))
+	require.NoError(t, s.Edit(right, casso.Medium))
+
+	for i := 0; i < 100; i++ {
+		require.NoError(t, s.Suggest(left, float64(i)*0.1))
+		require.NoError(t, s.Suggest(right, float64(i)*0.3+0.7))
+	}
+
+	require.Len(t, audits, 20)
+	for _, drift := range audits {
+		require.EqualValues(t, s.Tableau().Len(), drift.Rows)
+		require.False(t, drift.Symbol.Zero())
+		require.GreaterOrEqual(t, drift.Sum, drift.Max)
+		require.InDelta(t, 0, drift.Max, 1e-9)
+	}
+
+	drift, err = s.AuditDrift()
+	require.NoError(t, err)
+	require.InDelta(t, 0, drift.Max, 1e-9)
+	require.InDelta(t, (s.Val(left)+2*s.Val(right))/3, s.Val(mid), 1e-9)
+}
@@ -0,0 +1,11 @@
+//go:build tinygo || casso_tiny
+// +build tinygo casso_tiny
+
+package casso
+
+// AuditDrift is not supported by builds for small devices, which leave out the rational arithmetic
+// of math/big that auditing relies on. It always reports ErrUnsupported, such that solvers created
+// using WithDriftAudit never invoke their audit callback.
+func (s *Solver) AuditDrift() (Drift, error) {
+	return Drift{}, ErrUnsupported
+}
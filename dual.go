@@ -0,0 +1,78 @@
+package casso
+
+import "sort"
+
+// DualValue is the sensitivity of the objective of a solver to one of its installed constraints, as
+// returned by DualValues.
+type DualValue struct {
+	Marker   Symbol
+	Priority Priority
+	Price    float64 // change in the objective per unit the constraint is relaxed by
+}
+
+// DualValues returns the dual value of every installed constraint: the rate at which the objective
+// the solver minimizes, as reported by ObjectiveValue, changes as the constraint is relaxed. Layout
+// tooling may use these to show which constraint to loosen to have a preference be better met.
+//
+// An inequality is relaxed by raising the constant of its expression should it be GTE, and by
+// lowering it should it be LTE, such that it permits more. An equality is relaxed by raising the
+// constant of its expression, and thus tightened in the other direction; lowering it changes the
+// objective by the negated price instead. The constraints of edit variables and stays are expressed
+// as Constraints expresses them, as their variable being equal to the value suggested or preferred.
+//
+// A negative price means relaxing the constraint would have preferences be better met, while a price
+// of zero means the constraint does not hold any preference back. Prices are marginal: they hold for
+// as long as relaxing the constraint does not have another constraint become binding or stop being
+// so. Dual values are ordered by the order their constraints were installed in.
+func (s *Solver) DualValues() []DualValue {
+	if s.dirty && s.opts.lazy {
+		_ = s.Solve()
+	}
+
+	res := make([]DualValue, 0, len(s.tags))
+	for _, tag := range s.tags {
+		price := s.dual(tag)
+		if tag.cell.op == LTE {
+			price = -price
+		}
+		res = append(res, DualValue{Marker: tag.marker, Priority: tag.priority, Price: price})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Marker.ID() < res[j].Marker.ID() })
+
+	return res
+}
+
+// dual returns the rate at which the objective changes as the constant of the expression of the
+// constraint of tag is raised. Raising the constant by delta is the same as shifting one of the
+// symbols of the constraint by -delta over its coefficient in the row of the constraint, as
+// constructed by install. The shift is absorbed by a basic symbol of the constraint should it have
+// one, which only changes its own value, and otherwise by the marker of the constraint, which
+// shifts the objective by its coefficient in the objective. Either way, error symbols carry the
+// weight of their constraint in the objective along with them.
+func (s *Solver) dual(tag Tag) float64 {
+	symbol, coeff := tag.marker, 1.0
+	switch {
+	case tag.cell.op == GTE:
+		coeff = -1.0
+	case tag.cell.op == EQ && tag.priority < Required:
+		coeff = -1.0
+	}
+
+	if _, basic := s.tabs.get(symbol); !basic && !tag.other.Zero() {
+		if _, basic := s.tabs.get(tag.other); basic {
+			symbol, coeff = tag.other, -coeff
+		}
+	}
+
+	reduced := 0.0
+	if idx := s.objective.find(symbol); idx != -1 {
+		reduced = s.objective.terms[idx].coeff
+	}
+
+	weight := 0.0
+	if symbol.Error() {
+		weight = float64(tag.priority)
+	}
+
+	return (reduced - weight) / coeff
+}
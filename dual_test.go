@@ -0,0 +1,72 @@
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDualValues(t *testing.T) {
+	s := casso.NewSolver()
+	window, left, width := s.New(), s.New(), s.New()
+
+	require.NoError(t, s.Edit(window, casso.Strong))
+	require.NoError(t, s.Suggest(window, 300))
+
+	pad, err := s.AddConstraint(left.EQ(10))
+	require.NoError(t, err)
+	fit, err := s.AddConstraint(casso.NewConstraint(casso.LTE, 0, left.T(1), width.T(1), window.T(-1)))
+	require.NoError(t, err)
+	least, err := s.AddConstraint(width.GTE(50))
+	require.NoError(t, err)
+	grow, err := s.AddConstraintWithPriority(casso.Weak, width.EQ(1000))
+	require.NoError(t, err)
+
+	// width would grow to 1000, but is held back by the window it must fit in alongside its padding,
+	// such that loosening either lets width grow by a unit and lowers the objective by the weight of
+	// its preference to grow, while shrinking the window does the opposite
+
+	require.EqualValues(t, 290, s.Val(width))
+
+	prices := make(map[casso.Symbol]float64)
+	for _, dual := range s.DualValues() {
+		prices[dual.Marker] = dual.Price
+	}
+	require.Len(t, prices, 5)
+
+	require.InDelta(t, -float64(casso.Weak), prices[pad], 1e-9)
+	require.InDelta(t, -float64(casso.Weak), prices[fit], 1e-9)
+	require.InDelta(t, 0, prices[least], 1e-9)
+	require.InDelta(t, -float64(casso.Weak), prices[grow], 1e-9)
+
+	// prices match the change in the objective as each constraint is relaxed by a unit
+
+	for _, marker := range []casso.Symbol{pad, fit, least, grow} {
+		cell, ok := s.Constraint(marker)
+		require.True(t, ok)
+
+		relaxed := cell.Expr().Constant() + 1
+		if cell.Op() == casso.LTE {
+			relaxed = cell.Expr().Constant() - 1
+		}
+
+		c := s.Clone()
+		require.NoError(t, c.UpdateConstant(marker, relaxed))
+		require.InDelta(t, prices[marker], c.ObjectiveValue()-s.ObjectiveValue(), 1e-9, marker.String())
+	}
+
+	// raising the constant of the constraint of the edit variable suggests a smaller window, which
+	// shrinks width
+
+	var edit casso.DualValue
+	for _, dual := range s.DualValues() {
+		if dual.Priority == casso.Strong {
+			edit = dual
+		}
+	}
+	require.InDelta(t, float64(casso.Weak), edit.Price, 1e-9)
+
+	c := s.Clone()
+	require.NoError(t, c.Suggest(window, 299))
+	require.InDelta(t, edit.Price, c.ObjectiveValue()-s.ObjectiveValue(), 1e-9)
+}
@@ -0,0 +1,57 @@
+//go:build !tinygo && !casso_tiny
+// +build !tinygo,!casso_tiny
+
+package casso
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DumpTableau writes the internal state of the solver in a readable textual form: one line per
+// row of the tableau, followed by the objective, the edit variables and their suggested values, and
+// the rows pending to be made feasible. Symbols are labeled by their kind and id (e.g. 'v1' for
+// external variables, 's2' for slack variables, 'e3' for error variables, and 'd4' for dummy
+// variables), or by the user data associated with them if the data is a string or a fmt.Stringer.
+func (s *Solver) DumpTableau(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, "tableau:")
+	for _, row := range s.Tableau().Rows() {
+		fmt.Fprintf(bw, "  %s = %s\n", s.label(row.Basic), s.formatExpr(row.Expr))
+	}
+
+	fmt.Fprintln(bw, "objective:")
+	fmt.Fprintf(bw, "  %s\n", s.formatExpr(s.Tableau().Objective()))
+
+	ids := make([]Symbol, 0, len(s.edits))
+	for id := range s.edits {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	fmt.Fprintln(bw, "edits:")
+	for _, id := range ids {
+		edit := s.edits[id]
+		fmt.Fprintf(bw, "  %s = %s (%s, %s/%s)\n", s.label(id), strconv.FormatFloat(edit.val, 'g', -1, 64),
+			edit.tag.priority, edit.tag.marker, edit.tag.other)
+	}
+
+	fmt.Fprintln(bw, "infeasible:")
+	for _, symbol := range s.infeasible {
+		fmt.Fprintf(bw, "  %s\n", s.label(symbol))
+	}
+
+	return bw.Flush()
+}
+
+// String returns the internal state of the solver as written by DumpTableau.
+func (s *Solver) String() string {
+	var b strings.Builder
+	_ = s.DumpTableau(&b)
+	return b.String()
+}
@@ -0,0 +1,35 @@
+//go:build !tinygo && !casso_tiny
+// +build !tinygo,!casso_tiny
+
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestDumpTableau(t *testing.T) {
+	s := casso.NewSolver()
+
+	width := casso.New()
+	s.SetSymbolData(width, "width")
+
+	_, err := s.AddConstraintWithPriority(casso.Weak, width.GTE(100))
+	require.NoError(t, err)
+	require.NoError(t, s.Edit(width, casso.Strong))
+	require.NoError(t, s.Suggest(width, 50))
+
+	var b strings.Builder
+	require.NoError(t, s.DumpTableau(&b))
+	require.Equal(t, b.String(), s.String())
+
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	require.Equal(t, "tableau:", lines[0])
+	require.True(t, strings.HasPrefix(lines[1], "  width = "))
+	require.True(t, strings.HasSuffix(lines[1], " + 50"))
+	require.Contains(t, b.String(), "\nobjective:\n")
+	require.Contains(t, b.String(), "\nedits:\n  width = 50 (strong, ")
+	require.True(t, strings.HasSuffix(b.String(), "infeasible:\n"))
+}
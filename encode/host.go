@@ -0,0 +1,330 @@
+package encode
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"github.com/lithdew/casso"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Host keeps a solver in sync with a Spec stored in a file. Every time the file is reloaded, the
+// parsed spec is diffed against the constraints and edit variables installed by the previous load,
+// and only the differences are applied to the solver.
+//
+// Constraints are matched up between loads by their terms, with terms of the same variable merged,
+// and by their constant and operator. A constraint whose priority changed between loads has its
+// priority changed in place via SetPriority, unless it is made required or no longer required, in
+// which case it is removed and re-added. A load that fails leaves both the solver and the host as
+// they were before it.
+type Host struct {
+	path   string
+	solver *casso.Solver
+
+	vars  map[string]casso.Symbol // variable name -> id
+	rules map[string][]hostRule   // constraint key -> installed constraints
+	edits map[string]SpecEdit     // variable name -> installed edit
+
+	sum [sha256.Size]byte // hash of the contents of the file as last loaded
+}
+
+type hostRule struct {
+	marker   casso.Symbol
+	priority casso.Priority
+}
+
+func NewHost(path string, s *casso.Solver) *Host {
+	return &Host{
+		path:   path,
+		solver: s,
+		vars:   make(map[string]casso.Symbol),
+		rules:  make(map[string][]hostRule),
+		edits:  make(map[string]SpecEdit),
+	}
+}
+
+func (h *Host) Solver() *casso.Solver { return h.solver }
+
+// Var returns the symbol allocated for a variable declared in the spec. Symbols remain stable across
+// reloads for as long as the variable remains declared under the same name.
+func (h *Host) Var(name string) (casso.Symbol, bool) {
+	id, ok := h.vars[name]
+	return id, ok
+}
+
+// Poll reloads the spec if the contents of the file changed since it was last loaded. It reports
+// whether the spec was reloaded. Contents are compared by hash rather than by modification time and
+// size, as a rewrite that keeps the size of the file may land within the granularity of its
+// timestamps.
+func (h *Host) Poll() (bool, error) {
+	data, err := ioutil.ReadFile(h.path)
+	if err != nil {
+		return false, err
+	}
+	if sha256.Sum256(data) == h.sum {
+		return false, nil
+	}
+	return true, h.load(data)
+}
+
+// Watch polls the file for modifications every interval until stop is closed, calling fn every time
+// the spec is reloaded or fails to reload. As solvers are not safe for concurrent use, the solver
+// must not be used by any other goroutine while Watch is running; otherwise, call Poll instead from
+// the goroutine that owns the solver.
+func (h *Host) Watch(stop <-chan struct{}, interval time.Duration, fn func(err error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			reloaded, err := h.Poll()
+			if reloaded || err != nil {
+				fn(err)
+			}
+		}
+	}
+}
+
+// Reload reads the spec from the file, and applies all constraints and edit variables that were
+// added, removed, or changed since the last load to the solver.
+func (h *Host) Reload() error {
+	data, err := ioutil.ReadFile(h.path)
+	if err != nil {
+		return err
+	}
+	return h.load(data)
+}
+
+// load parses the spec in data, and applies it to the solver.
+func (h *Host) load(data []byte) error {
+	spec, err := ReadSpec(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	if err := h.apply(spec); err != nil {
+		return err
+	}
+
+	h.sum = sha256.Sum256(data)
+
+	return nil
+}
+
+// apply applies spec to the solver within a transaction, rolling back the solver and the state of
+// the host should any part of spec fail to apply.
+func (h *Host) apply(spec Spec) error {
+	vars := make(map[string]casso.Symbol, len(h.vars))
+	for name, id := range h.vars {
+		vars[name] = id
+	}
+	rules := make(map[string][]hostRule, len(h.rules))
+	for key, installed := range h.rules {
+		rules[key] = append([]hostRule(nil), installed...)
+	}
+	edits := make(map[string]SpecEdit, len(h.edits))
+	for name, e := range h.edits {
+		edits[name] = e
+	}
+
+	tx := h.solver.Begin()
+	if err := h.update(spec); err != nil {
+		_ = tx.Rollback()
+		h.vars, h.rules, h.edits = vars, rules, edits
+		return err
+	}
+	return tx.Commit()
+}
+
+func (h *Host) update(spec Spec) error {
+	// variables no longer declared are forgotten once the edits installed for them are removed
+
+	vars := make(map[string]casso.Symbol, len(spec.Variables))
+	for _, name := range spec.Variables {
+		if id, exists := h.vars[name]; exists {
+			vars[name] = id
+		} else {
+			vars[name] = casso.New()
+		}
+	}
+
+	// 1. resolve all constraints in the spec, keeping track of the priorities wanted for each key
+	// 2. reprioritize installed constraints whose priority changed, or remove them should they no
+	//    longer appear in the spec or be made required or no longer required
+	// 3. add constraints in the order they appear in the spec that are not yet installed
+
+	rules := make([]casso.Rule, 0, len(spec.Constraints))
+	keys := make([]string, 0, len(spec.Constraints))
+
+	wanted := make(map[string][]casso.Priority, len(spec.Constraints))
+	for _, c := range spec.Constraints {
+		rule, err := c.Rule(vars)
+		if err != nil {
+			return err
+		}
+		key := hostKey(rule.Constraint)
+		wanted[key] = append(wanted[key], rule.Priority)
+
+		rules = append(rules, rule)
+		keys = append(keys, key)
+	}
+
+	installed := make([]string, 0, len(h.rules))
+	for key := range h.rules {
+		installed = append(installed, key)
+	}
+	sort.Strings(installed)
+
+	for _, key := range installed {
+		kept := h.rules[key][:0]
+		stale := make([]hostRule, 0, len(h.rules[key]))
+		for _, r := range h.rules[key] {
+			if idx := hostFind(wanted[key], r.priority); idx != -1 {
+				wanted[key] = append(wanted[key][:idx], wanted[key][idx+1:]...)
+				kept = append(kept, r)
+				continue
+			}
+			stale = append(stale, r)
+		}
+		for _, r := range stale {
+			if idx := hostFindSoft(wanted[key]); idx != -1 && r.priority < casso.Required {
+				priority := wanted[key][idx]
+				if err := h.solver.SetPriority(r.marker, priority); err != nil {
+					return err
+				}
+				wanted[key] = append(wanted[key][:idx], wanted[key][idx+1:]...)
+				kept = append(kept, hostRule{marker: r.marker, priority: priority})
+				continue
+			}
+			if err := h.solver.RemoveConstraint(r.marker); err != nil {
+				return err
+			}
+		}
+		if len(kept) == 0 {
+			delete(h.rules, key)
+		} else {
+			h.rules[key] = kept
+		}
+	}
+
+	for i, rule := range rules {
+		key := keys[i]
+
+		idx := hostFind(wanted[key], rule.Priority)
+		if idx == -1 {
+			continue
+		}
+		wanted[key] = append(wanted[key][:idx], wanted[key][idx+1:]...)
+
+		marker, err := h.solver.AddConstraintWithPriority(rule.Priority, rule.Constraint)
+		if err != nil {
+			return err
+		}
+		h.rules[key] = append(h.rules[key], hostRule{marker: marker, priority: rule.Priority})
+	}
+
+	edits := make(map[string]SpecEdit, len(spec.Edits))
+	for _, e := range spec.Edits {
+		if _, ok := vars[e.Var]; !ok {
+			return fmt.Errorf("edit references undeclared variable %q", e.Var)
+		}
+		edits[e.Var] = e
+	}
+
+	for _, name := range hostSortedEdits(h.edits) {
+		e := h.edits[name]
+		if next, ok := edits[name]; ok && next.Priority == e.Priority {
+			continue
+		}
+		if err := h.solver.RemoveEdit(h.vars[name]); err != nil {
+			return err
+		}
+		delete(h.edits, name)
+	}
+	h.vars = vars
+
+	for _, e := range spec.Edits {
+		name := e.Var
+		id := h.vars[name]
+		if _, installed := h.edits[name]; !installed {
+			priority, err := casso.ParsePriority(e.Priority)
+			if err != nil {
+				return err
+			}
+			if err := h.solver.Edit(id, priority); err != nil {
+				return err
+			}
+		}
+		if err := h.solver.Suggest(id, e.Value); err != nil {
+			return err
+		}
+		h.edits[name] = e
+	}
+
+	return nil
+}
+
+func hostFind(priorities []casso.Priority, priority casso.Priority) int {
+	for i := range priorities {
+		if priorities[i] == priority {
+			return i
+		}
+	}
+	return -1
+}
+
+// hostFindSoft returns the index of the first priority that is not required, or -1 if there is none.
+func hostFindSoft(priorities []casso.Priority) int {
+	for i := range priorities {
+		if priorities[i] < casso.Required {
+			return i
+		}
+	}
+	return -1
+}
+
+func hostSortedEdits(edits map[string]SpecEdit) []string {
+	names := make([]string, 0, len(edits))
+	for name := range edits {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// hostKey returns a key identifying a constraint irrespective of its priority, and of the order and
+// spelling of its terms. Terms of the same variable are merged, and terms that cancel out are dropped.
+func hostKey(c casso.Constraint) string {
+	expr := c.Expr()
+
+	coeffs := make(map[casso.Symbol]float64)
+	for _, term := range expr.Terms() {
+		coeffs[term.Symbol()] += term.Coeff()
+	}
+
+	ids := make([]casso.Symbol, 0, len(coeffs))
+	for id, coeff := range coeffs {
+		if coeff != 0 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var b strings.Builder
+	for _, id := range ids {
+		b.WriteString(strconv.FormatUint(uint64(id), 10))
+		b.WriteString("*")
+		b.WriteString(strconv.FormatFloat(coeffs[id], 'g', -1, 64))
+		b.WriteString("+")
+	}
+	b.WriteString(strconv.FormatFloat(expr.Constant(), 'g', -1, 64))
+	b.WriteString(c.Op().String())
+	return b.String()
+}
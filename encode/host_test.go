@@ -0,0 +1,256 @@
+package encode_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/lithdew/casso/encode"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHostReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "casso")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "layout.json")
+
+	write := func(spec string) {
+		require.NoError(t, ioutil.WriteFile(path, []byte(spec), 0644))
+	}
+
+	write(`{
+		"variables": ["x", "w"],
+		"constraints": [
+			{"terms": [{"var": "x", "coeff": 1}], "constant": -10, "op": ">="},
+			{"terms": [{"var": "x", "coeff": 1}, {"var": "w", "coeff": -1}], "op": "=", "priority": "weak"}
+		],
+		"edits": [{"var": "w", "priority": "strong", "value": 50}]
+	}`)
+
+	s := casso.NewSolver()
+	h := encode.NewHost(path, s)
+
+	reloaded, err := h.Poll()
+	require.NoError(t, err)
+	require.True(t, reloaded)
+
+	x, ok := h.Var("x")
+	require.True(t, ok)
+	w, ok := h.Var("w")
+	require.True(t, ok)
+
+	require.EqualValues(t, 50, s.Val(x))
+	require.EqualValues(t, 50, s.Val(w))
+
+	reloaded, err = h.Poll()
+	require.NoError(t, err)
+	require.False(t, reloaded)
+
+	// Cap 'x' at 30, and suggest a new value for 'w'.
+
+	write(`{
+		"variables": ["x", "w"],
+		"constraints": [
+			{"terms": [{"var": "x", "coeff": 1}], "constant": -10, "op": ">="},
+			{"terms": [{"var": "x", "coeff": 1}], "constant": -30, "op": "<="},
+			{"terms": [{"var": "x", "coeff": 1}, {"var": "w", "coeff": -1}], "op": "=", "priority": "weak"}
+		],
+		"edits": [{"var": "w", "priority": "strong", "value": 20}]
+	}`)
+	require.NoError(t, h.Reload())
+
+	require.EqualValues(t, 20, s.Val(x))
+	require.EqualValues(t, 20, s.Val(w))
+
+	// Promote the equality between 'x' and 'w' to be required, and drop the edit variable and cap.
+
+	write(`{
+		"variables": ["x", "w"],
+		"constraints": [
+			{"terms": [{"var": "x", "coeff": 1}], "constant": -10, "op": ">="},
+			{"terms": [{"var": "w", "coeff": -1}, {"var": "x", "coeff": 1}], "op": "=", "priority": "required"},
+			{"terms": [{"var": "w", "coeff": 1}], "constant": -70, "op": "=", "priority": "medium"}
+		]
+	}`)
+	require.NoError(t, h.Reload())
+
+	require.EqualValues(t, 70, s.Val(x))
+	require.EqualValues(t, 70, s.Val(w))
+
+	// Touching the file leaves its contents as they were, and does not have it reloaded.
+
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(path, later, later))
+
+	reloaded, err = h.Poll()
+	require.NoError(t, err)
+	require.False(t, reloaded)
+
+	// Rewriting the file with contents of the same size and the same modification time has it
+	// reloaded.
+
+	write(`{
+		"variables": ["x", "w"],
+		"constraints": [
+			{"terms": [{"var": "x", "coeff": 1}], "constant": -10, "op": ">="},
+			{"terms": [{"var": "w", "coeff": -1}, {"var": "x", "coeff": 1}], "op": "=", "priority": "required"},
+			{"terms": [{"var": "w", "coeff": 1}], "constant": -80, "op": "=", "priority": "medium"}
+		]
+	}`)
+	require.NoError(t, os.Chtimes(path, later, later))
+
+	reloaded, err = h.Poll()
+	require.NoError(t, err)
+	require.True(t, reloaded)
+	require.EqualValues(t, 80, s.Val(w))
+}
+
+func TestHostReloadSpelling(t *testing.T) {
+	dir, err := ioutil.TempDir("", "casso")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "layout.json")
+
+	write := func(spec string) {
+		require.NoError(t, ioutil.WriteFile(path, []byte(spec), 0644))
+	}
+
+	// Re-adding a constraint allocates new symbols for its marker and slack variables, such that
+	// a reload that leaves all constraints installed as they were allocates no symbols.
+
+	allocated := func(fn func()) uint64 {
+		before := casso.New()
+		fn()
+		return uint64(casso.New() - before - 1)
+	}
+
+	write(`{
+		"variables": ["x", "y"],
+		"constraints": [
+			{"terms": [{"var": "x", "coeff": 1}], "constant": -10, "op": "="}
+		]
+	}`)
+
+	s := casso.NewSolver()
+	h := encode.NewHost(path, s)
+	require.NoError(t, h.Reload())
+
+	// Respelling the operator of a constraint leaves it installed as it was, and variables no longer
+	// declared are forgotten.
+
+	write(`{
+		"variables": ["x"],
+		"constraints": [
+			{"terms": [{"var": "x", "coeff": 1}], "constant": -10, "op": "=="}
+		]
+	}`)
+	require.Zero(t, allocated(func() { require.NoError(t, h.Reload()) }))
+
+	// Splitting a term of a constraint into terms of the same variable leaves it installed as it was.
+
+	write(`{
+		"variables": ["x"],
+		"constraints": [
+			{"terms": [{"var": "x", "coeff": 0.5}, {"var": "x", "coeff": 0.5}], "constant": -10, "op": "="}
+		]
+	}`)
+	require.Zero(t, allocated(func() { require.NoError(t, h.Reload()) }))
+
+	x, ok := h.Var("x")
+	require.True(t, ok)
+	require.EqualValues(t, 10, s.Val(x))
+
+	_, ok = h.Var("y")
+	require.False(t, ok)
+}
+
+func TestHostReloadPriority(t *testing.T) {
+	dir, err := ioutil.TempDir("", "casso")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "layout.json")
+
+	write := func(spec string) {
+		require.NoError(t, ioutil.WriteFile(path, []byte(spec), 0644))
+	}
+
+	write(`{
+		"variables": ["x"],
+		"constraints": [
+			{"terms": [{"var": "x", "coeff": 1}], "constant": -10, "op": "=", "priority": "weak"},
+			{"terms": [{"var": "x", "coeff": 1}], "constant": -20, "op": "=", "priority": "medium"}
+		]
+	}`)
+
+	s := casso.NewSolver()
+	h := encode.NewHost(path, s)
+	require.NoError(t, h.Reload())
+
+	x, ok := h.Var("x")
+	require.True(t, ok)
+	require.EqualValues(t, 20, s.Val(x))
+
+	// Swap the priorities of both constraints; they are reprioritized rather than re-added, such that
+	// no new markers are allocated for them.
+
+	write(`{
+		"variables": ["x"],
+		"constraints": [
+			{"terms": [{"var": "x", "coeff": 1}], "constant": -10, "op": "=", "priority": "strong"},
+			{"terms": [{"var": "x", "coeff": 1}], "constant": -20, "op": "=", "priority": "medium"}
+		]
+	}`)
+
+	before := casso.New()
+	require.NoError(t, h.Reload())
+	require.Equal(t, before+1, casso.New())
+
+	require.EqualValues(t, 10, s.Val(x))
+
+	markers := func() map[casso.Symbol]struct{} {
+		res := make(map[casso.Symbol]struct{})
+		for _, info := range s.Constraints() {
+			res[info.Marker] = struct{}{}
+		}
+		return res
+	}
+	installed := markers()
+	require.Len(t, installed, 2)
+
+	// A spec that fails to apply partway through leaves the solver as it was.
+
+	write(`{
+		"variables": ["x"],
+		"constraints": [
+			{"terms": [{"var": "x", "coeff": 1}], "constant": -10, "op": "=", "priority": "weak"},
+			{"terms": [{"var": "x", "coeff": 1}], "constant": -30, "op": "=", "priority": "required"}
+		],
+		"edits": [{"var": "y", "priority": "strong", "value": 50}]
+	}`)
+	require.Error(t, h.Reload())
+
+	require.EqualValues(t, 10, s.Val(x))
+	require.Equal(t, installed, markers())
+
+	// The host is left as it was too, such that the next load is diffed against the same state.
+
+	write(`{
+		"variables": ["x"],
+		"constraints": [
+			{"terms": [{"var": "x", "coeff": 1}], "constant": -10, "op": "=", "priority": "weak"}
+		]
+	}`)
+	require.NoError(t, h.Reload())
+
+	require.EqualValues(t, 10, s.Val(x))
+	require.Len(t, markers(), 1)
+	for marker := range markers() {
+		require.Contains(t, installed, marker)
+	}
+}
@@ -0,0 +1,194 @@
+package encode
+
+import (
+	"fmt"
+	"github.com/lithdew/casso"
+	"io"
+	"strconv"
+)
+
+// Recorder wraps a solver, recording the constraints and edit variables installed through it such
+// that they may be exported as a Spec. Constraints and edit variables are exported as they stand in
+// the solver at the time of export, such that changes made to them directly on the solver, such as
+// removing them or changing their priorities, are exported as well.
+type Recorder struct {
+	solver *casso.Solver
+
+	names map[casso.Symbol]string // variable id -> name
+	ids   map[string]casso.Symbol // name -> variable id
+	vars  []casso.Symbol          // variables in order of first use
+
+	markers []casso.Symbol // markers in order of installation
+	edits   []casso.Symbol // edit variables in order of registration
+}
+
+func NewRecorder(s *casso.Solver) *Recorder {
+	return &Recorder{
+		solver: s,
+		names:  make(map[casso.Symbol]string),
+		ids:    make(map[string]casso.Symbol),
+	}
+}
+
+func (r *Recorder) Solver() *casso.Solver { return r.solver }
+
+// New allocates a new variable with the given name.
+func (r *Recorder) New(name string) casso.Symbol {
+	id := casso.New()
+	r.Name(id, name)
+	return id
+}
+
+// Name assigns a name to an existing variable. Variables that are never named are exported with
+// generated names. It panics if name is already assigned to another variable, as specs may not
+// declare the same variable twice.
+func (r *Recorder) Name(id casso.Symbol, name string) {
+	if other, taken := r.ids[name]; taken && other != id {
+		panic(fmt.Sprintf("casso: variable name %q is already assigned to another variable", name))
+	}
+	if old, exists := r.names[id]; exists {
+		delete(r.ids, old)
+	} else {
+		r.vars = append(r.vars, id)
+	}
+	r.names[id] = name
+	r.ids[name] = id
+}
+
+func (r *Recorder) AddConstraint(cell casso.Constraint) (casso.Symbol, error) {
+	return r.AddConstraintWithPriority(casso.Required, cell)
+}
+
+func (r *Recorder) AddConstraintWithPriority(priority casso.Priority, cell casso.Constraint) (casso.Symbol, error) {
+	marker, err := r.solver.AddConstraintWithPriority(priority, cell)
+	if err != nil {
+		return marker, err
+	}
+	r.markers = append(r.markers, marker)
+	return marker, nil
+}
+
+func (r *Recorder) RemoveConstraint(marker casso.Symbol) error {
+	if err := r.solver.RemoveConstraint(marker); err != nil {
+		return err
+	}
+	r.markers = recorderDrop(r.markers, marker)
+	return nil
+}
+
+func (r *Recorder) SetPriority(marker casso.Symbol, priority casso.Priority) error {
+	return r.solver.SetPriority(marker, priority)
+}
+
+func (r *Recorder) Edit(id casso.Symbol, priority casso.Priority) error {
+	_, exists := r.solver.EditPriority(id)
+	if err := r.solver.Edit(id, priority); err != nil {
+		return err
+	}
+	if !exists {
+		r.edits = append(recorderDrop(r.edits, id), id)
+	}
+	return nil
+}
+
+func (r *Recorder) RemoveEdit(id casso.Symbol) error {
+	if err := r.solver.RemoveEdit(id); err != nil {
+		return err
+	}
+	r.edits = recorderDrop(r.edits, id)
+	return nil
+}
+
+func (r *Recorder) SetEditPriority(id casso.Symbol, priority casso.Priority) error {
+	return r.solver.SetEditPriority(id, priority)
+}
+
+func (r *Recorder) Suggest(id casso.Symbol, val float64) error {
+	return r.solver.Suggest(id, val)
+}
+
+// recorderDrop removes the first occurrence of id from ids.
+func recorderDrop(ids []casso.Symbol, id casso.Symbol) []casso.Symbol {
+	for i := range ids {
+		if ids[i] == id {
+			return append(ids[:i], ids[i+1:]...)
+		}
+	}
+	return ids
+}
+
+// Spec exports all recorded constraints and edit variables that are still installed.
+func (r *Recorder) Spec() Spec {
+	names := make(map[casso.Symbol]string, len(r.names))
+	taken := make(map[string]struct{}, len(r.names))
+	for id, name := range r.names {
+		names[id] = name
+		taken[name] = struct{}{}
+	}
+	vars := append([]casso.Symbol(nil), r.vars...)
+
+	name := func(id casso.Symbol) string {
+		if name, ok := names[id]; ok {
+			return name
+		}
+		name := "v" + strconv.Itoa(len(vars))
+		for {
+			if _, exists := taken[name]; !exists {
+				break
+			}
+			name = "_" + name
+		}
+		names[id] = name
+		taken[name] = struct{}{}
+		vars = append(vars, id)
+		return name
+	}
+
+	var spec Spec
+
+	for _, marker := range r.markers {
+		cell, ok := r.solver.Constraint(marker)
+		if !ok {
+			continue
+		}
+		priority, _ := r.solver.ConstraintPriority(marker)
+
+		expr := cell.Expr()
+		terms := expr.Terms()
+
+		c := SpecConstraint{
+			Terms:    make([]SpecTerm, 0, len(terms)),
+			Constant: expr.Constant(),
+			Op:       cell.Op().String(),
+		}
+		if priority != casso.Required {
+			c.Priority = priority.String()
+		}
+		for _, term := range terms {
+			c.Terms = append(c.Terms, SpecTerm{Var: name(term.Symbol()), Coeff: term.Coeff()})
+		}
+
+		spec.Constraints = append(spec.Constraints, c)
+	}
+
+	for _, id := range r.edits {
+		priority, ok := r.solver.EditPriority(id)
+		if !ok {
+			continue
+		}
+		val, _ := r.solver.Suggested(id)
+		spec.Edits = append(spec.Edits, SpecEdit{Var: name(id), Priority: priority.String(), Value: val})
+	}
+
+	spec.Variables = make([]string, 0, len(vars))
+	for _, id := range vars {
+		spec.Variables = append(spec.Variables, names[id])
+	}
+
+	return spec
+}
+
+// Export writes all recorded constraints and edit variables that are still installed as a Spec.
+func (r *Recorder) Export(w io.Writer) error {
+	return r.Spec().Write(w)
+}
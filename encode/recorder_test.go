@@ -0,0 +1,128 @@
+package encode_test
+
+import (
+	"bytes"
+	"github.com/lithdew/casso"
+	"github.com/lithdew/casso/encode"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestRecorderExport(t *testing.T) {
+	r := encode.NewRecorder(casso.NewSolver())
+
+	l := r.New("left")
+	m := r.New("mid")
+	w := r.New("width")
+	u := casso.New() // left unnamed on purpose
+
+	_, err := r.AddConstraint(casso.NewConstraint(casso.EQ, 0, w.T(1), l.T(1), m.T(-2)))
+	require.NoError(t, err)
+
+	_, err = r.AddConstraint(casso.NewConstraint(casso.GTE, 0, l.T(1)))
+	require.NoError(t, err)
+
+	removed, err := r.AddConstraintWithPriority(casso.Weak, casso.NewConstraint(casso.GTE, -10, l.T(1)))
+	require.NoError(t, err)
+	require.NoError(t, r.RemoveConstraint(removed))
+
+	_, err = r.AddConstraintWithPriority(casso.Strong, casso.NewConstraint(casso.EQ, -20, u.T(1)))
+	require.NoError(t, err)
+
+	require.NoError(t, r.Edit(w, casso.Strong))
+	require.NoError(t, r.Suggest(w, 100))
+
+	var buf bytes.Buffer
+	require.NoError(t, r.Export(&buf))
+
+	spec, err := encode.ReadSpec(&buf)
+	require.NoError(t, err)
+
+	require.EqualValues(t, []string{"left", "mid", "width", "v3"}, spec.Variables)
+	require.Len(t, spec.Constraints, 3)
+	require.EqualValues(t, "strong", spec.Constraints[2].Priority)
+	require.EqualValues(t, []encode.SpecEdit{{Var: "width", Priority: "strong", Value: 100}}, spec.Edits)
+
+	// Load the exported spec into a fresh solver, and check that it solves to the same values.
+
+	s := casso.NewSolver()
+
+	vars, err := spec.Load(s)
+	require.NoError(t, err)
+
+	require.EqualValues(t, r.Solver().Val(l), s.Val(vars["left"]))
+	require.EqualValues(t, r.Solver().Val(m), s.Val(vars["mid"]))
+	require.EqualValues(t, r.Solver().Val(w), s.Val(vars["width"]))
+	require.EqualValues(t, 20, s.Val(vars["v3"]))
+}
+
+func TestSpecLoadUndeclaredVariable(t *testing.T) {
+	spec := encode.Spec{
+		Variables: []string{"x"},
+		Constraints: []encode.SpecConstraint{
+			{Terms: []encode.SpecTerm{{Var: "y", Coeff: 1}}, Op: ">="},
+		},
+	}
+	_, err := spec.Load(casso.NewSolver())
+	require.Error(t, err)
+}
+
+func TestRecorderNameTaken(t *testing.T) {
+	r := encode.NewRecorder(casso.NewSolver())
+
+	x := r.New("x")
+	require.Panics(t, func() { r.New("x") })
+
+	// renaming a variable frees up its old name
+
+	r.Name(x, "left")
+	y := r.New("x")
+
+	_, err := r.AddConstraint(casso.NewConstraint(casso.EQ, 0, x.T(1), y.T(-1)))
+	require.NoError(t, err)
+
+	spec := r.Spec()
+	require.Equal(t, []string{"left", "x"}, spec.Variables)
+}
+
+func TestRecorderRoundTrip(t *testing.T) {
+	r := encode.NewRecorder(casso.NewSolver())
+	s := r.Solver()
+
+	x := r.New("x")
+	y := r.New("y")
+	z := r.New("z")
+
+	_, err := r.AddConstraint(casso.NewConstraint(casso.EQ, 0, y.T(1), x.T(-2)))
+	require.NoError(t, err)
+	pin, err := r.AddConstraintWithPriority(casso.Strong, x.EQ(10))
+	require.NoError(t, err)
+	prefer, err := r.AddConstraintWithPriority(casso.Weak, x.EQ(30))
+	require.NoError(t, err)
+	require.NoError(t, r.Edit(y, casso.Medium))
+	require.NoError(t, r.Suggest(y, 50))
+	require.NoError(t, r.Edit(z, casso.Weak))
+	require.NoError(t, r.Suggest(z, 5))
+	require.NoError(t, r.RemoveEdit(y))
+
+	// mutate the solver directly rather than through the recorder
+
+	require.NoError(t, s.RemoveConstraint(pin))
+	require.NoError(t, s.SetPriority(prefer, casso.Strong))
+	require.NoError(t, s.SetEditPriority(z, casso.Strong))
+	require.NoError(t, s.Suggest(z, 7))
+
+	spec := r.Spec()
+	require.Len(t, spec.Constraints, 2)
+	require.EqualValues(t, "strong", spec.Constraints[1].Priority)
+	require.EqualValues(t, []encode.SpecEdit{{Var: "z", Priority: "strong", Value: 7}}, spec.Edits)
+
+	loaded := casso.NewSolver()
+	vars, err := spec.Load(loaded)
+	require.NoError(t, err)
+
+	require.EqualValues(t, 30, loaded.Val(vars["x"]))
+	require.EqualValues(t, s.Val(x), loaded.Val(vars["x"]))
+	require.EqualValues(t, s.Val(y), loaded.Val(vars["y"]))
+	require.EqualValues(t, s.Val(z), loaded.Val(vars["z"]))
+}
@@ -0,0 +1,120 @@
+// Package encode reads and writes declarative descriptions of casso constraint systems.
+package encode
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/lithdew/casso"
+	"io"
+)
+
+// Spec is a declarative description of constraints and edit variables over a set of named variables.
+// Specs are encoded as JSON.
+type Spec struct {
+	Variables   []string         `json:"variables"`
+	Edits       []SpecEdit       `json:"edits,omitempty"`
+	Constraints []SpecConstraint `json:"constraints"`
+}
+
+// SpecEdit registers a variable as an edit variable, and suggests a value for it.
+type SpecEdit struct {
+	Var      string  `json:"var"`
+	Priority string  `json:"priority"`
+	Value    float64 `json:"value"`
+}
+
+// SpecConstraint describes the constraint 'sum(coeff * var) + constant <op> 0'. If the priority is
+// omitted, the constraint is required.
+type SpecConstraint struct {
+	Terms    []SpecTerm `json:"terms"`
+	Constant float64    `json:"constant,omitempty"`
+	Op       string     `json:"op"`
+	Priority string     `json:"priority,omitempty"`
+}
+
+type SpecTerm struct {
+	Var   string  `json:"var"`
+	Coeff float64 `json:"coeff"`
+}
+
+func ReadSpec(r io.Reader) (Spec, error) {
+	var spec Spec
+	if err := json.NewDecoder(r).Decode(&spec); err != nil {
+		return spec, err
+	}
+	return spec, nil
+}
+
+func (sp Spec) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sp)
+}
+
+// Load allocates a new symbol for every variable in the spec, and installs all of the spec's
+// edit variables and constraints into s. It returns the symbols allocated for each variable.
+func (sp Spec) Load(s *casso.Solver) (map[string]casso.Symbol, error) {
+	vars := make(map[string]casso.Symbol, len(sp.Variables))
+	for _, name := range sp.Variables {
+		if _, exists := vars[name]; exists {
+			return nil, fmt.Errorf("variable %q is declared more than once", name)
+		}
+		vars[name] = casso.New()
+	}
+
+	for _, c := range sp.Constraints {
+		rule, err := c.Rule(vars)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := s.AddConstraintWithPriority(rule.Priority, rule.Constraint); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, e := range sp.Edits {
+		id, ok := vars[e.Var]
+		if !ok {
+			return nil, fmt.Errorf("edit references undeclared variable %q", e.Var)
+		}
+		priority, err := casso.ParsePriority(e.Priority)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.Edit(id, priority); err != nil {
+			return nil, err
+		}
+		if err := s.Suggest(id, e.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	return vars, nil
+}
+
+// Rule converts c into a constraint and its priority, resolving variable names using vars.
+func (c SpecConstraint) Rule(vars map[string]casso.Symbol) (casso.Rule, error) {
+	op, err := casso.ParseOp(c.Op)
+	if err != nil {
+		return casso.Rule{}, err
+	}
+
+	priority := casso.Required
+	if c.Priority != "" {
+		priority, err = casso.ParsePriority(c.Priority)
+		if err != nil {
+			return casso.Rule{}, err
+		}
+	}
+
+	terms := make([]casso.Term, 0, len(c.Terms))
+	for _, term := range c.Terms {
+		id, ok := vars[term.Var]
+		if !ok {
+			return casso.Rule{}, fmt.Errorf("constraint references undeclared variable %q", term.Var)
+		}
+		terms = append(terms, id.T(term.Coeff))
+	}
+
+	return casso.Rule{Priority: priority, Constraint: casso.NewConstraint(op, c.Constant, terms...)}, nil
+}
@@ -1,6 +1,9 @@
 package casso
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrBadPriority         = errors.New("priority must be non-negative and not required for edit variable")
@@ -8,4 +11,59 @@ var (
 	ErrBadDummyVariable    = errors.New("constraint is unsatisfiable: non-zero dummy variable")
 	ErrBadConstraintMarker = errors.New("symbol is not registered to refer to a constraint")
 	ErrBadTermInConstraint = errors.New("one of the terms in the constraint references a nil symbol")
+	ErrUnsatisfiable       = errors.New("constraint is unsatisfiable")
+	ErrUndetermined        = errors.New("target is not linearly determined by the given edit variables")
+	ErrBadDefinition       = errors.New("no expression is defined under the given name")
+	ErrBadPriorityChange   = errors.New("priority of a constraint may not be changed to or from required")
+	ErrBadStay             = errors.New("symbol has no stay installed")
+	ErrBadGroup            = errors.New("group does not exist or was already removed")
+	ErrUnknownPriority     = errors.New("unknown priority")
+	ErrUnknownOp           = errors.New("unknown operator")
+	ErrNothingToUndo       = errors.New("no operation to undo")
+	ErrNothingToRedo       = errors.New("no operation to redo")
+	ErrTxDone              = errors.New("transaction has already been committed or rolled back")
+	ErrSingularBasis       = errors.New("installed constraints do not determine the basic symbols of the tableau")
+	ErrMaxIterations       = errors.New("solver pivoted the maximum number of times without reaching an optimum")
+	ErrUnbounded           = errors.New("objective is unbounded")
+	ErrUnsupported         = errors.New("not supported by this build of casso")
 )
+
+// ConstraintError is returned by AddConstraint and RemoveConstraint, identifying the constraint that
+// could not be installed or removed. It wraps the sentinel error describing why, such that it may be
+// tested for via errors.Is.
+type ConstraintError struct {
+	Marker     Symbol     // marker of the constraint, or the zero symbol if none was allocated
+	Priority   Priority   // priority the constraint was to be installed with, or was installed with
+	Constraint Constraint // constraint as supplied by the caller
+	Err        error
+
+	// Conflicts holds the markers of an irreducible set of installed required constraints that a
+	// required constraint is unsatisfiable alongside, should the solver be created using
+	// WithConflicts and the constraint fail to be added for being unsatisfiable.
+	Conflicts []Symbol
+
+	desc string // constraint rendered with the labels of its symbols
+}
+
+func (s *Solver) constraintError(marker Symbol, priority Priority, cell Constraint, err error) error {
+	if err == nil {
+		return nil
+	}
+	e := &ConstraintError{Marker: marker, Priority: priority, Constraint: cell, Err: err}
+	if len(cell.expr.terms) > 0 || cell.expr.constant != 0 {
+		e.desc = s.format(cell)
+	}
+	return e
+}
+
+func (e *ConstraintError) Error() string {
+	if e.desc == "" {
+		return fmt.Sprintf("%v (marker %s)", e.Err, e.Marker)
+	}
+	if len(e.Conflicts) > 0 {
+		return fmt.Sprintf("%v: %s (priority %s, marker %s, conflicts with %v)", e.Err, e.desc, e.Priority, e.Marker, e.Conflicts)
+	}
+	return fmt.Sprintf("%v: %s (priority %s, marker %s)", e.Err, e.desc, e.Priority, e.Marker)
+}
+
+func (e *ConstraintError) Unwrap() error { return e.Err }
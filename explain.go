@@ -0,0 +1,39 @@
+package casso
+
+import "sort"
+
+// Explain returns the installed constraints that pin the current value of the variable id, such as
+// to find out why a widget refuses to grow. These are the constraints holding with equality whose
+// marker or error symbols the row of id is expressed in terms of, such that changing the constant of
+// any one of them would move id. Constraints that are violated or satisfied with room to spare do
+// not pin id, and are left out. The constraints of edit variables and stays are described as they
+// are by Constraints.
+//
+// Constraints are ordered by priority, strongest first, and then in the order they were installed
+// in. Should id not be basic, its value is pinned by no constraint, and nil is returned.
+func (s *Solver) Explain(id Symbol) []ConstraintInfo {
+	if s.dirty && s.opts.lazy {
+		_ = s.Solve()
+	}
+
+	row, exists := s.tabs.get(id)
+	if !exists {
+		return nil
+	}
+
+	var res []ConstraintInfo
+	for _, tag := range s.tags {
+		if row.expr.find(tag.marker) == -1 && (tag.other.Zero() || row.expr.find(tag.other) == -1) {
+			continue
+		}
+		res = append(res, s.info(tag))
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Priority != res[j].Priority {
+			return res[i].Priority > res[j].Priority
+		}
+		return res[i].Marker.ID() < res[j].Marker.ID()
+	})
+
+	return res
+}
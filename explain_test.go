@@ -0,0 +1,51 @@
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	s := casso.NewSolver()
+	window, left, width := s.New(), s.New(), s.New()
+
+	require.NoError(t, s.Edit(window, casso.Strong))
+	require.NoError(t, s.Suggest(window, 300))
+
+	pad, err := s.AddConstraint(left.EQ(10))
+	require.NoError(t, err)
+	fit, err := s.AddConstraint(casso.NewConstraint(casso.LTE, 0, left.T(1), width.T(1), window.T(-1)))
+	require.NoError(t, err)
+	grow, err := s.AddConstraintWithPriority(casso.Weak, width.EQ(1000))
+	require.NoError(t, err)
+
+	// width would grow to 1000, but is held back by the window it must fit in alongside its padding
+
+	require.EqualValues(t, 290, s.Val(width))
+
+	markers := func(infos []casso.ConstraintInfo) []casso.Symbol {
+		var res []casso.Symbol
+		for _, info := range infos {
+			res = append(res, info.Marker)
+		}
+		return res
+	}
+
+	explained := s.Explain(width)
+	require.Len(t, explained, 3)
+	require.Equal(t, []casso.Symbol{pad, fit}, markers(explained[:2]))
+	require.Equal(t, casso.Required, explained[0].Priority)
+	require.True(t, explained[2].Edit)
+	require.Equal(t, window.EQ(300), explained[2].Constraint)
+	require.NotContains(t, markers(explained), grow)
+
+	// capping width has the cap pin it instead, with the window left to spare
+
+	limit, err := s.AddConstraint(width.LTE(100))
+	require.NoError(t, err)
+	require.EqualValues(t, 100, s.Val(width))
+	require.Equal(t, []casso.Symbol{limit}, markers(s.Explain(width)))
+
+	require.Nil(t, s.Explain(s.New()))
+}
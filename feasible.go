@@ -0,0 +1,37 @@
+package casso
+
+// CanAddConstraint reports whether c could be added to the solver with the given priority, returning
+// the error AddConstraintWithPriority would return without modifying the solver. Constraints that are
+// not required may always be added so long as they reference no zero symbol, while required
+// constraints are added to a scratch copy of the rows of the tableau to check that they are
+// satisfiable alongside the required constraints already installed.
+func (s *Solver) CanAddConstraint(c Constraint, priority Priority) error {
+	if priority < Required {
+		for _, term := range c.expr.terms {
+			if term.id.Zero() && !s.eqz(term.coeff) {
+				return s.constraintError(zero, priority, c, ErrBadTermInConstraint)
+			}
+		}
+		return nil
+	}
+
+	scratch := &Solver{
+		tabs:      newTabs(s.tabs.len() + 1),
+		tags:      make(map[Symbol]Tag, 1),
+		objective: s.objective.clone(),
+		opts:      s.opts,
+		count:     s.count,
+		zeroed:    s.zeroed,
+	}
+	for _, tab := range s.tabs.entries {
+		scratch.tabs.set(tab.basic, tab.row.clone())
+	}
+	scratch.indexColumns()
+	scratch.opts.manual = true // feasibility is settled before the objective is optimized
+
+	marker, err := scratch.addConstraint(priority, c)
+	if err != nil {
+		return s.constraintError(marker, priority, c, err)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package casso_test
+
+import (
+	"errors"
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCanAddConstraint(t *testing.T) {
+	s := casso.NewSolver()
+
+	x := casso.New()
+	y := casso.New()
+
+	_, err := s.AddConstraint(x.GTE(10))
+	require.NoError(t, err)
+	_, err = s.AddConstraint(casso.NewConstraint(casso.EQ, 0, y.T(1), x.T(-2)))
+	require.NoError(t, err)
+	require.NoError(t, s.Edit(x, casso.Strong))
+	require.NoError(t, s.Suggest(x, 30))
+
+	before := s.Tableau().Rows()
+
+	require.NoError(t, s.CanAddConstraint(y.LTE(100), casso.Required))
+	require.True(t, errors.Is(s.CanAddConstraint(y.LTE(10), casso.Required), casso.ErrUnsatisfiable))
+	require.NoError(t, s.CanAddConstraint(y.LTE(10), casso.Strong))
+	require.True(t, errors.Is(s.CanAddConstraint(casso.NewConstraint(casso.EQ, 0, casso.Symbol(0).T(1)), casso.Weak), casso.ErrBadTermInConstraint))
+
+	require.Equal(t, before, s.Tableau().Rows())
+	require.EqualValues(t, 30, s.Val(x))
+	require.EqualValues(t, 60, s.Val(y))
+
+	_, err = s.AddConstraint(y.LTE(10))
+	require.Error(t, err)
+}
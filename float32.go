@@ -0,0 +1,24 @@
+package casso
+
+// Val32 returns the value of id as Val does, rounded to the nearest float32 with ties rounded to
+// even, for consumers such as graphics pipelines that work in single precision.
+func (s *Solver) Val32(id Symbol) float32 {
+	return float32(s.Val(id))
+}
+
+// Vals32 appends the values of ids to dst in the order of ids, read and rounded as Val32 reads and
+// rounds them, and
+// returns the extended slice. Passing the slice returned by the last call truncated to zero length
+// as dst, such as the vertex buffer of the last frame, has no values be allocated.
+func (s *Solver) Vals32(dst []float32, ids []Symbol) []float32 {
+	if s.dirty && s.opts.lazy {
+		_ = s.Solve()
+	}
+	for _, id := range ids {
+		if s.strict != nil {
+			s.strict.checkVal(id)
+		}
+		dst = append(dst, float32(s.tabs.read(id)))
+	}
+	return dst
+}
@@ -0,0 +1,49 @@
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestVals32(t *testing.T) {
+	s := casso.NewSolver()
+
+	x, y, z := casso.New(), casso.New(), casso.New()
+
+	_, err := s.AddConstraint(x.EQ(0.1))
+	require.NoError(t, err)
+	_, err = s.AddConstraint(casso.NewConstraint(casso.EQ, 0, y.T(3), x.T(-1)))
+	require.NoError(t, err)
+
+	require.Equal(t, float32(0.1), s.Val32(x))
+	require.Equal(t, float32(s.Val(y)), s.Val32(y))
+	require.Equal(t, float32(0), s.Val32(z))
+
+	// values are appended in the order of ids, reusing the storage of dst
+
+	dst := make([]float32, 0, 4)
+	vals := s.Vals32(dst, []casso.Symbol{y, z, x})
+	require.Equal(t, []float32{s.Val32(y), 0, s.Val32(x)}, vals)
+	require.Equal(t, &dst[:1][0], &vals[0])
+
+	require.Equal(t, []float32{s.Val32(x)}, s.Vals32(vals[:0], []casso.Symbol{x}))
+}
+
+func BenchmarkVals32(b *testing.B) {
+	s := casso.NewSolver()
+	buildWidgetLayout(s, 500)
+
+	ids := make([]casso.Symbol, 0, len(s.Vals()))
+	for id := range s.Vals() {
+		ids = append(ids, id)
+	}
+	dst := make([]float32, 0, len(ids))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		dst = s.Vals32(dst[:0], ids)
+	}
+}
@@ -0,0 +1,63 @@
+package casso
+
+// Fork returns a copy of the solver that shares the rows of its tableau and its column index with
+// the solver, such that hypothetical constraints may be added to the fork to measure the layout
+// that results, after which the fork is discarded. Either solver copies a shared row or column only
+// once it first modifies it, so forking takes time proportional to the number of rows and columns
+// of the tableau rather than to the number of terms within them. Forks otherwise behave as clones.
+// See Clone.
+func (s *Solver) Fork() *Solver {
+	c := s.copyState()
+	c.cols = make(map[Symbol]*column, len(s.cols))
+	c.borrowed = make(map[Symbol]struct{}, s.tabs.len())
+	c.borrowedCols = make(map[Symbol]struct{}, len(s.cols))
+
+	if s.borrowed == nil {
+		s.borrowed = make(map[Symbol]struct{}, s.tabs.len())
+	}
+	if s.borrowedCols == nil {
+		s.borrowedCols = make(map[Symbol]struct{}, len(s.cols))
+	}
+
+	for _, tab := range s.tabs.entries {
+		c.tabs.set(tab.basic, tab.row)
+		c.borrowed[tab.basic] = struct{}{}
+		s.borrowed[tab.basic] = struct{}{}
+	}
+	c.tabs.vals = s.tabs.copyVals()
+	for id, col := range s.cols {
+		c.cols[id] = col
+		c.borrowedCols[id] = struct{}{}
+		s.borrowedCols[id] = struct{}{}
+	}
+
+	return c
+}
+
+// ownRow returns row, the row of basic, with a term buffer of its own that has room for extra more
+// terms should its terms be shared with a fork. The caller is expected to store the returned row.
+func (s *Solver) ownRow(basic Symbol, row Constraint, extra int) Constraint {
+	if len(s.borrowed) == 0 {
+		return row
+	}
+	if _, ok := s.borrowed[basic]; !ok {
+		return row
+	}
+	delete(s.borrowed, basic)
+	row.expr.terms = append(s.alloc(len(row.expr.terms)+extra), row.expr.terms...)
+	return row
+}
+
+// ownColumn returns the column of id, copying it first should it be shared with a fork.
+func (s *Solver) ownColumn(id Symbol, col *column) *column {
+	if len(s.borrowedCols) == 0 {
+		return col
+	}
+	if _, ok := s.borrowedCols[id]; !ok {
+		return col
+	}
+	delete(s.borrowedCols, id)
+	res := col.clone(1)
+	s.cols[id] = res
+	return res
+}
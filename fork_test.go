@@ -0,0 +1,57 @@
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestFork(t *testing.T) {
+	s := casso.NewSolver()
+
+	left := casso.New()
+	width := casso.New()
+	panel := casso.New()
+	window := casso.New()
+
+	_, err := s.AddConstraint(left.EQ(0))
+	require.NoError(t, err)
+	_, err = s.AddConstraint(casso.NewConstraint(casso.EQ, 0, panel.T(1), left.T(-1), width.T(-1)))
+	require.NoError(t, err)
+	_, err = s.AddConstraint(casso.NewConstraint(casso.LTE, 0, panel.T(1), window.T(-1)))
+	require.NoError(t, err)
+	_, err = s.AddConstraintWithPriority(casso.Weak, width.EQ(300))
+	require.NoError(t, err)
+	require.NoError(t, s.Edit(window, casso.Strong))
+	require.NoError(t, s.Suggest(window, 800))
+
+	rows := s.Clone().Tableau().Rows()
+
+	// what if the panel were collapsed?
+
+	f := s.Fork()
+	require.Equal(t, rows, f.Tableau().Rows())
+
+	collapsed, err := f.AddConstraintWithPriority(casso.Strong, width.EQ(0))
+	require.NoError(t, err)
+	require.NoError(t, f.Suggest(window, 600))
+	require.EqualValues(t, 0, f.Val(panel))
+	require.EqualValues(t, 600, f.Val(window))
+
+	require.Equal(t, rows, s.Tableau().Rows())
+	require.EqualValues(t, 300, s.Val(panel))
+	require.EqualValues(t, 800, s.Val(window))
+
+	// modifying the solver does not affect the fork either
+
+	require.NoError(t, s.Suggest(window, 100))
+	require.EqualValues(t, 100, s.Val(panel))
+	require.EqualValues(t, 0, f.Val(panel))
+
+	require.NoError(t, f.RemoveConstraint(collapsed))
+	require.EqualValues(t, 300, f.Val(panel))
+
+	s.Reset()
+	require.EqualValues(t, 300, f.Val(panel))
+	require.EqualValues(t, 600, f.Val(window))
+}
@@ -0,0 +1,71 @@
+package casso
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// eval returns the value of an expression given the current values of its variables.
+func (s *Solver) eval(e Expr) float64 {
+	val := e.constant
+	for _, term := range e.terms {
+		val += term.coeff * s.Val(term.id)
+	}
+	return val
+}
+
+// label returns a human-readable label for a symbol.
+func (s *Solver) label(id Symbol) string {
+	if name, ok := s.names[id]; ok {
+		return name
+	}
+	switch data := s.data[id].(type) {
+	case string:
+		return data
+	case fmt.Stringer:
+		return data.String()
+	}
+	return id.String()
+}
+
+// format renders a constraint as a human-readable equation, such as '2 * v1 - v2 + 10 >= 0'.
+func (s *Solver) format(c Constraint) string {
+	return s.formatExpr(c.expr) + " " + c.op.String() + " 0"
+}
+
+// formatExpr renders an expression as a human-readable sum, such as '2 * v1 - v2 + 10'.
+func (s *Solver) formatExpr(e Expr) string {
+	var b strings.Builder
+
+	for i, term := range e.terms {
+		coeff := term.coeff
+		switch {
+		case i == 0 && coeff < 0:
+			b.WriteString("-")
+		case i > 0 && coeff < 0:
+			b.WriteString(" - ")
+		case i > 0:
+			b.WriteString(" + ")
+		}
+		if coeff = math.Abs(coeff); coeff != 1 {
+			b.WriteString(strconv.FormatFloat(coeff, 'g', -1, 64))
+			b.WriteString(" * ")
+		}
+		b.WriteString(s.label(term.id))
+	}
+
+	switch constant := e.constant; {
+	case len(e.terms) == 0:
+		b.WriteString(strconv.FormatFloat(constant, 'g', -1, 64))
+	case constant < 0:
+		b.WriteString(" - ")
+		b.WriteString(strconv.FormatFloat(-constant, 'g', -1, 64))
+	case constant > 0:
+		b.WriteString(" + ")
+		b.WriteString(strconv.FormatFloat(constant, 'g', -1, 64))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,89 @@
+package casso
+
+// Group refers to a set of constraints and edit variables that are removed from a solver together.
+// The zero group is invalid.
+type Group uint64
+
+type group struct {
+	markers []Symbol // markers of constraints added to the group
+	edits   []Symbol // edit variables registered under the group
+}
+
+// NewGroup creates a new empty group of constraints.
+func (s *Solver) NewGroup() Group {
+	if s.groups == nil {
+		s.groups = make(map[Group]*group)
+	}
+	s.lastGroup++
+	s.groups[s.lastGroup] = &group{}
+	return s.lastGroup
+}
+
+// AddConstraintToGroup adds a required constraint to a group. See AddConstraintToGroupWithPriority.
+func (s *Solver) AddConstraintToGroup(g Group, cell Constraint) (Symbol, error) {
+	return s.AddConstraintToGroupWithPriority(g, Required, cell)
+}
+
+// AddConstraintToGroupWithPriority adds a constraint to the solver under a group, such that it is
+// removed once the group is removed. The constraint may still be removed on its own via
+// RemoveConstraint.
+func (s *Solver) AddConstraintToGroupWithPriority(g Group, priority Priority, cell Constraint) (Symbol, error) {
+	grp, exists := s.groups[g]
+	if !exists {
+		return zero, ErrBadGroup
+	}
+	marker, err := s.AddConstraintWithPriority(priority, cell)
+	if err != nil {
+		return marker, err
+	}
+	grp.markers = append(grp.markers, marker)
+	return marker, nil
+}
+
+// EditInGroup registers an edit variable under a group, such that it is unregistered once the group
+// is removed. Variables already registered as edit variables are left out of the group.
+func (s *Solver) EditInGroup(g Group, id Symbol, priority Priority) error {
+	grp, exists := s.groups[g]
+	if !exists {
+		return ErrBadGroup
+	}
+	if _, exists := s.edits[id]; exists {
+		return s.Edit(id, priority)
+	}
+	if err := s.Edit(id, priority); err != nil {
+		return err
+	}
+	grp.edits = append(grp.edits, id)
+	return nil
+}
+
+// RemoveGroup removes all constraints and unregisters all edit variables of a group that are still
+// installed in the solver. Should any of them fail to be removed, the rest are still removed, and
+// the first error encountered is returned.
+func (s *Solver) RemoveGroup(g Group) error {
+	grp, exists := s.groups[g]
+	if !exists {
+		return ErrBadGroup
+	}
+	delete(s.groups, g)
+
+	var first error
+	for _, id := range grp.edits {
+		if !s.HasEdit(id) {
+			continue
+		}
+		if err := s.RemoveEdit(id); err != nil && first == nil {
+			first = err
+		}
+	}
+	markers := make([]Symbol, 0, len(grp.markers))
+	for _, marker := range grp.markers {
+		if s.HasConstraint(marker) {
+			markers = append(markers, marker)
+		}
+	}
+	if err := s.RemoveConstraints(markers...); err != nil && first == nil {
+		first = err
+	}
+	return first
+}
@@ -0,0 +1,45 @@
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGroup(t *testing.T) {
+	s := casso.NewSolver()
+
+	x := casso.New()
+	y := casso.New()
+
+	root, err := s.AddConstraintWithPriority(casso.Weak, x.EQ(10))
+	require.NoError(t, err)
+
+	widget := s.NewGroup()
+	require.NotZero(t, widget)
+
+	a, err := s.AddConstraintToGroup(widget, casso.NewConstraint(casso.EQ, -5, y.T(1), x.T(-1)))
+	require.NoError(t, err)
+	b, err := s.AddConstraintToGroupWithPriority(widget, casso.Strong, x.GTE(50))
+	require.NoError(t, err)
+	require.NoError(t, s.EditInGroup(widget, y, casso.Medium))
+	require.NoError(t, s.Suggest(y, 100))
+
+	require.EqualValues(t, 95, s.Val(x))
+	require.EqualValues(t, 100, s.Val(y))
+
+	// constraints removed on their own are skipped when the group is removed
+
+	require.NoError(t, s.RemoveConstraint(b))
+
+	require.NoError(t, s.RemoveGroup(widget))
+	require.False(t, s.HasConstraint(a))
+	require.False(t, s.HasEdit(y))
+	require.True(t, s.HasConstraint(root))
+	require.EqualValues(t, 10, s.Val(x))
+
+	require.Equal(t, casso.ErrBadGroup, s.RemoveGroup(widget))
+	_, err = s.AddConstraintToGroup(widget, x.EQ(0))
+	require.Equal(t, casso.ErrBadGroup, err)
+	require.Equal(t, casso.ErrBadGroup, s.EditInGroup(widget, x, casso.Strong))
+}
@@ -0,0 +1,64 @@
+package casso
+
+// Handle refers to an expression registered via EditExpr whose value may be suggested as a whole.
+type Handle struct {
+	proxy  Symbol // edit variable constrained to equal the expression
+	marker Symbol // marker of the constraint relating the proxy variable to the expression
+}
+
+// Symbol returns the edit variable standing in for the expression. Suggesting a value for the
+// symbol suggests a value for the expression.
+func (h Handle) Symbol() Symbol { return h.proxy }
+
+// EditExpr registers an expression as a unit whose value may be suggested via Suggest, such as the
+// total width of a row of boxes. A hidden proxy variable is constrained to equal the expression and
+// registered as an edit variable of the given priority, with the current value of the expression as
+// its initial suggested value.
+func (s *Solver) EditExpr(expr Expr, priority Priority) (Handle, error) {
+	if priority < 0 || priority >= Required {
+		return Handle{}, ErrBadPriority
+	}
+
+	if s.dirty && s.opts.lazy {
+		_ = s.Solve()
+	}
+
+	// the variables of the expression need not be referenced by any constraint yet, such that they
+	// are read without being checked as Val checks them under WithStrict
+
+	val := expr.constant
+	for _, term := range expr.terms {
+		val += term.coeff * s.val(term.id)
+	}
+
+	h := Handle{proxy: New()}
+
+	cell := Constraint{op: EQ, expr: expr.Sub(h.proxy.Expr())}
+
+	marker, err := s.AddConstraint(cell)
+	if err != nil {
+		return Handle{}, err
+	}
+	h.marker = marker
+
+	if err := s.Edit(h.proxy, priority); err != nil {
+		_ = s.RemoveConstraint(h.marker)
+		return Handle{}, err
+	}
+	if err := s.Suggest(h.proxy, val); err != nil {
+		_ = s.RemoveEdit(h.proxy)
+		_ = s.RemoveConstraint(h.marker)
+		return Handle{}, err
+	}
+
+	return h, nil
+}
+
+// RemoveEditExpr unregisters an expression registered via EditExpr, removing its proxy variable
+// alongside all constraints installed for it.
+func (s *Solver) RemoveEditExpr(h Handle) error {
+	if err := s.RemoveEdit(h.proxy); err != nil {
+		return err
+	}
+	return s.RemoveConstraint(h.marker)
+}
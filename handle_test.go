@@ -0,0 +1,53 @@
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestEditExpr(t *testing.T) {
+	s := casso.NewSolver()
+
+	a := casso.New()
+	b := casso.New()
+
+	_, err := s.AddConstraintWithPriority(casso.Weak, a.EQ(100))
+	require.NoError(t, err)
+	_, err = s.AddConstraintWithPriority(casso.Weak, b.EQ(200))
+	require.NoError(t, err)
+	_, err = s.AddConstraint(casso.NewConstraint(casso.EQ, 0, a.T(2), b.T(-1)))
+	require.NoError(t, err)
+
+	before := s.Val(a) + s.Val(b)
+
+	total, err := s.EditExpr(casso.NewExpr(0, a.T(1), b.T(1)), casso.Strong)
+	require.NoError(t, err)
+
+	// registering the expression does not disturb the current solution
+
+	require.InDelta(t, before, s.Val(a)+s.Val(b), 1e-9)
+
+	require.NoError(t, s.Suggest(total.Symbol(), 600))
+	require.InDelta(t, 200, s.Val(a), 1e-9)
+	require.InDelta(t, 400, s.Val(b), 1e-9)
+	require.InDelta(t, 600, s.Val(total.Symbol()), 1e-9)
+
+	require.NoError(t, s.RemoveEditExpr(total))
+	require.Equal(t, casso.ErrBadEditVariable, s.Suggest(total.Symbol(), 300))
+	require.Equal(t, casso.ErrBadEditVariable, s.RemoveEditExpr(total))
+
+	_, err = s.EditExpr(casso.NewExpr(0, a.T(1)), casso.Required)
+	require.Equal(t, casso.ErrBadPriority, err)
+}
+
+func TestEditExprStrict(t *testing.T) {
+	s := casso.NewSolver(casso.WithStrict())
+
+	a, b := casso.New(), casso.New()
+
+	total, err := s.EditExpr(casso.NewExpr(0, a.T(1), b.T(1)), casso.Strong)
+	require.NoError(t, err)
+	require.NoError(t, s.Suggest(total.Symbol(), 10))
+	require.InDelta(t, 10, s.Val(a)+s.Val(b), 1e-9)
+}
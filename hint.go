@@ -0,0 +1,68 @@
+package casso
+
+// hintPriority is the priority of the stays installed by Hint, which is weaker than that of any
+// constraint a caller would reasonably install, such that hints only settle variables that are
+// otherwise left undetermined.
+const hintPriority = Weak / 1e3
+
+// Hint installs a stay preferring a variable to take on val, such that a solver re-created from a
+// serialized layout may be seeded with the values its variables last had. Constraints added
+// afterwards are then optimized starting from a solution that is nearly right, rather than from one
+// in which hinted variables are zero, which is costly for variables such as the width of a window
+// that bound many others.
+//
+// Should the variable later be registered as an edit variable via Edit, it is registered with val
+// suggested, and its hint is removed. Hinting a variable that is already hinted updates its hint.
+// Hints are ignored for edit variables and for variables that have a stay installed via AddStay,
+// which replaces the hint of a variable hinted beforehand.
+// Hints are weaker than any other constraint, yet may still settle variables that are otherwise
+// undetermined; call ClearHints once a layout is built to have them no longer do so.
+func (s *Solver) Hint(id Symbol, val float64) error {
+	if _, exists := s.edits[id]; exists {
+		return nil
+	}
+	if s.strict != nil {
+		s.strict.checkStay("Hint", id)
+	}
+	if s.watched() {
+		defer s.notify()
+	}
+
+	if _, hinted := s.hints[id]; hinted {
+		s.stays[id] = s.suggest(s.stays[id], val)
+		if s.opts.manual {
+			s.dirty = true
+			return nil
+		}
+		return s.optimizeDualObjective()
+	}
+	if _, exists := s.stays[id]; exists {
+		return nil
+	}
+
+	err := s.addStay(id, hintPriority, val)
+	if _, installed := s.stays[id]; installed {
+		if s.hints == nil {
+			s.hints = make(map[Symbol]struct{})
+		}
+		s.hints[id] = struct{}{}
+	}
+	return err
+}
+
+// ClearHints removes all hints that were not yet taken over by edit variables.
+func (s *Solver) ClearHints() error {
+	if len(s.hints) == 0 {
+		return nil
+	}
+	markers := make([]Symbol, 0, len(s.hints))
+	for id := range s.hints {
+		markers = append(markers, s.stays[id].tag.marker)
+	}
+	err := s.RemoveConstraints(markers...)
+	for id := range s.hints {
+		delete(s.stays, id)
+		delete(s.hints, id)
+	}
+	return err
+}
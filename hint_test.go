@@ -0,0 +1,108 @@
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestHint(t *testing.T) {
+	s := casso.NewSolver()
+
+	window := casso.New()
+	width := casso.New()
+
+	require.NoError(t, s.Hint(window, 800))
+	require.EqualValues(t, 800, s.Val(window))
+
+	_, err := s.AddConstraint(casso.NewConstraint(casso.EQ, 0, width.T(2), window.T(-1)))
+	require.NoError(t, err)
+	require.EqualValues(t, 800, s.Val(window))
+	require.EqualValues(t, 400, s.Val(width))
+
+	// registering the window as an edit variable takes over its hint
+
+	require.NoError(t, s.Edit(window, casso.Strong))
+	val, ok := s.Suggested(window)
+	require.True(t, ok)
+	require.EqualValues(t, 800, val)
+	require.Len(t, s.Constraints(), 2)
+
+	require.NoError(t, s.Suggest(window, 600))
+	require.EqualValues(t, 300, s.Val(width))
+
+	// hints are ignored for edit variables
+
+	require.NoError(t, s.Hint(window, 100))
+	require.EqualValues(t, 600, s.Val(window))
+}
+
+func TestClearHints(t *testing.T) {
+	s := casso.NewSolver()
+
+	x := casso.New()
+	y := casso.New()
+
+	_, err := s.AddConstraint(casso.NewConstraint(casso.EQ, -10, y.T(1), x.T(-1)))
+	require.NoError(t, err)
+
+	// hints settle variables left undetermined by constraints, and may be updated
+
+	require.NoError(t, s.Hint(x, 5))
+	require.EqualValues(t, 15, s.Val(y))
+	require.NoError(t, s.Hint(x, 7))
+	require.EqualValues(t, 17, s.Val(y))
+
+	_, err = s.AddConstraintWithPriority(casso.Weak, y.EQ(30))
+	require.NoError(t, err)
+	require.EqualValues(t, 20, s.Val(x))
+
+	require.NoError(t, s.ClearHints())
+	require.Len(t, s.Constraints(), 2)
+	require.EqualValues(t, 20, s.Val(x))
+	require.EqualValues(t, 30, s.Val(y))
+}
+
+func TestHintReplacedByStay(t *testing.T) {
+	s := casso.NewSolver()
+
+	x := casso.New()
+
+	require.NoError(t, s.Hint(x, 5))
+	require.NoError(t, s.AddStay(x, casso.Strong))
+	require.Len(t, s.Constraints(), 1)
+
+	// the stay is as strong as requested rather than as weak as the hint it replaced
+
+	_, err := s.AddConstraintWithPriority(casso.Medium, casso.NewConstraint(casso.EQ, -100, x.T(1)))
+	require.NoError(t, err)
+	require.EqualValues(t, 5, s.Val(x))
+
+	// clearing hints leaves the stay be
+
+	require.NoError(t, s.ClearHints())
+	require.Len(t, s.Constraints(), 2)
+	require.EqualValues(t, 5, s.Val(x))
+}
+
+func TestHintRemovedAsStay(t *testing.T) {
+	s := casso.NewSolver()
+
+	x := casso.New()
+	y := casso.New()
+
+	require.NoError(t, s.Hint(x, 5))
+	require.NoError(t, s.Hint(y, 10))
+	require.NoError(t, s.RemoveStay(x))
+
+	require.NoError(t, s.ClearHints())
+	require.Empty(t, s.Constraints())
+}
+
+func TestHintStrict(t *testing.T) {
+	s := casso.NewSolver(casso.WithStrict())
+	x := s.New()
+
+	require.NoError(t, s.Hint(x, 42))
+	require.EqualValues(t, 42, s.Val(x))
+}
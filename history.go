@@ -0,0 +1,206 @@
+package casso
+
+type opKind uint8
+
+const (
+	opAdd opKind = iota
+	opRemove
+	opSuggest
+	opSuggestAll
+	opSetPriority
+	opEdit
+	opRemoveEdit
+	opUpdateConstant
+	opUpdateCoefficient
+)
+
+// op is an operation recorded into the history of a solver.
+type op struct {
+	kind opKind
+
+	marker   Symbol // constraint marker, for adds and removals
+	priority Priority
+	cell     Constraint
+
+	id  Symbol  // edit variable id, or the variable whose coefficient in a constraint was updated
+	old float64 // previous value suggested, priority, constant, or coefficient, or value suggested for an edit variable
+	new float64
+
+	batch []op // suggestions made together via SuggestAll
+}
+
+// inverse returns the operation which reverts o.
+func (o op) inverse() op {
+	switch o.kind {
+	case opAdd:
+		o.kind = opRemove
+	case opRemove:
+		o.kind = opAdd
+	case opEdit:
+		o.kind = opRemoveEdit
+	case opRemoveEdit:
+		o.kind = opEdit
+	case opSuggest, opSetPriority, opUpdateConstant, opUpdateCoefficient:
+		o.old, o.new = o.new, o.old
+	case opSuggestAll:
+		batch := make([]op, 0, len(o.batch))
+		for _, b := range o.batch {
+			batch = append(batch, b.inverse())
+		}
+		o.batch = batch
+	}
+	return o
+}
+
+// history keeps a bounded window of operations made to a solver, such that they may be undone and
+// redone by applying their inverses.
+type history struct {
+	depth int
+	undo  []op
+	redo  []op
+
+	replaying bool // whether operations are being made by Undo or Redo
+}
+
+func (h *history) record(o op) {
+	if h.replaying {
+		return
+	}
+	h.redo = h.redo[:0]
+	if len(h.undo) == h.depth {
+		copy(h.undo, h.undo[1:])
+		h.undo = h.undo[:len(h.undo)-1]
+	}
+	h.undo = append(h.undo, o)
+}
+
+// remap updates all recorded operations referring to a constraint marker to refer to the marker the
+// constraint was reinstalled under.
+func (h *history) remap(old, new Symbol) {
+	for _, ops := range [...][]op{h.undo, h.redo} {
+		for i := range ops {
+			if ops[i].marker == old {
+				ops[i].marker = new
+			}
+		}
+	}
+}
+
+// EnableHistory has the solver record the last depth operations made to it from now on, such that
+// they may be undone and redone via Undo and Redo, as though it were created using WithHistory.
+// Operations already recorded are kept, up to the new depth. A depth of zero or less disables the
+// history and discards all operations recorded.
+func (s *Solver) EnableHistory(depth int) {
+	if depth <= 0 {
+		s.history = nil
+		return
+	}
+	if s.history == nil {
+		s.history = &history{depth: depth}
+		return
+	}
+	s.history.depth = depth
+	if n := len(s.history.undo); n > depth {
+		s.history.undo = append(s.history.undo[:0], s.history.undo[n-depth:]...)
+	}
+}
+
+// Undo reverts the last constraint added or removed, edit variable registered or unregistered,
+// value suggested, priority changed, or constant or coefficient updated, by applying its inverse.
+// Constraints reinstalled by Undo or Redo are installed under new markers, which may be found via
+// Report. Undo requires the solver to be created using WithHistory, or EnableHistory to be called.
+func (s *Solver) Undo() error {
+	if s.history == nil || len(s.history.undo) == 0 {
+		return ErrNothingToUndo
+	}
+	o := s.history.undo[len(s.history.undo)-1]
+
+	inv, err := s.replay(o.inverse())
+	if err != nil {
+		return err
+	}
+
+	s.history.undo = s.history.undo[:len(s.history.undo)-1]
+	s.history.redo = append(s.history.redo, inv.inverse())
+
+	return nil
+}
+
+// Redo reapplies the last operation reverted by Undo. Making any other operation after Undo discards
+// all operations that could have been redone.
+func (s *Solver) Redo() error {
+	if s.history == nil || len(s.history.redo) == 0 {
+		return ErrNothingToRedo
+	}
+	o := s.history.redo[len(s.history.redo)-1]
+
+	done, err := s.replay(o)
+	if err != nil {
+		return err
+	}
+
+	s.history.redo = s.history.redo[:len(s.history.redo)-1]
+	s.history.undo = append(s.history.undo, done)
+
+	return nil
+}
+
+// replay applies o without recording it, and returns o updated to refer to the marker of any
+// constraint it installed.
+func (s *Solver) replay(o op) (op, error) {
+	s.history.replaying = true
+	defer func() { s.history.replaying = false }()
+
+	switch o.kind {
+	case opAdd:
+		marker, err := s.AddConstraintWithPriority(o.priority, o.cell)
+		if err != nil {
+			return o, err
+		}
+		s.history.remap(o.marker, marker)
+		o.marker = marker
+	case opRemove:
+		if err := s.RemoveConstraint(o.marker); err != nil {
+			return o, err
+		}
+	case opEdit:
+		if err := s.Edit(o.id, o.priority); err != nil {
+			return o, err
+		}
+		if o.old != 0 {
+			if err := s.Suggest(o.id, o.old); err != nil {
+				return o, err
+			}
+		}
+	case opRemoveEdit:
+		if err := s.RemoveEdit(o.id); err != nil {
+			return o, err
+		}
+	case opSuggest:
+		if err := s.Suggest(o.id, o.new); err != nil {
+			return o, err
+		}
+	case opSuggestAll:
+		vals := make(map[Symbol]float64, len(o.batch))
+		for _, b := range o.batch {
+			vals[b.id] = b.new
+		}
+		if err := s.SuggestAll(vals); err != nil {
+			return o, err
+		}
+	case opSetPriority:
+		if err := s.SetPriority(o.marker, Priority(o.new)); err != nil {
+			return o, err
+		}
+	case opUpdateConstant:
+		if err := s.UpdateConstant(o.marker, o.new); err != nil {
+			return o, err
+		}
+	case opUpdateCoefficient:
+		if err := s.UpdateCoefficient(o.marker, o.id, o.new); err != nil {
+			return o, err
+		}
+	}
+
+	return o, nil
+}
@@ -0,0 +1,110 @@
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestUndoRedo(t *testing.T) {
+	s := casso.NewSolver(casso.WithHistory(3))
+
+	x := casso.New()
+	y := casso.New()
+
+	require.Equal(t, casso.ErrNothingToUndo, s.Undo())
+	require.Equal(t, casso.ErrNothingToRedo, s.Redo())
+
+	require.NoError(t, s.Edit(x, casso.Strong))
+
+	_, err := s.AddConstraint(casso.NewConstraint(casso.EQ, -10, y.T(1), x.T(-1)))
+	require.NoError(t, err)
+	require.NoError(t, s.Suggest(x, 5))
+	require.NoError(t, s.Suggest(x, 20))
+
+	require.EqualValues(t, 30, s.Val(y))
+
+	require.NoError(t, s.Undo())
+	require.EqualValues(t, 5, s.Val(x))
+	require.EqualValues(t, 15, s.Val(y))
+
+	require.NoError(t, s.Undo())
+	require.EqualValues(t, 0, s.Val(x))
+	require.EqualValues(t, 10, s.Val(y))
+
+	require.NoError(t, s.Undo())
+	require.EqualValues(t, 0, s.Val(y))
+
+	// the history only retains the last three operations
+
+	require.Equal(t, casso.ErrNothingToUndo, s.Undo())
+
+	require.NoError(t, s.Redo())
+	require.NoError(t, s.Redo())
+	require.EqualValues(t, 5, s.Val(x))
+	require.EqualValues(t, 15, s.Val(y))
+
+	// making a new operation discards operations that could have been redone
+
+	marker, err := s.AddConstraintWithPriority(casso.Weak, y.EQ(100))
+	require.NoError(t, err)
+	require.Equal(t, casso.ErrNothingToRedo, s.Redo())
+
+	require.NoError(t, s.RemoveConstraint(marker))
+	require.NoError(t, s.Undo())
+	require.NoError(t, s.Undo())
+	require.NoError(t, s.Redo())
+	require.NoError(t, s.Redo())
+
+	// the constraint reinstalled by undoing its removal is removed again by redoing its removal
+
+	require.Len(t, s.Vals(), 2)
+	for _, c := range s.Constraints() {
+		require.NotEqual(t, casso.Weak, c.Priority)
+	}
+}
+
+func TestUndoWithoutHistory(t *testing.T) {
+	s := casso.NewSolver()
+	_, err := s.AddConstraint(casso.New().EQ(1))
+	require.NoError(t, err)
+	require.Equal(t, casso.ErrNothingToUndo, s.Undo())
+}
+
+func TestEnableHistory(t *testing.T) {
+	s := casso.NewSolver()
+
+	x := casso.New()
+
+	require.NoError(t, s.Edit(x, casso.Strong))
+	require.NoError(t, s.Suggest(x, 10))
+	require.Equal(t, casso.ErrNothingToUndo, s.Undo())
+
+	s.EnableHistory(8)
+
+	require.NoError(t, s.Suggest(x, 20))
+	require.NoError(t, s.RemoveEdit(x))
+	require.False(t, s.HasEdit(x))
+
+	// Unregistering an edit variable is undone by registering it again with its suggested value.
+
+	require.NoError(t, s.Undo())
+	require.True(t, s.HasEdit(x))
+	require.EqualValues(t, 20, s.Val(x))
+
+	require.NoError(t, s.Undo())
+	require.EqualValues(t, 10, s.Val(x))
+	require.Equal(t, casso.ErrNothingToUndo, s.Undo())
+
+	require.NoError(t, s.Redo())
+	require.NoError(t, s.Redo())
+	require.False(t, s.HasEdit(x))
+
+	y := casso.New()
+	require.NoError(t, s.Edit(y, casso.Weak))
+	require.NoError(t, s.Undo())
+	require.False(t, s.HasEdit(y))
+
+	s.EnableHistory(0)
+	require.Equal(t, casso.ErrNothingToUndo, s.Undo())
+}
@@ -0,0 +1,88 @@
+package casso
+
+import (
+	"github.com/stretchr/testify/require"
+	"math/rand"
+	"testing"
+)
+
+func TestInfeasibleQueue(t *testing.T) {
+	for _, deterministic := range []bool{false, true} {
+		s := NewSolver()
+		s.opts.deterministic = deterministic
+
+		a, b, c := next(Slack), next(Slack), next(Slack)
+		p := New()
+
+		s.insertRow(a, Constraint{expr: NewExpr(-1, p.T(1))})
+		s.insertRow(b, Constraint{expr: NewExpr(-5, p.T(1))})
+		s.insertRow(c, Constraint{expr: NewExpr(-3, p.T(1))})
+
+		// rows are queued once, no matter how many times they are marked
+
+		for _, symbol := range []Symbol{a, b, a, c, b} {
+			s.markInfeasible(symbol)
+		}
+		require.Len(t, s.infeasible, 3)
+
+		order := []Symbol{b, c, a} // most negative constant first
+		if deterministic {
+			order = []Symbol{a, b, c}
+		}
+		for _, symbol := range order {
+			require.Equal(t, symbol, s.popInfeasible())
+		}
+		require.Empty(t, s.infeasible)
+		require.Empty(t, s.queued)
+
+		s.markInfeasible(a)
+		require.Len(t, s.infeasible, 1)
+	}
+}
+
+func requireFeasible(t *testing.T, s *Solver) {
+	t.Helper()
+
+	for _, tab := range s.tabs.entries {
+		if tab.basic.Restricted() {
+			require.GreaterOrEqualf(t, tab.row.expr.constant, -1e-6, "row of %s is infeasible", tab.basic)
+		}
+	}
+}
+
+func TestRemainsFeasible(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	s := NewSolver()
+
+	vars := make([]Symbol, 16)
+	for i := range vars {
+		vars[i] = New()
+		require.NoError(t, s.Edit(vars[i], Weak))
+	}
+
+	// removing required constraints, and failing to add unsatisfiable ones, leaves all restricted
+	// rows feasible
+
+	var markers []Symbol
+	for i := 0; i < 500; i++ {
+		switch rng.Intn(3) {
+		case 0, 1:
+			a, b := vars[rng.Intn(len(vars))], vars[rng.Intn(len(vars))]
+			op := Op(rng.Intn(3))
+			marker, err := s.AddConstraint(NewConstraint(op, -rng.Float64()*100, a.T(1), b.T(-rng.Float64())))
+			if err == nil {
+				markers = append(markers, marker)
+			}
+		case 2:
+			if len(markers) == 0 {
+				continue
+			}
+			idx := rng.Intn(len(markers))
+			require.NoError(t, s.RemoveConstraint(markers[idx]))
+			markers = append(markers[:idx], markers[idx+1:]...)
+		}
+		requireFeasible(t, s)
+		requireColumnsIndexed(t, s)
+	}
+}
@@ -0,0 +1,28 @@
+// Package ident converts the names of views and attributes into Go identifiers for the code
+// generators of casso.
+package ident
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Exported converts names such as "content-width", "content_width", "sidebar.left", and
+// "contentWidth" into the exported Go identifiers "ContentWidth", "ContentWidth", "SidebarLeft",
+// and "ContentWidth".
+func Exported(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if r == '-' || r == '_' || r == ' ' || r == '.' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,19 @@
+package ident_test
+
+import (
+	"github.com/lithdew/casso/internal/ident"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestExported(t *testing.T) {
+	for name, expected := range map[string]string{
+		"content-width": "ContentWidth",
+		"content_width": "ContentWidth",
+		"sidebar.left":  "SidebarLeft",
+		"contentWidth":  "ContentWidth",
+		"main view":     "MainView",
+	} {
+		require.Equal(t, expected, ident.Exported(name), name)
+	}
+}
@@ -0,0 +1,54 @@
+package casso
+
+// SolveFor computes the values that would need to be suggested for the edit variables via such that
+// the target variable takes on the desired value. It does not modify the solver.
+//
+// The suggestions are computed by linearly extrapolating how the target variable responds to
+// suggestions for each edit variable given the constraints that are currently binding. The
+// suggestions are thus exact so long as suggesting them does not cause a different set of
+// constraints to become binding. If several edit variables affect the target variable, the
+// adjustment is spread across them such that the sum of squares of their adjustments is minimal.
+//
+// It returns ErrBadEditVariable if any of via is not registered as an edit variable, and
+// ErrUndetermined if suggestions for via do not affect the value of the target variable.
+func (s *Solver) SolveFor(target Symbol, desired float64, via []Symbol) (map[Symbol]float64, error) {
+	// the sensitivity of the target to suggestions for an edit variable is the coefficient of the
+	// edit variable's marker in the target's row, as Suggest adds coeff * delta to the target's row
+
+	sensitivities := make([]float64, len(via))
+	norm := 0.0
+
+	row, basic := s.tabs.get(target)
+
+	for i, id := range via {
+		edit, ok := s.edits[id]
+		if !ok {
+			return nil, ErrBadEditVariable
+		}
+		if !basic {
+			continue
+		}
+		if s.tabs.has(edit.tag.marker) || s.tabs.has(edit.tag.other) {
+			continue
+		}
+		idx := row.expr.find(edit.tag.marker)
+		if idx == -1 {
+			continue
+		}
+		sensitivities[i] = row.expr.terms[idx].coeff
+		norm += sensitivities[i] * sensitivities[i]
+	}
+
+	if s.eqz(norm) {
+		return nil, ErrUndetermined
+	}
+
+	delta := desired - s.Val(target)
+
+	res := make(map[Symbol]float64, len(via))
+	for i, id := range via {
+		res[id] = s.edits[id].val + sensitivities[i]*delta/norm
+	}
+
+	return res, nil
+}
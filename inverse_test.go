@@ -0,0 +1,70 @@
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSolveFor(t *testing.T) {
+	s := casso.NewSolver()
+
+	window := casso.New()
+	column := casso.New()
+
+	require.NoError(t, s.Edit(window, casso.Strong))
+	require.NoError(t, s.Suggest(window, 800))
+
+	// column = window / 4 - 10
+
+	_, err := s.AddConstraint(casso.NewConstraint(casso.EQ, 10, column.T(1), window.T(-0.25)))
+	require.NoError(t, err)
+	require.EqualValues(t, 190, s.Val(column))
+
+	// What window width makes the column 300 wide?
+
+	res, err := s.SolveFor(column, 300, []casso.Symbol{window})
+	require.NoError(t, err)
+	require.EqualValues(t, map[casso.Symbol]float64{window: 1240}, res)
+
+	// The solver is left untouched.
+
+	require.EqualValues(t, 800, s.Val(window))
+
+	require.NoError(t, s.Suggest(window, res[window]))
+	require.EqualValues(t, 300, s.Val(column))
+}
+
+func TestSolveForSpreadsAcrossEditVariables(t *testing.T) {
+	s := casso.NewSolver()
+
+	a := casso.New()
+	b := casso.New()
+	c := casso.New()
+	total := casso.New()
+
+	require.NoError(t, s.Edit(a, casso.Strong))
+	require.NoError(t, s.Edit(b, casso.Strong))
+	require.NoError(t, s.Edit(c, casso.Strong))
+	require.NoError(t, s.Suggest(a, 100))
+	require.NoError(t, s.Suggest(b, 200))
+	require.NoError(t, s.Suggest(c, 5))
+
+	_, err := s.AddConstraint(casso.NewConstraint(casso.EQ, 0, total.T(1), a.T(-1), b.T(-1)))
+	require.NoError(t, err)
+	require.EqualValues(t, 300, s.Val(total))
+
+	res, err := s.SolveFor(total, 600, []casso.Symbol{a, b, c})
+	require.NoError(t, err)
+	require.EqualValues(t, map[casso.Symbol]float64{a: 250, b: 350, c: 5}, res)
+
+	// 'c' does not affect 'total'.
+
+	_, err = s.SolveFor(total, 600, []casso.Symbol{c})
+	require.Equal(t, casso.ErrUndetermined, err)
+
+	// 'total' is not an edit variable.
+
+	_, err = s.SolveFor(a, 600, []casso.Symbol{total})
+	require.Equal(t, casso.ErrBadEditVariable, err)
+}
@@ -0,0 +1,122 @@
+package layout
+
+import (
+	"github.com/lithdew/casso"
+	"image"
+	"math"
+	"sort"
+)
+
+// Report describes the node found under a point in a layout, and the constraints installed in the
+// layout that reference each of the variables describing its box.
+type Report struct {
+	Node *Node // nil if no node lies under the point
+	Rect Rect
+
+	X, Y, W, H []Binding
+}
+
+// Binding is a constraint referencing a variable of an inspected box.
+type Binding struct {
+	Node *Node // node that installed the constraint
+	Rule casso.Rule
+
+	// Slack is how far the constraint is from being violated. It is positive for inequalities that
+	// have room to spare, zero for constraints that hold with equality, and negative by the amount a
+	// constraint is violated.
+	Slack float64
+
+	eps float64 // tolerance of the solver below which slack is treated as zero
+}
+
+// Active reports whether the constraint holds with equality, and is thus currently determining the
+// value of the variables it references. Slack below the tolerance of the solver, as configured via
+// casso.WithEpsilon, is treated as zero.
+func (b Binding) Active() bool { return math.Abs(b.Slack) < b.eps }
+
+// Inspect finds the deepest node whose solved rectangle contains pt, and reports the constraints
+// determining each of the variables of its box. Where the rectangles of siblings overlap, later
+// siblings are considered to lie on top of earlier ones.
+//
+// Bindings are ordered such that active constraints come first, followed by stronger priorities.
+func (l *Layout) Inspect(pt image.Point) Report {
+	var report Report
+
+	n := l.hit(l.root, float64(pt.X), float64(pt.Y))
+	if n == nil {
+		return report
+	}
+
+	report.Node = n
+	report.Rect = l.Rect(n)
+
+	var walk func(owner *Node)
+	walk = func(owner *Node) {
+		for _, rule := range owner.rules {
+			binding := l.binding(owner, rule)
+			for _, term := range rule.Constraint.Expr().Terms() {
+				switch term.Symbol() {
+				case n.box.X:
+					report.X = append(report.X, binding)
+				case n.box.Y:
+					report.Y = append(report.Y, binding)
+				case n.box.W:
+					report.W = append(report.W, binding)
+				case n.box.H:
+					report.H = append(report.H, binding)
+				}
+			}
+		}
+		for _, child := range owner.installed {
+			walk(child)
+		}
+	}
+	walk(l.root)
+
+	// the measured size of a widget, and the viewport for the root, are suggested through edit
+	// variables rather than rules
+
+	for i, id := range [...]casso.Symbol{n.box.W, n.box.H} {
+		priority, ok := l.solver.EditPriority(id)
+		if !ok {
+			continue
+		}
+		val, _ := l.solver.Suggested(id)
+		rule := casso.Rule{Priority: priority, Constraint: id.EQ(val)}
+		binding := l.binding(n, rule)
+		if i == 0 {
+			report.W = append(report.W, binding)
+		} else {
+			report.H = append(report.H, binding)
+		}
+	}
+
+	for _, bindings := range [...][]Binding{report.X, report.Y, report.W, report.H} {
+		sort.SliceStable(bindings, func(i, j int) bool {
+			if bindings[i].Active() != bindings[j].Active() {
+				return bindings[i].Active()
+			}
+			return bindings[i].Rule.Priority > bindings[j].Rule.Priority
+		})
+	}
+
+	return report
+}
+
+func (l *Layout) hit(n *Node, x, y float64) *Node {
+	r := l.Rect(n)
+	if x < r.X || y < r.Y || x >= r.X+r.W || y >= r.Y+r.H {
+		return nil
+	}
+	for i := len(n.installed) - 1; i >= 0; i-- {
+		if hit := l.hit(n.installed[i], x, y); hit != nil {
+			return hit
+		}
+	}
+	return n
+}
+
+// binding returns the binding of a rule installed by owner as of the last solved layout.
+func (l *Layout) binding(owner *Node, rule casso.Rule) Binding {
+	return Binding{Node: owner, Rule: rule, Slack: l.slack(rule.Constraint), eps: l.solver.Epsilon()}
+}
@@ -0,0 +1,335 @@
+// Package layout implements the two-pass measure/arrange protocol of widget toolkits on top of casso.
+package layout
+
+import (
+	"github.com/lithdew/casso"
+	"math"
+)
+
+// Size is a width and a height.
+type Size struct {
+	W, H float64
+}
+
+// Rect is a solved rectangle.
+type Rect struct {
+	X, Y, W, H float64
+}
+
+// Box is a rectangle whose position and size are described by solver variables.
+type Box struct {
+	X, Y, W, H casso.Symbol
+}
+
+func NewBox() Box {
+	return Box{X: casso.New(), Y: casso.New(), W: casso.New(), H: casso.New()}
+}
+
+func (b Box) Rect(s *casso.Solver) Rect {
+	return Rect{X: s.Val(b.X), Y: s.Val(b.Y), W: s.Val(b.W), H: s.Val(b.H)}
+}
+
+// Measurable is implemented by widgets that have an intrinsic size. Measure is given the space
+// available to the layout, and reports the size the widget would prefer to take up.
+type Measurable interface {
+	Measure(available Size) Size
+}
+
+// Constrainer is implemented by widgets that relate their own box to the boxes of their children.
+type Constrainer interface {
+	Constrain(box Box, children []Box) []casso.Rule
+}
+
+// Arranger is implemented by widgets that want to be told where they were placed.
+type Arranger interface {
+	Arrange(r Rect)
+}
+
+// Node is a widget placed in a layout tree. The widget may implement any of Measurable,
+// Constrainer, and Arranger.
+type Node struct {
+	Name     string
+	Widget   interface{}
+	Children []*Node
+
+	box       Box
+	rules     []casso.Rule   // installed rules, including the non-negativity of the box's size
+	markers   []casso.Symbol // markers of installed rules
+	installed []*Node        // children whose constraints are currently installed
+	edited    bool           // whether the box's size was registered as edit variables by the node
+	dirty     bool
+	rect      Rect // last rectangle handed to the widget
+}
+
+func NewNode(name string, widget interface{}, children ...*Node) *Node {
+	return &Node{Name: name, Widget: widget, Children: children, box: NewBox()}
+}
+
+func (n *Node) Box() Box { return n.box }
+
+// Layout drives the two-pass measure/arrange protocol over a tree of nodes using a solver.
+//
+// 1. measure: intrinsic sizes of Measurable widgets are suggested to the solver at MeasurePriority
+// 2. arrange: the solver is optimized, and Arranger widgets are handed their solved rectangles
+//
+// Constraints produced by Constrainer widgets are installed the first time the layout is updated.
+// Afterwards, only the subtrees marked via Invalidate are re-measured and have their constraints
+// re-installed, and only widgets whose rectangles changed are re-arranged. Rectangles outside of
+// invalidated subtrees are only read should the solver report a change to their boxes via
+// FetchChanges, which must thus not be called on the solver of the layout by anyone else.
+type Layout struct {
+	MeasurePriority casso.Priority
+
+	solver   *casso.Solver
+	root     *Node
+	built    bool
+	viewport Size
+}
+
+func New(root *Node) *Layout {
+	return &Layout{MeasurePriority: casso.Medium, solver: casso.NewSolver(), root: root}
+}
+
+func (l *Layout) Solver() *casso.Solver { return l.solver }
+func (l *Layout) Root() *Node           { return l.root }
+
+func (l *Layout) Rect(n *Node) Rect { return n.box.Rect(l.solver) }
+
+// Invalidate marks the subtree rooted at n as dirty. On the next update, the widgets within the
+// subtree are re-measured, and the constraints of the subtree are removed and re-installed so that
+// changes to the constraints or children of any of its nodes are picked up.
+func (l *Layout) Invalidate(n *Node) {
+	n.dirty = true
+}
+
+// Update lays out the tree within a viewport of the given size.
+func (l *Layout) Update(viewport Size) error {
+	full := !l.built || viewport != l.viewport
+
+	if !l.built {
+		if err := l.build(); err != nil {
+			return err
+		}
+		l.built = true
+	} else if err := l.reinstall(l.root); err != nil {
+		return err
+	}
+
+	if full {
+		if err := l.solver.Suggest(l.root.box.W, viewport.W); err != nil {
+			return err
+		}
+		if err := l.solver.Suggest(l.root.box.H, viewport.H); err != nil {
+			return err
+		}
+		l.viewport = viewport
+	}
+
+	if err := l.measure(l.root, viewport, full); err != nil {
+		return err
+	}
+
+	changed := l.solver.FetchChanges()
+	if full {
+		changed = nil
+	}
+	l.arrange(l.root, changed)
+
+	return nil
+}
+
+func (l *Layout) build() error {
+	root := l.root.box
+
+	if _, err := l.solver.AddConstraint(root.X.EQ(0)); err != nil {
+		return err
+	}
+	if _, err := l.solver.AddConstraint(root.Y.EQ(0)); err != nil {
+		return err
+	}
+	if err := l.solver.Edit(root.W, casso.Strong); err != nil {
+		return err
+	}
+	if err := l.solver.Edit(root.H, casso.Strong); err != nil {
+		return err
+	}
+
+	return l.install(l.root)
+}
+
+func (l *Layout) install(n *Node) error {
+	n.installed = append(n.installed[:0], n.Children...)
+
+	for _, child := range n.Children {
+		if err := l.install(child); err != nil {
+			return err
+		}
+	}
+
+	for _, c := range [...]casso.Constraint{n.box.W.GTE(0), n.box.H.GTE(0)} {
+		if err := l.add(n, casso.Rule{Priority: casso.Required, Constraint: c}); err != nil {
+			return err
+		}
+	}
+
+	// the size of the root is already registered as edit variables carrying the viewport, which are
+	// left to the layout rather than to the root to remove
+
+	if _, ok := n.Widget.(Measurable); ok && n != l.root {
+		if err := l.solver.Edit(n.box.W, l.MeasurePriority); err != nil {
+			return err
+		}
+		if err := l.solver.Edit(n.box.H, l.MeasurePriority); err != nil {
+			return err
+		}
+		n.edited = true
+	}
+
+	w, ok := n.Widget.(Constrainer)
+	if !ok {
+		return nil
+	}
+
+	children := make([]Box, 0, len(n.Children))
+	for _, child := range n.Children {
+		children = append(children, child.box)
+	}
+
+	for _, rule := range w.Constrain(n.box, children) {
+		if err := l.add(n, rule); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (l *Layout) add(n *Node, rule casso.Rule) error {
+	marker, err := l.solver.AddConstraintWithPriority(rule.Priority, rule.Constraint)
+	if err != nil {
+		return err
+	}
+	n.rules = append(n.rules, rule)
+	n.markers = append(n.markers, marker)
+	return nil
+}
+
+// uninstall removes all constraints and edit variables installed for the subtree rooted at n.
+func (l *Layout) uninstall(n *Node) error {
+	for _, child := range n.installed {
+		if err := l.uninstall(child); err != nil {
+			return err
+		}
+	}
+	if n.edited {
+		if err := l.solver.RemoveEdit(n.box.W); err != nil {
+			return err
+		}
+		if err := l.solver.RemoveEdit(n.box.H); err != nil {
+			return err
+		}
+		n.edited = false
+	}
+	for _, marker := range n.markers {
+		if err := l.solver.RemoveConstraint(marker); err != nil {
+			return err
+		}
+	}
+	n.rules = n.rules[:0]
+	n.markers = n.markers[:0]
+	n.installed = n.installed[:0]
+	return nil
+}
+
+// reinstall walks the tree top-down, re-installing the constraints of every dirty subtree.
+func (l *Layout) reinstall(n *Node) error {
+	if !n.dirty {
+		for _, child := range n.Children {
+			if err := l.reinstall(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := l.uninstall(n); err != nil {
+		return err
+	}
+	return l.install(n)
+}
+
+// measure walks the tree bottom-up, suggesting the intrinsic size of every Measurable widget. If
+// all is false, only widgets within dirty subtrees are measured.
+func (l *Layout) measure(n *Node, available Size, all bool) error {
+	all = all || n.dirty
+
+	for _, child := range n.Children {
+		if err := l.measure(child, available, all); err != nil {
+			return err
+		}
+	}
+
+	w, ok := n.Widget.(Measurable)
+	if !ok || !all {
+		return nil
+	}
+
+	size := w.Measure(available)
+
+	if err := l.solver.Suggest(n.box.W, size.W); err != nil {
+		return err
+	}
+	return l.solver.Suggest(n.box.H, size.H)
+}
+
+// arrange walks the tree top-down, handing every Arranger widget its solved rectangle. If changed
+// is nil, all widgets are arranged. Otherwise, only widgets within dirty subtrees, or whose boxes
+// have variables in changed and whose rectangles changed, are arranged.
+func (l *Layout) arrange(n *Node, changed map[casso.Symbol]float64) {
+	if n.dirty {
+		n.dirty = false
+		changed = nil
+	}
+
+	if changed == nil || n.moved(changed) {
+		rect := l.Rect(n)
+		if w, ok := n.Widget.(Arranger); ok && (changed == nil || rect != n.rect) {
+			w.Arrange(rect)
+		}
+		n.rect = rect
+	}
+
+	for _, child := range n.Children {
+		l.arrange(child, changed)
+	}
+}
+
+// moved reports whether any variable of the box of n is in changed.
+func (n *Node) moved(changed map[casso.Symbol]float64) bool {
+	for _, id := range [...]casso.Symbol{n.box.X, n.box.Y, n.box.W, n.box.H} {
+		if _, ok := changed[id]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// slack returns how far c is from being violated as of the last solved layout. It is positive for
+// inequalities that have room to spare, zero for constraints that hold with equality, and negative
+// by the amount c is violated.
+func (l *Layout) slack(c casso.Constraint) float64 {
+	expr := c.Expr()
+
+	val := expr.Constant()
+	for _, term := range expr.Terms() {
+		val += term.Coeff() * l.solver.Val(term.Symbol())
+	}
+
+	switch c.Op() {
+	case casso.GTE:
+		return val
+	case casso.LTE:
+		return -val
+	default:
+		return -math.Abs(val)
+	}
+}
@@ -0,0 +1,360 @@
+package layout_test
+
+import (
+	"bytes"
+	"github.com/lithdew/casso"
+	"github.com/lithdew/casso/layout"
+	"github.com/stretchr/testify/require"
+	"image"
+	"strings"
+	"testing"
+)
+
+type label struct {
+	size layout.Size
+	rect layout.Rect
+}
+
+func (l *label) Measure(available layout.Size) layout.Size { return l.size }
+func (l *label) Arrange(r layout.Rect)                     { l.rect = r }
+
+// row places its children next to each other from left to right.
+type row struct {
+	rect layout.Rect
+}
+
+func (w *row) Constrain(box layout.Box, children []layout.Box) []casso.Rule {
+	var rules []casso.Rule
+
+	req := func(c casso.Constraint) {
+		rules = append(rules, casso.Rule{Priority: casso.Required, Constraint: c})
+	}
+
+	// left edge of the next child: box.X, then child.X + child.W
+
+	left := []casso.Term{box.X.T(1)}
+	for _, child := range children {
+		req(casso.NewConstraint(casso.EQ, 0, append(left, child.X.T(-1))...))
+		req(casso.NewConstraint(casso.EQ, 0, child.Y.T(1), box.Y.T(-1)))
+		req(casso.NewConstraint(casso.LTE, 0, child.H.T(1), box.H.T(-1)))
+		left = []casso.Term{child.X.T(1), child.W.T(1)}
+	}
+	req(casso.NewConstraint(casso.LTE, 0, append(left, box.X.T(-1), box.W.T(-1))...))
+
+	return rules
+}
+
+func (w *row) Arrange(r layout.Rect) { w.rect = r }
+
+func TestLayout(t *testing.T) {
+	a := &label{size: layout.Size{W: 100, H: 20}}
+	b := &label{size: layout.Size{W: 200, H: 30}}
+	container := &row{}
+
+	root := layout.NewNode("row", container,
+		layout.NewNode("a", a),
+		layout.NewNode("b", b),
+	)
+
+	l := layout.New(root)
+	require.NoError(t, l.Update(layout.Size{W: 800, H: 600}))
+
+	require.EqualValues(t, layout.Rect{X: 0, Y: 0, W: 800, H: 600}, container.rect)
+	require.EqualValues(t, layout.Rect{X: 0, Y: 0, W: 100, H: 20}, a.rect)
+	require.EqualValues(t, layout.Rect{X: 100, Y: 0, W: 200, H: 30}, b.rect)
+
+	// Shrink the viewport such that 'a' and 'b' no longer fit at their intrinsic sizes.
+
+	require.NoError(t, l.Update(layout.Size{W: 250, H: 600}))
+
+	require.EqualValues(t, layout.Rect{X: 0, Y: 0, W: 250, H: 600}, container.rect)
+	require.EqualValues(t, 0, a.rect.X)
+	require.EqualValues(t, a.rect.W, b.rect.X)
+	require.EqualValues(t, 250, a.rect.W+b.rect.W)
+}
+
+type counter struct {
+	label
+	arranged int
+}
+
+func (c *counter) Arrange(r layout.Rect) { c.label.Arrange(r); c.arranged++ }
+
+func TestLayoutInvalidate(t *testing.T) {
+	a := &counter{label: label{size: layout.Size{W: 100, H: 20}}}
+	b := &counter{label: label{size: layout.Size{W: 200, H: 30}}}
+	c := &counter{label: label{size: layout.Size{W: 50, H: 10}}}
+
+	left := layout.NewNode("left", &row{}, layout.NewNode("a", a))
+	right := layout.NewNode("right", &row{}, layout.NewNode("b", b))
+
+	root := layout.NewNode("root", &row{}, left, right)
+
+	l := layout.New(root)
+	require.NoError(t, l.Update(layout.Size{W: 800, H: 600}))
+
+	require.EqualValues(t, 1, a.arranged)
+	require.EqualValues(t, 1, b.arranged)
+
+	// Nothing is invalidated, and the viewport has not changed: no widget should be re-arranged.
+
+	require.NoError(t, l.Update(layout.Size{W: 800, H: 600}))
+
+	require.EqualValues(t, 1, a.arranged)
+	require.EqualValues(t, 1, b.arranged)
+
+	// Add a child to the right subtree. Only the widgets in the right subtree should be re-arranged.
+
+	node := layout.NewNode("c", c)
+	right.Children = append(right.Children, node)
+	l.Invalidate(right)
+
+	require.NoError(t, l.Update(layout.Size{W: 800, H: 600}))
+
+	require.EqualValues(t, 1, a.arranged)
+	require.EqualValues(t, 2, b.arranged)
+	require.EqualValues(t, 1, c.arranged)
+
+	require.EqualValues(t, layout.Rect{X: 0, Y: 0, W: 100, H: 20}, a.rect)
+	require.EqualValues(t, layout.Rect{X: l.Rect(right).X, Y: 0, W: 200, H: 30}, b.rect)
+	require.EqualValues(t, layout.Rect{X: l.Rect(right).X + 200, Y: 0, W: 50, H: 10}, c.rect)
+
+	// Remove the child from the right subtree.
+
+	right.Children = right.Children[:1]
+	l.Invalidate(right)
+
+	require.NoError(t, l.Update(layout.Size{W: 800, H: 600}))
+	require.EqualValues(t, 3, b.arranged)
+	require.EqualValues(t, layout.Rect{X: l.Rect(right).X, Y: 0, W: 200, H: 30}, b.rect)
+
+	// The size of the removed child is no longer registered as edit variables.
+
+	require.Equal(t, casso.ErrBadEditVariable, l.Solver().Suggest(node.Box().W, 10))
+	require.Equal(t, casso.ErrBadEditVariable, l.Solver().Suggest(node.Box().H, 10))
+}
+
+func TestLayoutInvalidateMovesSiblings(t *testing.T) {
+	a := &counter{label: label{size: layout.Size{W: 100, H: 20}}}
+	b := &counter{label: label{size: layout.Size{W: 200, H: 30}}}
+
+	first := layout.NewNode("a", a)
+	root := layout.NewNode("root", &row{}, first, layout.NewNode("b", b))
+
+	l := layout.New(root)
+	require.NoError(t, l.Update(layout.Size{W: 800, H: 600}))
+	require.EqualValues(t, layout.Rect{X: 100, Y: 0, W: 200, H: 30}, b.rect)
+
+	// Growing 'a' pushes 'b' to the right, which is re-arranged though it was not invalidated.
+
+	a.size.W = 150
+	l.Invalidate(first)
+
+	require.NoError(t, l.Update(layout.Size{W: 800, H: 600}))
+	require.EqualValues(t, 2, a.arranged)
+	require.EqualValues(t, 2, b.arranged)
+	require.EqualValues(t, layout.Rect{X: 150, Y: 0, W: 200, H: 30}, b.rect)
+}
+
+// wide is a widget that prefers its intrinsic size, though asks to be at least min wide at a
+// priority between Medium and Strong.
+type wide struct {
+	label
+	min float64
+}
+
+func (w *wide) Constrain(box layout.Box, children []layout.Box) []casso.Rule {
+	return []casso.Rule{{Priority: 10 * casso.Medium, Constraint: box.W.GTE(w.min)}}
+}
+
+func TestLayoutInvalidateMeasurableRoot(t *testing.T) {
+	w := &wide{label: label{size: layout.Size{W: 100, H: 20}}, min: 500}
+
+	root := layout.NewNode("root", w)
+
+	l := layout.New(root)
+	require.NoError(t, l.Update(layout.Size{W: 800, H: 600}))
+
+	// The measured width of the root is suggested through the Strong edit variables of the
+	// viewport, which override its preference to be wider.
+
+	require.EqualValues(t, 100, l.Rect(root).W)
+
+	// The size of the root stays registered as the edit variables of the viewport, rather than
+	// being removed along with the constraints of the root and registered anew at MeasurePriority.
+
+	l.Invalidate(root)
+	require.NoError(t, l.Update(layout.Size{W: 800, H: 600}))
+	require.EqualValues(t, 100, l.Rect(root).W)
+}
+
+func grid(rows, cols int) *layout.Node {
+	children := make([]*layout.Node, 0, rows)
+	for i := 0; i < rows; i++ {
+		cells := make([]*layout.Node, 0, cols)
+		for j := 0; j < cols; j++ {
+			cells = append(cells, layout.NewNode("cell", &label{size: layout.Size{W: 10, H: 10}}))
+		}
+		children = append(children, layout.NewNode("row", &row{}, cells...))
+	}
+	return layout.NewNode("root", &row{}, children...)
+}
+
+// BenchmarkLayoutInvalidateAll re-measures and re-installs the whole of an unchanged tree, which
+// is what every update amounted to before only invalidated subtrees were re-installed, against which
+// BenchmarkLayoutInvalidate is to be compared.
+func BenchmarkLayoutInvalidateAll(b *testing.B) {
+	root := grid(20, 5)
+
+	l := layout.New(root)
+	if err := l.Update(layout.Size{W: 2000, H: 100}); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		l.Invalidate(root)
+		if err := l.Update(layout.Size{W: 2000, H: 100}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkLayoutInvalidate(b *testing.B) {
+	root := grid(20, 5)
+
+	l := layout.New(root)
+	if err := l.Update(layout.Size{W: 2000, H: 100}); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		l.Invalidate(root.Children[i%len(root.Children)])
+		if err := l.Update(layout.Size{W: 2000, H: 100}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestLayoutRenderSVG(t *testing.T) {
+	root := layout.NewNode("row", &row{},
+		layout.NewNode("a<1>", &label{size: layout.Size{W: 100, H: 20}}),
+		layout.NewNode("b", &label{size: layout.Size{W: 200, H: 30}}),
+		layout.NewNode("empty", &label{}),
+	)
+
+	l := layout.New(root)
+	require.NoError(t, l.Update(layout.Size{W: 800, H: 600}))
+
+	var buf bytes.Buffer
+	require.NoError(t, l.RenderSVG(&buf))
+
+	svg := buf.String()
+	require.True(t, strings.HasPrefix(svg, "<svg"))
+	require.Contains(t, svg, `width="200" height="30"`)
+	require.Contains(t, svg, ">a&lt;1&gt;</text>")
+	require.Contains(t, svg, ">= required</text>")
+	require.Contains(t, svg, `marker-end="url(#arrow-required)"`)
+
+	// rules that do not bind, such as the heights of children being at most that of the row, are
+	// left out
+
+	require.NotContains(t, svg, "&lt;= required")
+
+	// rules on a single box, and the sizes suggested for boxes, are written out on the box
+
+	require.Contains(t, svg, ">w = 800 strong</text>")
+	require.Contains(t, svg, ">w = 200 medium</text>")
+	require.Contains(t, svg, ">w &gt;= 0 required</text>")
+
+	// children added since the last update are not drawn until they are installed
+
+	root.Children = append(root.Children, layout.NewNode("late", &label{size: layout.Size{W: 10, H: 10}}))
+
+	buf.Reset()
+	require.NoError(t, l.RenderSVG(&buf))
+	require.NotContains(t, buf.String(), ">late</text>")
+}
+
+func TestLayoutInspect(t *testing.T) {
+	a := layout.NewNode("a", &label{size: layout.Size{W: 100, H: 20}})
+	b := layout.NewNode("b", &label{size: layout.Size{W: 200, H: 30}})
+
+	root := layout.NewNode("row", &row{}, a, b)
+
+	l := layout.New(root)
+	require.NoError(t, l.Update(layout.Size{W: 800, H: 600}))
+
+	report := l.Inspect(image.Pt(150, 10))
+	require.Equal(t, b, report.Node)
+	require.EqualValues(t, layout.Rect{X: 100, Y: report.Rect.Y, W: 200, H: 30}, report.Rect)
+
+	// 'b' is placed right after 'a' by a required constraint installed by the row.
+
+	require.NotEmpty(t, report.X)
+	require.True(t, report.X[0].Active())
+	require.Equal(t, root, report.X[0].Node)
+	require.EqualValues(t, casso.Required, report.X[0].Rule.Priority)
+
+	// The width of 'b' is determined by its measured size, and is non-negative with room to spare.
+
+	var measured, nonnegative bool
+	for _, binding := range report.W {
+		switch binding.Rule.Priority {
+		case casso.Medium:
+			measured = binding.Active()
+		case casso.Required:
+			nonnegative = nonnegative || binding.Slack == 200
+		}
+	}
+	require.True(t, measured)
+	require.True(t, nonnegative)
+
+	// Points within the row that do not lie within any of its children resolve to the row.
+
+	require.Equal(t, root, l.Inspect(image.Pt(500, 500)).Node)
+	require.Nil(t, l.Inspect(image.Pt(900, 10)).Node)
+}
+
+func TestLayoutInspectRoot(t *testing.T) {
+	root := layout.NewNode("root", &label{size: layout.Size{W: 100, H: 20}})
+
+	l := layout.New(root)
+	require.NoError(t, l.Update(layout.Size{W: 800, H: 600}))
+
+	// The size of the root is suggested through the edit variables of the viewport.
+
+	report := l.Inspect(image.Pt(10, 10))
+	require.Equal(t, root, report.Node)
+	require.NotEmpty(t, report.W)
+	require.EqualValues(t, casso.Strong, report.W[0].Rule.Priority)
+	require.True(t, report.W[0].Active())
+}
+
+func TestLayoutInspectInstalled(t *testing.T) {
+	a := layout.NewNode("a", &label{size: layout.Size{W: 100, H: 20}})
+	b := layout.NewNode("b", &label{size: layout.Size{W: 200, H: 30}})
+
+	root := layout.NewNode("row", &row{}, a, b)
+
+	l := layout.New(root)
+	require.NoError(t, l.Update(layout.Size{W: 800, H: 600}))
+
+	// Nodes are inspected as installed until the layout is updated with their parent invalidated.
+
+	root.Children = root.Children[:1]
+
+	report := l.Inspect(image.Pt(150, 10))
+	require.Equal(t, b, report.Node)
+	require.NotEmpty(t, report.X)
+	require.Equal(t, root, report.X[0].Node)
+
+	l.Invalidate(root)
+	require.NoError(t, l.Update(layout.Size{W: 800, H: 600}))
+	require.Equal(t, root, l.Inspect(image.Pt(150, 10)).Node)
+}
@@ -0,0 +1,184 @@
+package layout
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/lithdew/casso"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var svgPriorityColors = [...]struct {
+	priority casso.Priority
+	color    string
+}{
+	{casso.Required, "#d62728"},
+	{casso.Strong, "#ff7f0e"},
+	{casso.Medium, "#1f77b4"},
+	{casso.Weak, "#7f7f7f"},
+}
+
+// svgPriorityClass returns the strongest named priority that p is at least as strong as, and its color.
+func svgPriorityClass(p casso.Priority) (casso.Priority, string) {
+	for _, c := range svgPriorityColors {
+		if p >= c.priority {
+			return c.priority, c.color
+		}
+	}
+	c := svgPriorityColors[len(svgPriorityColors)-1]
+	return c.priority, c.color
+}
+
+// RenderSVG draws the last solved layout as an SVG document. Every box is drawn alongside its name,
+// and every installed rule that binds, holding with equality as reported by Binding.Active, is drawn
+// labeled with its operator and priority. Rules relating two or more boxes are drawn as arrows from
+// the box of the node that installed them to each other box they reference. Rules on a single box,
+// and the sizes suggested for boxes through edit variables, are written out on the box they bind.
+func (l *Layout) RenderSVG(w io.Writer) error {
+	var nodes []*Node
+
+	owners := make(map[casso.Symbol]*Node)
+
+	var walk func(n *Node)
+	walk = func(n *Node) {
+		nodes = append(nodes, n)
+		for _, sym := range [...]casso.Symbol{n.box.X, n.box.Y, n.box.W, n.box.H} {
+			owners[sym] = n
+		}
+		for _, child := range n.installed {
+			walk(child)
+		}
+	}
+	walk(l.root)
+
+	// annotations lists the bindings written out on each box
+
+	annotations := make(map[*Node][]casso.Rule)
+
+	for _, n := range nodes {
+		for _, id := range [...]casso.Symbol{n.box.W, n.box.H} {
+			priority, ok := l.solver.EditPriority(id)
+			if !ok {
+				continue
+			}
+			val, _ := l.solver.Suggested(id)
+			rule := casso.Rule{Priority: priority, Constraint: id.EQ(val)}
+			if l.binding(n, rule).Active() {
+				annotations[n] = append(annotations[n], rule)
+			}
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+
+	root := l.Rect(l.root)
+
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%g" height="%g" viewBox="%g %g %g %g" font-family="monospace" font-size="10">`+"\n",
+		root.W, root.H, root.X, root.Y, root.W, root.H)
+
+	fmt.Fprintln(bw, `<defs>`)
+	for _, c := range svgPriorityColors {
+		fmt.Fprintf(bw, `<marker id="arrow-%s" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="6" markerHeight="6" orient="auto-start-reverse"><path d="M 0 0 L 10 5 L 0 10 z" fill="%s"/></marker>`+"\n",
+			c.priority, c.color)
+	}
+	fmt.Fprintln(bw, `</defs>`)
+
+	for _, n := range nodes {
+		from := l.Rect(n)
+
+		for _, rule := range n.rules {
+			if !l.binding(n, rule).Active() {
+				continue
+			}
+
+			var others []*Node
+
+			seen := make(map[*Node]struct{})
+			for _, term := range rule.Constraint.Expr().Terms() {
+				other, ok := owners[term.Symbol()]
+				if !ok {
+					continue
+				}
+				if _, dup := seen[other]; dup {
+					continue
+				}
+				seen[other] = struct{}{}
+				others = append(others, other)
+			}
+
+			if len(others) == 1 {
+				annotations[others[0]] = append(annotations[others[0]], rule)
+				continue
+			}
+
+			class, color := svgPriorityClass(rule.Priority)
+
+			for _, other := range others {
+				if other == n {
+					continue
+				}
+
+				to := l.Rect(other)
+
+				x1, y1 := from.X+from.W/2, from.Y+from.H/2
+				x2, y2 := to.X+to.W/2, to.Y+to.H/2
+
+				fmt.Fprintf(bw, `<line x1="%g" y1="%g" x2="%g" y2="%g" stroke="%s" marker-end="url(#arrow-%s)"/>`+"\n",
+					x1, y1, x2, y2, color, class)
+				fmt.Fprintf(bw, `<text x="%g" y="%g" fill="%s">%s %s</text>`+"\n",
+					(x1+x2)/2, (y1+y2)/2, color, html.EscapeString(rule.Constraint.Op().String()), rule.Priority)
+			}
+		}
+	}
+
+	for _, n := range nodes {
+		r := l.Rect(n)
+		fmt.Fprintf(bw, `<rect x="%g" y="%g" width="%g" height="%g" fill="none" stroke="black"/>`+"\n", r.X, r.Y, r.W, r.H)
+		fmt.Fprintf(bw, `<text x="%g" y="%g">%s</text>`+"\n", r.X+2, r.Y+10, html.EscapeString(n.Name))
+
+		for i, rule := range annotations[n] {
+			_, color := svgPriorityClass(rule.Priority)
+			fmt.Fprintf(bw, `<text x="%g" y="%g" fill="%s">%s %s</text>`+"\n",
+				r.X+2, r.Y+float64(i+2)*10, color, html.EscapeString(svgRule(n, rule.Constraint)), rule.Priority)
+		}
+	}
+
+	fmt.Fprintln(bw, `</svg>`)
+
+	return bw.Flush()
+}
+
+// svgRule formats a constraint on the box of n, naming the variables of the box x, y, w, and h.
+func svgRule(n *Node, c casso.Constraint) string {
+	names := map[casso.Symbol]string{n.box.X: "x", n.box.Y: "y", n.box.W: "w", n.box.H: "h"}
+
+	expr := c.Expr()
+
+	var b strings.Builder
+	for i, term := range expr.Terms() {
+		coeff := term.Coeff()
+		switch {
+		case i > 0 && coeff < 0:
+			b.WriteString(" - ")
+			coeff = -coeff
+		case i > 0:
+			b.WriteString(" + ")
+		}
+		switch coeff {
+		case 1:
+		case -1:
+			b.WriteString("-")
+		default:
+			b.WriteString(strconv.FormatFloat(coeff, 'g', -1, 64))
+			b.WriteString(" ")
+		}
+		b.WriteString(names[term.Symbol()])
+	}
+	b.WriteString(" ")
+	b.WriteString(c.Op().String())
+	b.WriteString(" ")
+	b.WriteString(strconv.FormatFloat(-expr.Constant(), 'g', -1, 64))
+	return b.String()
+}
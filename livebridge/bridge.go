@@ -0,0 +1,298 @@
+// Package livebridge exposes a solver over WebSocket, such that live design tools and remote
+// inspectors may watch and manipulate the layout of a running application.
+//
+// Clients exchange JSON messages with a bridge. Clients send:
+//
+//	{"type": "subscribe", "vars": ["sidebar.width"]}
+//	{"type": "unsubscribe", "vars": ["sidebar.width"]}
+//	{"type": "suggest", "var": "sidebar.width", "value": 300}
+//
+// A bridge replies to subscriptions with the current values of the subscribed variables, sends
+// updates whenever subscribed variables change value, and reports failed requests:
+//
+//	{"type": "values", "values": {"sidebar.width": 200}}
+//	{"type": "update", "changes": [{"var": "sidebar.width", "old": 200, "new": 300}]}
+//	{"type": "error", "error": "symbol is not yet registered as an edit variable"}
+package livebridge
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/lithdew/casso"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// queueSize is the number of updates that may be queued for a client before it is disconnected
+// for falling behind.
+const queueSize = 64
+
+// Bridge serves a solver to WebSocket clients. As solvers are not safe for concurrent use, once a
+// bridge is created, the solver must only be accessed through Do.
+type Bridge struct {
+	// Origins lists the origins, such as "https://tools.example.com", of the web pages besides
+	// those served from the host of the bridge that may connect to it. Requests without an Origin
+	// header, which are not made by browsers, are always accepted. Origins must not be modified
+	// once the bridge serves requests.
+	Origins []string
+
+	mu sync.Mutex // guards s
+	s  *casso.Solver
+
+	vars  map[string]casso.Symbol // variable name -> id
+	names map[casso.Symbol]string // variable id -> name
+
+	changes chan []casso.Change
+	done    chan struct{}
+
+	cmu     sync.Mutex // guards clients
+	clients map[*client]struct{}
+}
+
+type client struct {
+	conn    *conn
+	updates chan response // updates queued to be written by the client's writer
+
+	mu   sync.Mutex // guards subs
+	subs map[casso.Symbol]struct{}
+}
+
+type request struct {
+	Type  string   `json:"type"`
+	Vars  []string `json:"vars,omitempty"`
+	Var   string   `json:"var,omitempty"`
+	Value float64  `json:"value,omitempty"`
+}
+
+type response struct {
+	Type    string             `json:"type"`
+	Values  map[string]float64 `json:"values,omitempty"`
+	Changes []change           `json:"changes,omitempty"`
+	Error   string             `json:"error,omitempty"`
+}
+
+type change struct {
+	Var string  `json:"var"`
+	Old float64 `json:"old"`
+	New float64 `json:"new"`
+}
+
+// New creates a bridge serving s, whose variables are exposed to clients under the names given by
+// vars, such as the variables returned by encode.Spec.Load.
+func New(s *casso.Solver, vars map[string]casso.Symbol) *Bridge {
+	b := &Bridge{
+		s:       s,
+		vars:    make(map[string]casso.Symbol, len(vars)),
+		names:   make(map[casso.Symbol]string, len(vars)),
+		changes: make(chan []casso.Change, 64),
+		done:    make(chan struct{}),
+		clients: make(map[*client]struct{}),
+	}
+	for name, id := range vars {
+		b.vars[name] = id
+		b.names[id] = name
+	}
+
+	s.Subscribe(b.changes)
+	go b.broadcast()
+
+	return b
+}
+
+// Do calls fn with the solver while no client may access it.
+func (b *Bridge) Do(fn func(s *casso.Solver) error) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return fn(b.s)
+}
+
+// Close disconnects all clients and stops the bridge from serving the solver.
+func (b *Bridge) Close() {
+	b.mu.Lock()
+	b.s.Unsubscribe(b.changes)
+	close(b.changes)
+	b.mu.Unlock()
+
+	<-b.done
+
+	b.cmu.Lock()
+	defer b.cmu.Unlock()
+	for c := range b.clients {
+		_ = c.conn.close()
+	}
+}
+
+// ServeHTTP upgrades the request to a WebSocket connection, and serves the client until it
+// disconnects. Requests made from web pages of origins that are not allowed are rejected.
+func (b *Bridge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !b.allowed(r) {
+		http.Error(w, "livebridge: origin not allowed", http.StatusForbidden)
+		return
+	}
+	conn, err := upgrade(w, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer conn.close()
+
+	c := &client{
+		conn:    conn,
+		updates: make(chan response, queueSize),
+		subs:    make(map[casso.Symbol]struct{}),
+	}
+
+	b.cmu.Lock()
+	b.clients[c] = struct{}{}
+	b.cmu.Unlock()
+
+	go c.write()
+
+	defer func() {
+		b.cmu.Lock()
+		delete(b.clients, c)
+		close(c.updates)
+		b.cmu.Unlock()
+	}()
+
+	for {
+		msg, err := conn.readMessage()
+		if err != nil {
+			return
+		}
+
+		var req request
+		if err := json.Unmarshal(msg, &req); err != nil {
+			err = c.send(response{Type: "error", Error: err.Error()})
+		} else {
+			err = c.send(b.handle(c, req))
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// allowed reports whether r is not made by a web page, is made by a web page served from the host
+// of the bridge, or is made by a web page of one of the origins listed in Origins.
+func (b *Bridge) allowed(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	for _, allowed := range b.Origins {
+		if strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *Bridge) handle(c *client, req request) response {
+	switch req.Type {
+	case "subscribe", "unsubscribe":
+		ids := make([]casso.Symbol, 0, len(req.Vars))
+		for _, name := range req.Vars {
+			id, ok := b.vars[name]
+			if !ok {
+				return response{Type: "error", Error: fmt.Sprintf("unknown variable %q", name)}
+			}
+			ids = append(ids, id)
+		}
+
+		c.mu.Lock()
+		for _, id := range ids {
+			if req.Type == "subscribe" {
+				c.subs[id] = struct{}{}
+			} else {
+				delete(c.subs, id)
+			}
+		}
+		c.mu.Unlock()
+
+		if req.Type == "unsubscribe" {
+			return response{Type: "values"}
+		}
+
+		res := response{Type: "values", Values: make(map[string]float64, len(ids))}
+		_ = b.Do(func(s *casso.Solver) error {
+			for _, id := range ids {
+				res.Values[b.names[id]] = s.Val(id)
+			}
+			return nil
+		})
+		return res
+	case "suggest":
+		id, ok := b.vars[req.Var]
+		if !ok {
+			return response{Type: "error", Error: fmt.Sprintf("unknown variable %q", req.Var)}
+		}
+		res := response{Type: "values", Values: make(map[string]float64, 1)}
+		err := b.Do(func(s *casso.Solver) error {
+			if err := s.Suggest(id, req.Value); err != nil {
+				return err
+			}
+			res.Values[req.Var] = s.Val(id)
+			return nil
+		})
+		if err != nil {
+			return response{Type: "error", Error: err.Error()}
+		}
+		return res
+	}
+	return response{Type: "error", Error: fmt.Sprintf("unknown request type %q", req.Type)}
+}
+
+// broadcast queues changes made to the solver for all clients subscribed to the changed
+// variables. Clients whose queues are full are disconnected rather than waited on, as the solver
+// may not be accessed until broadcast receives the next changes.
+func (b *Bridge) broadcast() {
+	defer close(b.done)
+
+	for changes := range b.changes {
+		b.cmu.Lock()
+		for c := range b.clients {
+			c.mu.Lock()
+			res := response{Type: "update"}
+			for _, ch := range changes {
+				if _, ok := c.subs[ch.Variable]; ok {
+					res.Changes = append(res.Changes, change{Var: b.names[ch.Variable], Old: ch.Old, New: ch.New})
+				}
+			}
+			c.mu.Unlock()
+
+			if len(res.Changes) == 0 {
+				continue
+			}
+			select {
+			case c.updates <- res:
+			default:
+				_ = c.conn.close()
+			}
+		}
+		b.cmu.Unlock()
+	}
+}
+
+// write writes the updates queued for the client until its queue is closed, closing the
+// connection should a write fail.
+func (c *client) write() {
+	for res := range c.updates {
+		if err := c.send(res); err != nil {
+			_ = c.conn.close()
+		}
+	}
+}
+
+func (c *client) send(res response) error {
+	buf, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	return c.conn.writeMessage(buf)
+}
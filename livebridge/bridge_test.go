@@ -0,0 +1,179 @@
+package livebridge_test
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"github.com/lithdew/casso"
+	"github.com/lithdew/casso/livebridge"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// testClient is a minimal WebSocket client that writes masked single-frame text messages.
+type testClient struct {
+	t  *testing.T
+	nc net.Conn
+	br *bufio.Reader
+}
+
+func dial(t *testing.T, url string) *testClient {
+	return dialOrigin(t, url, "")
+}
+
+func dialOrigin(t *testing.T, url, origin string) *testClient {
+	nc, err := net.Dial("tcp", strings.TrimPrefix(url, "http://"))
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	require.NoError(t, req.Write(nc))
+
+	br := bufio.NewReader(nc)
+	res, err := http.ReadResponse(br, req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusSwitchingProtocols, res.StatusCode)
+	require.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", res.Header.Get("Sec-WebSocket-Accept"))
+
+	return &testClient{t: t, nc: nc, br: br}
+}
+
+func (c *testClient) send(v interface{}) {
+	payload, err := json.Marshal(v)
+	require.NoError(c.t, err)
+	require.Less(c.t, len(payload), 126)
+
+	mask := [4]byte{0x12, 0x34, 0x56, 0x78}
+	frame := []byte{0x81, 0x80 | byte(len(payload)), mask[0], mask[1], mask[2], mask[3]}
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	_, err = c.nc.Write(frame)
+	require.NoError(c.t, err)
+}
+
+func (c *testClient) recv() map[string]interface{} {
+	var hdr [2]byte
+	_, err := io.ReadFull(c.br, hdr[:])
+	require.NoError(c.t, err)
+	require.EqualValues(c.t, 0x81, hdr[0])
+
+	size := int(hdr[1])
+	if size == 126 {
+		var ext [2]byte
+		_, err := io.ReadFull(c.br, ext[:])
+		require.NoError(c.t, err)
+		size = int(binary.BigEndian.Uint16(ext[:]))
+	}
+
+	payload := make([]byte, size)
+	_, err = io.ReadFull(c.br, payload)
+	require.NoError(c.t, err)
+
+	var msg map[string]interface{}
+	require.NoError(c.t, json.Unmarshal(payload, &msg))
+	return msg
+}
+
+func TestBridge(t *testing.T) {
+	s := casso.NewSolver()
+
+	left := casso.New()
+	width := casso.New()
+	right := casso.New()
+
+	_, err := s.AddConstraint(left.EQ(10))
+	require.NoError(t, err)
+	_, err = s.AddConstraint(casso.NewConstraint(casso.EQ, 0, right.T(1), left.T(-1), width.T(-1)))
+	require.NoError(t, err)
+	require.NoError(t, s.Edit(width, casso.Strong))
+	require.NoError(t, s.Suggest(width, 100))
+
+	b := livebridge.New(s, map[string]casso.Symbol{"left": left, "width": width, "right": right})
+	defer b.Close()
+
+	srv := httptest.NewServer(b)
+	defer srv.Close()
+
+	c := dial(t, srv.URL)
+	defer c.nc.Close()
+
+	c.send(map[string]interface{}{"type": "subscribe", "vars": []string{"right"}})
+	require.Equal(t, map[string]interface{}{"type": "values", "values": map[string]interface{}{"right": 110.0}}, c.recv())
+
+	c.send(map[string]interface{}{"type": "suggest", "var": "width", "value": 200})
+	msgs := []map[string]interface{}{c.recv(), c.recv()}
+	require.Contains(t, msgs, map[string]interface{}{"type": "values", "values": map[string]interface{}{"width": 200.0}})
+	require.Contains(t, msgs, map[string]interface{}{
+		"type":    "update",
+		"changes": []interface{}{map[string]interface{}{"var": "right", "old": 110.0, "new": 210.0}},
+	})
+
+	c.send(map[string]interface{}{"type": "suggest", "var": "left", "value": 5})
+	require.Equal(t, map[string]interface{}{"type": "error", "error": casso.ErrBadEditVariable.Error()}, c.recv())
+
+	c.send(map[string]interface{}{"type": "subscribe", "vars": []string{"bottom"}})
+	require.Equal(t, map[string]interface{}{"type": "error", "error": `unknown variable "bottom"`}, c.recv())
+
+	// changes made by the application through the bridge are pushed to clients
+
+	require.NoError(t, b.Do(func(s *casso.Solver) error { return s.Suggest(width, 50) }))
+	require.Equal(t, map[string]interface{}{
+		"type":    "update",
+		"changes": []interface{}{map[string]interface{}{"var": "right", "old": 210.0, "new": 60.0}},
+	}, c.recv())
+}
+
+func TestBridgeRejectsPlainRequests(t *testing.T) {
+	b := livebridge.New(casso.NewSolver(), nil)
+	defer b.Close()
+
+	srv := httptest.NewServer(b)
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+func TestBridgeOrigins(t *testing.T) {
+	b := livebridge.New(casso.NewSolver(), nil)
+	defer b.Close()
+	b.Origins = []string{"https://tools.example.com"}
+
+	srv := httptest.NewServer(b)
+	defer srv.Close()
+
+	for _, origin := range []string{"https://tools.example.com", srv.URL} {
+		c := dialOrigin(t, srv.URL, origin)
+		c.nc.Close()
+	}
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	req.Header.Set("Origin", "https://evil.example.com")
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusForbidden, res.StatusCode)
+}
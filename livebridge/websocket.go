@@ -0,0 +1,231 @@
+package livebridge
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// This file implements the subset of the WebSocket protocol (RFC 6455) needed to serve a bridge:
+// the server side of the opening handshake, and reading and writing unextended data frames.
+
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xa
+)
+
+const (
+	maxMessageSize = 1 << 20
+	writeTimeout   = 10 * time.Second
+)
+
+const handshakeGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+var (
+	errBadHandshake = errors.New("websocket: bad handshake")
+	errBadFrame     = errors.New("websocket: bad frame")
+	errTooLarge     = errors.New("websocket: message too large")
+)
+
+// conn is the server side of a WebSocket connection. Reads must be made from a single goroutine,
+// while writes may be made concurrently.
+type conn struct {
+	nc net.Conn
+	br *bufio.Reader
+
+	mu sync.Mutex // guards writes
+}
+
+// accept computes the value of the Sec-WebSocket-Accept header for a Sec-WebSocket-Key.
+func accept(key string) string {
+	h := sha1.Sum([]byte(key + handshakeGUID))
+	return base64.StdEncoding.EncodeToString(h[:])
+}
+
+func headerContains(h http.Header, name, token string) bool {
+	for _, val := range h[http.CanonicalHeaderKey(name)] {
+		for _, field := range strings.Split(val, ",") {
+			if strings.EqualFold(strings.TrimSpace(field), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// upgrade performs the opening handshake of a WebSocket connection. Should the request not be a
+// valid WebSocket handshake, an error is returned and the response is left untouched.
+func upgrade(w http.ResponseWriter, r *http.Request) (*conn, error) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	switch {
+	case r.Method != http.MethodGet,
+		!headerContains(r.Header, "Connection", "upgrade"),
+		!headerContains(r.Header, "Upgrade", "websocket"),
+		r.Header.Get("Sec-WebSocket-Version") != "13",
+		key == "":
+		return nil, errBadHandshake
+	}
+
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		return nil, errBadHandshake
+	}
+	nc, brw, err := hj.Hijack()
+	if err != nil {
+		return nil, err
+	}
+
+	res := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + accept(key) + "\r\n\r\n"
+
+	if _, err := brw.WriteString(res); err != nil {
+		nc.Close()
+		return nil, err
+	}
+	if err := brw.Flush(); err != nil {
+		nc.Close()
+		return nil, err
+	}
+
+	return &conn{nc: nc, br: brw.Reader}, nil
+}
+
+// readMessage reads the next text or binary message, reassembling fragmented messages and
+// answering control frames. io.EOF is returned once the peer closes the connection.
+func (c *conn) readMessage() ([]byte, error) {
+	var msg []byte
+	for {
+		fin, op, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+
+		switch op {
+		case opClose:
+			_ = c.writeFrame(opClose, payload)
+			return nil, io.EOF
+		case opPing:
+			if err := c.writeFrame(opPong, payload); err != nil {
+				return nil, err
+			}
+			continue
+		case opPong:
+			continue
+		case opText, opBinary:
+			if msg != nil {
+				return nil, errBadFrame
+			}
+			msg = payload
+		case opContinuation:
+			if msg == nil {
+				return nil, errBadFrame
+			}
+			if len(msg)+len(payload) > maxMessageSize {
+				return nil, errTooLarge
+			}
+			msg = append(msg, payload...)
+		default:
+			return nil, errBadFrame
+		}
+
+		if fin {
+			return msg, nil
+		}
+	}
+}
+
+func (c *conn) readFrame() (fin bool, op byte, payload []byte, err error) {
+	var hdr [2]byte
+	if _, err := io.ReadFull(c.br, hdr[:]); err != nil {
+		return false, 0, nil, err
+	}
+
+	fin, op = hdr[0]&0x80 != 0, hdr[0]&0x0f
+	if hdr[0]&0x70 != 0 || hdr[1]&0x80 == 0 { // no extensions are negotiated, and clients must mask
+		return false, 0, nil, errBadFrame
+	}
+
+	size := uint64(hdr[1] & 0x7f)
+	switch size {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		size = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		size = binary.BigEndian.Uint64(ext[:])
+	}
+	if size > maxMessageSize {
+		return false, 0, nil, errTooLarge
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(c.br, mask[:]); err != nil {
+		return false, 0, nil, err
+	}
+
+	payload = make([]byte, size)
+	if _, err := io.ReadFull(c.br, payload); err != nil {
+		return false, 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+
+	return fin, op, payload, nil
+}
+
+// writeMessage writes a text message as a single frame.
+func (c *conn) writeMessage(msg []byte) error {
+	return c.writeFrame(opText, msg)
+}
+
+func (c *conn) writeFrame(op byte, payload []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	hdr := make([]byte, 2, 10)
+	hdr[0] = 0x80 | op
+
+	switch {
+	case len(payload) < 126:
+		hdr[1] = byte(len(payload))
+	case len(payload) <= 0xffff:
+		hdr[1] = 126
+		hdr = hdr[:4]
+		binary.BigEndian.PutUint16(hdr[2:], uint16(len(payload)))
+	default:
+		hdr[1] = 127
+		hdr = hdr[:10]
+		binary.BigEndian.PutUint64(hdr[2:], uint64(len(payload)))
+	}
+
+	if err := c.nc.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+	if _, err := c.nc.Write(append(hdr, payload...)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *conn) close() error { return c.nc.Close() }
@@ -1,6 +1,13 @@
 package casso
 
-import "sync/atomic"
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
 
 type SymbolKind uint8
 
@@ -18,6 +25,13 @@ var SymbolTable = [...]string{
 	Dummy:    "Dummy",
 }
 
+var SymbolPrefixTable = [...]string{
+	External: "v",
+	Slack:    "s",
+	Error:    "e",
+	Dummy:    "d",
+}
+
 func (s SymbolKind) Restricted() bool { return s == Slack || s == Error }
 func (s SymbolKind) String() string   { return SymbolTable[s] }
 
@@ -28,15 +42,42 @@ var (
 	zero  Symbol
 )
 
+// local is set on the ids of symbols created by solvers, such that they never collide with symbols
+// created via New.
+const local = 1 << 61
+
+// New creates a new external symbol shared by all solvers. Symbols created via New are counted
+// process-wide; see Solver.New for symbols counted by a solver of their own.
 func New() Symbol {
 	return next(External)
 }
 
 func next(typ SymbolKind) Symbol {
-	return Symbol((atomic.AddUint64(&count, 1) & 0x3fffffffffffffff) | (uint64(typ) << 62))
+	return Symbol((atomic.AddUint64(&count, 1) & (local - 1)) | (uint64(typ) << 62))
+}
+
+// New creates a new external symbol counted by the solver rather than process-wide. Symbols created
+// by a solver never collide with symbols created via New, though they may collide with symbols
+// created by other solvers, and thus should only be used with the solver that created them and its
+// clones.
+func (s *Solver) New() Symbol {
+	return s.next(External)
+}
+
+// next creates a new symbol of the given kind counted by the solver. Solvers count their own slack,
+// error, and dummy symbols, such that solvers running on different goroutines do not contend over
+// a shared counter.
+func (s *Solver) next(typ SymbolKind) Symbol {
+	s.count++
+	return Symbol((s.count & (local - 1)) | local | (uint64(typ) << 62))
 }
 
 func (sym Symbol) Kind() SymbolKind { return SymbolKind(sym >> 62) }
+func (sym Symbol) ID() uint64       { return uint64(sym) & (local - 1) }
+func (sym Symbol) Local() bool      { return uint64(sym)&local != 0 }
+func (sym Symbol) String() string {
+	return SymbolPrefixTable[sym.Kind()] + strconv.FormatUint(sym.ID(), 10)
+}
 func (sym Symbol) Zero() bool       { return sym == zero }
 func (sym Symbol) Restricted() bool { return !sym.Zero() && sym.Kind().Restricted() }
 func (sym Symbol) External() bool   { return !sym.Zero() && sym.Kind() == External }
@@ -50,6 +91,12 @@ func (sym Symbol) EQ(val float64) Constraint  { return NewConstraint(EQ, -val, s
 func (sym Symbol) GTE(val float64) Constraint { return NewConstraint(GTE, -val, sym.T(1.0)) }
 func (sym Symbol) LTE(val float64) Constraint { return NewConstraint(LTE, -val, sym.T(1.0)) }
 
+func (sym Symbol) Expr() Expr                 { return NewExpr(0, sym.T(1.0)) }
+func (sym Symbol) Times(coeff float64) Expr   { return NewExpr(0, sym.T(coeff)) }
+func (sym Symbol) Plus(other Symbol) Expr     { return sym.Expr().Add(other.Expr()) }
+func (sym Symbol) Minus(other Symbol) Expr    { return sym.Expr().Sub(other.Expr()) }
+func (sym Symbol) PlusConst(val float64) Expr { return NewExpr(val, sym.T(1.0)) }
+
 type Priority float64
 
 const (
@@ -59,6 +106,87 @@ const (
 	Required          = 1e3 * Strong
 )
 
+// Strength composes a priority out of strong, medium, and weak components scaled by weight, as
+// kiwi's strength::create does. Components are each clamped to [0, 1000] once scaled, and the
+// composed priority is clamped to remain below Required, such that composed priorities are always
+// valid priorities for edit variables. For example, Strength(0, 1, 1, 1) is slightly stronger than
+// Medium.
+func Strength(strong, medium, weak, weight float64) Priority {
+	clamp := func(val float64) float64 { return math.Max(0, math.Min(1000, val*weight)) }
+
+	p := Priority(clamp(strong))*Strong + Priority(clamp(medium))*Medium + Priority(clamp(weak))*Weak
+	if p >= Required {
+		p = Priority(math.Nextafter(float64(Required), 0))
+	}
+	return p
+}
+
+// Clip clamps a priority to the range of valid priorities [0, Required].
+func (p Priority) Clip() Priority {
+	if p < 0 {
+		return 0
+	}
+	if p > Required {
+		return Required
+	}
+	return p
+}
+
+func (p Priority) String() string {
+	switch p {
+	case Required:
+		return "required"
+	case Strong:
+		return "strong"
+	case Medium:
+		return "medium"
+	case Weak:
+		return "weak"
+	}
+	return strconv.FormatFloat(float64(p), 'g', -1, 64)
+}
+
+// ParsePriority parses a priority from its name (e.g. "strong"), from a number (e.g. "1e3"), or from
+// a name offset by a number (e.g. "strong+1", "medium-0.5"). Names are case-insensitive. Priorities
+// that are negative, not a number, or above Required are rejected with ErrBadPriority.
+func ParsePriority(s string) (Priority, error) {
+	p, err := parsePriority(s)
+	if err != nil {
+		return 0, err
+	}
+	if p != p || p < 0 || p > Required {
+		return 0, fmt.Errorf("%w: %q", ErrBadPriority, s)
+	}
+	return p, nil
+}
+
+func parsePriority(s string) (Priority, error) {
+	str := strings.TrimSpace(s)
+	if val, err := strconv.ParseFloat(str, 64); err == nil {
+		return Priority(val), nil
+	}
+
+	name, offset := str, 0.0
+	if i := strings.IndexAny(str, "+-"); i > 0 {
+		val, err := strconv.ParseFloat(strings.TrimSpace(str[i+1:]), 64)
+		if err != nil || val != val {
+			return 0, fmt.Errorf("%w %q: bad offset", ErrUnknownPriority, s)
+		}
+		if str[i] == '-' {
+			val = -val
+		}
+		name, offset = strings.TrimSpace(str[:i]), val
+	}
+
+	for _, p := range [...]Priority{Required, Strong, Medium, Weak} {
+		if strings.EqualFold(p.String(), name) {
+			return p + Priority(offset), nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w %q", ErrUnknownPriority, s)
+}
+
 type Op uint8
 
 const (
@@ -75,6 +203,20 @@ var OpTable = [...]string{
 
 func (o Op) String() string { return OpTable[o] }
 
+// ParseOp parses an operator from its symbol in OpTable. "==" is accepted as an alias of "=".
+func ParseOp(s string) (Op, error) {
+	str := strings.TrimSpace(s)
+	if str == "==" {
+		return EQ, nil
+	}
+	for op, sym := range OpTable {
+		if sym == str {
+			return Op(op), nil
+		}
+	}
+	return 0, fmt.Errorf("%w %q", ErrUnknownOp, s)
+}
+
 type Constraint struct {
 	op   Op
 	expr Expr
@@ -84,16 +226,35 @@ func NewConstraint(op Op, constant float64, terms ...Term) Constraint {
 	return Constraint{op: op, expr: NewExpr(constant, terms...)}
 }
 
+// Relate returns the constraint 'lhs op rhs', moving all terms and constants onto the left-hand side
+// such that it takes the form 'lhs - rhs op 0'.
+func Relate(lhs Expr, op Op, rhs Expr) Constraint {
+	return Constraint{op: op, expr: lhs.Sub(rhs)}
+}
+
+func (c Constraint) Op() Op     { return c.op }
+func (c Constraint) Expr() Expr { return c.expr }
+
 func (c Constraint) clone() Constraint {
 	res := Constraint{op: c.op, expr: c.expr.clone()}
 	return res
 }
 
+// Rule is a constraint paired with the priority it is to be installed with.
+type Rule struct {
+	Priority   Priority
+	Constraint Constraint
+}
+
 type Term struct {
 	coeff float64
 	id    Symbol
 }
 
+func (t Term) Coeff() float64 { return t.coeff }
+func (t Term) Symbol() Symbol { return t.id }
+func (t Term) Expr() Expr     { return NewExpr(0, t) }
+
 type Expr struct {
 	constant float64
 	terms    []Term
@@ -103,45 +264,242 @@ func NewExpr(constant float64, terms ...Term) Expr {
 	return Expr{constant: constant, terms: terms}
 }
 
+// NewExprFromMap returns the expression constant + sum(coeff * id) over all symbols and coefficients
+// in coeffs. Terms with zero coefficients are dropped, and terms are ordered by symbol such that
+// the expression does not depend on the order maps are iterated in.
+func NewExprFromMap(constant float64, coeffs map[Symbol]float64) Expr {
+	res := Expr{constant: constant, terms: make([]Term, 0, len(coeffs))}
+	for id, coeff := range coeffs {
+		if coeff != 0 {
+			res.terms = append(res.terms, Term{coeff: coeff, id: id})
+		}
+	}
+	sort.Slice(res.terms, func(i, j int) bool { return res.terms[i].id < res.terms[j].id })
+	return res
+}
+
+func (c Expr) Constant() float64 { return c.constant }
+
+func (c Expr) EQ(val float64) Constraint  { return NewConstraint(EQ, c.constant-val, c.Terms()...) }
+func (c Expr) GTE(val float64) Constraint { return NewConstraint(GTE, c.constant-val, c.Terms()...) }
+func (c Expr) LTE(val float64) Constraint { return NewConstraint(LTE, c.constant-val, c.Terms()...) }
+
+func (c Expr) EQExpr(o Expr) Constraint  { return Relate(c, EQ, o) }
+func (c Expr) GTEExpr(o Expr) Constraint { return Relate(c, GTE, o) }
+func (c Expr) LTEExpr(o Expr) Constraint { return Relate(c, LTE, o) }
+
+// exact is the tolerance of expression arithmetic, which only cancels out terms whose coefficients
+// are exactly zero. Solvers cancel out terms within their own tolerance once constraints are added.
+const exact = math.SmallestNonzeroFloat64
+
+// Add returns the expression c + o.
+func (c Expr) Add(o Expr) Expr {
+	res := c.canonical()
+	res.addExpr(1.0, o.canonical(), exact)
+	return res
+}
+
+// Sub returns the expression c - o.
+func (c Expr) Sub(o Expr) Expr {
+	res := c.canonical()
+	res.addExpr(-1.0, o.canonical(), exact)
+	return res
+}
+
+// AddConst returns the expression c + val.
+func (c Expr) AddConst(val float64) Expr {
+	res := c.canonical()
+	res.constant += val
+	return res
+}
+
+// MulConst returns the expression c * val.
+func (c Expr) MulConst(val float64) Expr {
+	if val == 0 {
+		return Expr{constant: c.constant * val}
+	}
+	res := c.canonical()
+	res.constant *= val
+	for i := range res.terms {
+		res.terms[i].coeff *= val
+	}
+	return res
+}
+
+// DivConst returns the expression c / val.
+func (c Expr) DivConst(val float64) Expr { return c.MulConst(1.0 / val) }
+
+// Neg returns the expression -c.
+func (c Expr) Neg() Expr { return c.MulConst(-1.0) }
+
+// Terms returns a copy of the terms of the expression.
+func (c Expr) Terms() []Term {
+	res := make([]Term, len(c.terms))
+	copy(res, c.terms)
+	return res
+}
+
 func (c Expr) clone() Expr {
 	res := Expr{constant: c.constant, terms: make([]Term, len(c.terms))}
 	copy(res.terms, c.terms)
 	return res
 }
 
-func (c Expr) find(id Symbol) int {
-	for i := 0; i < len(c.terms); i++ {
-		if c.terms[i].id == id {
-			return i
+// canonical returns a copy of c whose terms are ordered by symbol, with the terms of each symbol
+// combined and terms whose coefficients are zero dropped. Expressions kept by a solver are always
+// canonical, whereas expressions provided by users may order their terms in any way.
+func (c Expr) canonical() Expr {
+	res := c.clone()
+	sort.SliceStable(res.terms, func(i, j int) bool { return res.terms[i].id < res.terms[j].id })
+
+	n := 0
+	for _, term := range res.terms {
+		if n > 0 && res.terms[n-1].id == term.id {
+			res.terms[n-1].coeff += term.coeff
+			continue
 		}
+		res.terms[n] = term
+		n++
+	}
+	res.terms = res.terms[:n]
+
+	n = 0
+	for _, term := range res.terms {
+		if term.coeff != 0 {
+			res.terms[n] = term
+			n++
+		}
+	}
+	res.terms = res.terms[:n]
+
+	return res
+}
+
+// search returns the index of the term of id among the terms of c, or the index at which it is to
+// be inserted should c have no such term. c must be canonical.
+func (c Expr) search(id Symbol) int {
+	lo, hi := 0, len(c.terms)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if c.terms[mid].id < id {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// find returns the index of the term of id, or -1 should c have no such term. c must be canonical.
+func (c Expr) find(id Symbol) int {
+	idx := c.search(id)
+	if idx < len(c.terms) && c.terms[idx].id == id {
+		return idx
 	}
 	return -1
 }
 
+// contains reports whether c has a term of id, regardless of whether c is canonical.
+func (c Expr) contains(id Symbol) bool {
+	for _, term := range c.terms {
+		if term.id == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Expr) insert(idx int, term Term) {
+	c.terms = append(c.terms, Term{})
+	copy(c.terms[idx+1:], c.terms[idx:])
+	c.terms[idx] = term
+}
+
 func (c *Expr) delete(idx int) {
 	copy(c.terms[idx:], c.terms[idx+1:])
 	c.terms = c.terms[:len(c.terms)-1]
 }
 
-func (c *Expr) addSymbol(coeff float64, id Symbol) {
-	idx := c.find(id)
-	if idx == -1 {
-		if !eqz(coeff) {
-			c.terms = append(c.terms, Term{coeff: coeff, id: id})
+func (c *Expr) addSymbol(coeff float64, id Symbol, eps float64) {
+	idx := c.search(id)
+	if idx == len(c.terms) || c.terms[idx].id != id {
+		if !nearZero(coeff, eps) {
+			c.insert(idx, Term{coeff: coeff, id: id})
 		}
 		return
 	}
 	c.terms[idx].coeff += coeff
-	if eqz(c.terms[idx].coeff) {
+	if nearZero(c.terms[idx].coeff, eps) {
 		c.delete(idx)
 	}
 }
 
-func (c *Expr) addExpr(coeff float64, other Expr) {
+// mergeRatio is the ratio of the number of terms of an expression to the number of terms of the
+// expression added to it past which the terms added are binary searched for rather than merged.
+const mergeRatio = 16
+
+// addExpr adds other scaled by coeff to c. Both c and other must be canonical, such that their terms
+// are merged in place from the back of c in a single pass over both. Terms whose coefficients
+// cancel out are dropped.
+func (c *Expr) addExpr(coeff float64, other Expr, eps float64) {
 	c.constant += coeff * other.constant
-	for i := 0; i < len(other.terms); i++ {
-		c.addSymbol(coeff*other.terms[i].coeff, other.terms[i].id)
+	if len(other.terms) == 0 {
+		return
+	}
+	if len(other.terms)*mergeRatio < len(c.terms) {
+		for _, term := range other.terms {
+			c.addSymbol(coeff*term.coeff, term.id, eps)
+		}
+		return
+	}
+	c.merge(coeff, other, -1, len(other.terms), eps)
+}
+
+// merge merges the terms of other scaled by coeff into c from the back of c, dropping the term of c
+// at index skip along the way should skip not be negative. The terms are merged into room for added
+// more terms past the end of c, where added may be no fewer than the number of terms of other whose
+// symbols c does not reference. As every term of other either lands past the end of c or on a term
+// of c already merged, the merge never overwrites a term of c it has yet to visit.
+func (c *Expr) merge(coeff float64, other Expr, skip, added int, eps float64) {
+	n, m := len(c.terms), len(other.terms)
+	c.terms = append(c.terms, make([]Term, added)...)
+
+	i, j, k := n-1, m-1, n+added-1
+	for j >= 0 {
+		switch {
+		case i >= 0 && i == skip:
+			i--
+			continue
+		case i >= 0 && c.terms[i].id > other.terms[j].id:
+			c.terms[k] = c.terms[i]
+			i--
+		case i >= 0 && c.terms[i].id == other.terms[j].id:
+			val := c.terms[i].coeff + coeff*other.terms[j].coeff
+			i, j = i-1, j-1
+			if nearZero(val, eps) {
+				continue
+			}
+			c.terms[k] = Term{coeff: val, id: other.terms[j+1].id}
+		default:
+			val := coeff * other.terms[j].coeff
+			j--
+			if nearZero(val, eps) {
+				continue
+			}
+			c.terms[k] = Term{coeff: val, id: other.terms[j+1].id}
+		}
+		k--
+	}
+
+	// terms c.terms[:i+1] are in place but for the term at skip, should it not have been passed
+	// over; close the gap left by it and by dropped terms.
+
+	if skip >= 0 && skip <= i {
+		copy(c.terms[skip:], c.terms[skip+1:i+1])
+		i--
 	}
+	copy(c.terms[i+1:], c.terms[k+1:n+added])
+	c.terms = c.terms[:i+1+n+added-1-k]
 }
 
 func (c *Expr) negate() {
@@ -173,24 +531,73 @@ func (c *Expr) solveFor(id Symbol) {
 	}
 }
 
-func (c *Expr) solveForSymbols(lhs, rhs Symbol) {
-	c.addSymbol(-1.0, lhs)
+func (c *Expr) solveForSymbols(lhs, rhs Symbol, eps float64) {
+	c.addSymbol(-1.0, lhs, eps)
 	c.solveFor(rhs)
 }
 
-func (c *Expr) substitute(id Symbol, other Expr) {
+// substitute substitutes other for id in c. Terms of other whose symbols c does not reference are
+// merged into room for added more terms, for which the rules of merge apply.
+func (c *Expr) substitute(id Symbol, other Expr, added int, eps float64) {
 	idx := c.find(id)
 	if idx == -1 {
 		return
 	}
 	coeff := c.terms[idx].coeff
-	c.delete(idx)
-	c.addExpr(coeff, other)
+	c.constant += coeff * other.constant
+
+	// drop the term of id while merging rather than shifting the terms after it beforehand
+
+	if len(other.terms) > 0 && len(other.terms)*mergeRatio >= len(c.terms) {
+		c.merge(coeff, other, idx, added, eps)
+		return
+	}
+
+	// otherwise, fill the slot of id with the first term of other not in c, such that only the terms
+	// between the two are shifted rather than every term after either
+
+	hole := idx
+	for _, term := range other.terms {
+		val := coeff * term.coeff
+		i := c.search(term.id)
+		if i < len(c.terms) && c.terms[i].id == term.id {
+			c.terms[i].coeff += val
+			if nearZero(c.terms[i].coeff, eps) {
+				c.delete(i)
+				if i < hole {
+					hole--
+				}
+			}
+			continue
+		}
+		if nearZero(val, eps) {
+			continue
+		}
+		if hole == -1 {
+			c.insert(i, Term{coeff: val, id: term.id})
+			continue
+		}
+		if i > hole {
+			copy(c.terms[hole:i-1], c.terms[hole+1:i])
+			i--
+		} else {
+			copy(c.terms[i+1:hole+1], c.terms[i:hole])
+		}
+		c.terms[i] = Term{coeff: val, id: term.id}
+		hole = -1
+	}
+	if hole != -1 {
+		c.delete(hole)
+	}
 }
 
-func eqz(val float64) bool {
+// DefaultEpsilon is the tolerance below which values are treated as zero by solvers not configured
+// otherwise via WithEpsilon.
+const DefaultEpsilon = 1.0e-8
+
+func nearZero(val, eps float64) bool {
 	if val < 0 {
-		return -val < 1.0e-8
+		return -val < eps
 	}
-	return val < 1.0e-8
+	return val < eps
 }
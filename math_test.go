@@ -1,7 +1,10 @@
 package casso
 
 import (
+	"errors"
 	"github.com/stretchr/testify/require"
+	"math/rand"
+	"sort"
 	"testing"
 )
 
@@ -22,3 +25,261 @@ func TestSymbol(t *testing.T) {
 	require.False(t, v.Zero())
 	require.EqualValues(t, Dummy, v.Kind())
 }
+
+func TestSolverSymbols(t *testing.T) {
+	a := NewSolver()
+	b := NewSolver()
+
+	x := a.New()
+	require.True(t, x.External())
+	require.True(t, x.Local())
+	require.False(t, New().Local())
+
+	// solvers count their symbols independently of one another, apart from symbols created via New
+
+	require.Equal(t, x, b.New())
+	require.NotEqual(t, x, Symbol(x.ID()))
+	require.Equal(t, a.next(Slack), b.next(Slack))
+	require.EqualValues(t, 3, a.next(Error).ID())
+	require.Equal(t, "e3", b.next(Error).String())
+
+	c := a.Clone()
+	require.Equal(t, a.next(Dummy), c.next(Dummy))
+}
+
+func TestParsePriority(t *testing.T) {
+	cases := map[string]Priority{
+		"required":     Required,
+		"Strong":       Strong,
+		"medium":       Medium,
+		"weak":         Weak,
+		"strong+1":     Strong + 1,
+		"medium - 0.5": Medium - 0.5,
+		"12.5":         12.5,
+		"1e3":          Medium,
+		" weak ":       Weak,
+	}
+	for str, expected := range cases {
+		actual, err := ParsePriority(str)
+		require.NoError(t, err, str)
+		require.EqualValues(t, expected, actual, str)
+	}
+
+	for _, str := range []string{"", "heavy", "strong+", "strong+x", "+1strong"} {
+		_, err := ParsePriority(str)
+		require.True(t, errors.Is(err, ErrUnknownPriority), str)
+	}
+
+	for _, str := range []string{"NaN", "-1", "Inf", "-Inf", "required+1", "weak-1e9", "2e9"} {
+		_, err := ParsePriority(str)
+		require.True(t, errors.Is(err, ErrBadPriority), str)
+	}
+}
+
+func TestStrength(t *testing.T) {
+	require.Equal(t, Strong, Strength(1, 0, 0, 1))
+	require.Equal(t, Medium, Strength(0, 1, 0, 1))
+	require.Equal(t, Weak, Strength(0, 0, 1, 1))
+	require.Equal(t, Medium+Weak, Strength(0, 1, 1, 1))
+	require.Equal(t, 2*Strong, Strength(1, 0, 0, 2))
+
+	require.EqualValues(t, 0, Strength(-1, -1, -1, 1))
+	require.Less(t, float64(Strength(1000, 1000, 1000, 1)), float64(Required))
+	require.Less(t, float64(Strength(2000, 0, 0, 1)), float64(Required))
+
+	require.EqualValues(t, 0, Priority(-1).Clip())
+	require.Equal(t, Required, (2 * Required).Clip())
+	require.Equal(t, Medium, Medium.Clip())
+}
+
+func TestParseOp(t *testing.T) {
+	for op, str := range OpTable {
+		actual, err := ParseOp(str)
+		require.NoError(t, err)
+		require.EqualValues(t, op, actual)
+	}
+
+	actual, err := ParseOp("==")
+	require.NoError(t, err)
+	require.EqualValues(t, EQ, actual)
+
+	_, err = ParseOp("=>")
+	require.True(t, errors.Is(err, ErrUnknownOp))
+	require.EqualError(t, err, `unknown operator "=>"`)
+}
+
+func TestExprArithmetic(t *testing.T) {
+	x := New()
+	y := New()
+
+	// (x + y) * 2 - (x - 10) / 2 == 1.5x + 2y + 5
+
+	e := x.Plus(y).MulConst(2).Sub(x.PlusConst(-10).DivConst(2))
+	require.EqualValues(t, 5, e.Constant())
+	require.Equal(t, []Term{x.T(1.5), y.T(2)}, e.Terms())
+
+	require.Empty(t, x.Minus(x).Terms())
+	require.Equal(t, NewExpr(-5, x.T(-1.5), y.T(-2)), e.Neg())
+	require.Equal(t, NewExpr(0), e.MulConst(0))
+
+	// Operands are left untouched.
+
+	a := x.Expr()
+	b := a.Add(y.Times(3)).AddConst(1)
+	require.Equal(t, x.Expr(), a)
+	require.Equal(t, NewExpr(1, x.T(1), y.T(3)), b)
+}
+
+func TestNewExprFromMap(t *testing.T) {
+	x := New()
+	y := New()
+	z := New()
+
+	e := NewExprFromMap(5, map[Symbol]float64{z: 3, x: 1, y: 0})
+	require.Equal(t, NewExpr(5, x.T(1), z.T(3)), e)
+	require.Empty(t, NewExprFromMap(1, nil).Terms())
+}
+
+func TestRelate(t *testing.T) {
+	x := New()
+	y := New()
+
+	// x + 10 <= 2y - 5 == x - 2y + 15 <= 0
+
+	c := Relate(x.PlusConst(10), LTE, y.Times(2).AddConst(-5))
+	require.Equal(t, LTE, c.Op())
+	require.Equal(t, NewExpr(15, x.T(1), y.T(-2)), c.Expr())
+
+	require.Equal(t, Relate(x.Expr(), EQ, y.T(3).Expr()), x.Expr().EQExpr(y.Times(3)))
+	require.Equal(t, GTE, x.Expr().GTEExpr(y.Expr()).Op())
+	require.Equal(t, LTE, x.Expr().LTEExpr(y.Expr()).Op())
+}
+
+// randomExpr returns a canonical expression with n terms picked from ids, which must be ordered.
+func randomExpr(rng *rand.Rand, ids []Symbol, n int) Expr {
+	picked := rng.Perm(len(ids))[:n]
+	sort.Ints(picked)
+
+	expr := NewExpr(rng.Float64())
+	for _, i := range picked {
+		expr.terms = append(expr.terms, ids[i].T(float64(rng.Intn(4)+1)*float64(rng.Intn(2)*2-1)))
+	}
+	return expr
+}
+
+func TestAddExprMerge(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	ids := make([]Symbol, 64)
+	for i := range ids {
+		ids[i] = next(External)
+	}
+
+	for i := 0; i < 1000; i++ {
+		a := randomExpr(rng, ids, rng.Intn(len(ids)))
+		b := randomExpr(rng, ids, rng.Intn(len(ids)))
+		coeff := float64(rng.Intn(3) - 1)
+
+		coeffs := make(map[Symbol]float64)
+		for _, term := range a.terms {
+			coeffs[term.id] += term.coeff
+		}
+		for _, term := range b.terms {
+			coeffs[term.id] += coeff * term.coeff
+		}
+		expected := NewExprFromMap(a.constant+coeff*b.constant, coeffs)
+
+		res := a.clone()
+		res.addExpr(coeff, b, DefaultEpsilon)
+		require.Equal(t, expected, res)
+
+		for _, term := range b.terms {
+			require.Equal(t, res.find(term.id) != -1, coeffs[term.id] != 0)
+		}
+	}
+}
+
+func TestSubstituteMerge(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	ids := make([]Symbol, 64)
+	for i := range ids {
+		ids[i] = next(External)
+	}
+
+	for i := 0; i < 1000; i++ {
+		// substitute small expressions into large ones half of the time, such that terms are binary
+		// searched for rather than merged
+
+		n, m := rng.Intn(len(ids))+1, rng.Intn(len(ids))
+		if i%2 == 0 {
+			n, m = len(ids)-rng.Intn(8), rng.Intn(4)
+		}
+
+		a := randomExpr(rng, ids, n)
+		id := a.terms[rng.Intn(len(a.terms))].id
+		b := randomExpr(rng, ids, m)
+		if idx := b.find(id); idx != -1 {
+			b.delete(idx)
+		}
+
+		coeff := a.terms[a.find(id)].coeff
+
+		coeffs := make(map[Symbol]float64)
+		for _, term := range a.terms {
+			if term.id != id {
+				coeffs[term.id] += term.coeff
+			}
+		}
+		for _, term := range b.terms {
+			coeffs[term.id] += coeff * term.coeff
+		}
+		expected := NewExprFromMap(a.constant+coeff*b.constant, coeffs)
+
+		res := a.clone()
+		res.substitute(id, b, len(b.terms), DefaultEpsilon)
+		require.Equal(t, expected, res)
+	}
+}
+
+func TestAddSymbolOrdered(t *testing.T) {
+	x, y, z := New(), New(), New()
+
+	var e Expr
+	e.addSymbol(1, z, DefaultEpsilon)
+	e.addSymbol(2, x, DefaultEpsilon)
+	e.addSymbol(3, y, DefaultEpsilon)
+	require.Equal(t, NewExpr(0, x.T(2), y.T(3), z.T(1)), e)
+	require.Equal(t, 1, e.find(y))
+
+	e.addSymbol(-3, y, DefaultEpsilon)
+	require.Equal(t, NewExpr(0, x.T(2), z.T(1)), e)
+	require.Equal(t, -1, e.find(y))
+
+	require.Equal(t, NewExpr(1, x.T(3), z.T(2)), NewExpr(1, z.T(2), x.T(1), y.T(0), x.T(2)).canonical())
+}
+
+func benchmarkAddExpr(b *testing.B, terms int) {
+	rng := rand.New(rand.NewSource(1))
+
+	ids := make([]Symbol, 2*terms)
+	for i := range ids {
+		ids[i] = next(External)
+	}
+
+	dst := randomExpr(rng, ids, terms)
+	src := randomExpr(rng, ids, terms)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		expr := dst.clone()
+		expr.addExpr(1, src, DefaultEpsilon)
+	}
+}
+
+func BenchmarkAddExpr4(b *testing.B)   { benchmarkAddExpr(b, 4) }
+func BenchmarkAddExpr16(b *testing.B)  { benchmarkAddExpr(b, 16) }
+func BenchmarkAddExpr64(b *testing.B)  { benchmarkAddExpr(b, 64) }
+func BenchmarkAddExpr256(b *testing.B) { benchmarkAddExpr(b, 256) }
@@ -0,0 +1,69 @@
+package casso
+
+import "unsafe"
+
+// MemoryStats approximates the number of bytes held by a solver, such that applications running on
+// small devices may budget how many constraints they can afford. Sizes are estimated from the
+// number of entries and the capacity of buffers held by the solver rather than measured from the
+// heap, and leave out the bookkeeping of the Go runtime. Maps are counted by the entries they hold
+// rather than by the room they retain for entries since removed, which the index of columns may
+// retain plenty of once the solver pivots its way through dense intermediate rows.
+type MemoryStats struct {
+	Rows  int // bytes held by the rows of the tableau and the index of their columns, but not their terms
+	Terms int // bytes held by the term buffers of rows, including spare buffers and the objective
+	Tags  int // bytes held by the tags of installed constraints, including the constraints as supplied
+	Edits int // bytes held by edit variables and stays
+}
+
+// Total returns the number of bytes held by a solver across all categories.
+func (m MemoryStats) Total() int {
+	return m.Rows + m.Terms + m.Tags + m.Edits
+}
+
+// mapBytes approximates the number of bytes held by a map of n entries of the given size, accounting
+// for the header of the map, and the control bytes and unused slots it keeps per entry.
+func mapBytes(n int, entry uintptr) int {
+	if n == 0 {
+		return 0
+	}
+	return 48 + n*(int(entry)*3/2+16)
+}
+
+// MemoryStats returns the approximate number of bytes held by the solver. Rows whose terms are
+// shared with a fork of the solver are counted by whichever solver owns them.
+func (s *Solver) MemoryStats() MemoryStats {
+	var (
+		symbolSize = unsafe.Sizeof(Symbol(0))
+		termSize   = int(unsafe.Sizeof(Term{}))
+	)
+
+	var m MemoryStats
+
+	m.Rows = cap(s.tabs.entries)*int(unsafe.Sizeof(tab{})) + cap(s.tabs.dense)*int(unsafe.Sizeof(int32(0)))
+	m.Rows += mapBytes(len(s.tabs.index), symbolSize+unsafe.Sizeof(0))
+	m.Rows += cap(s.tabs.sorted) * int(symbolSize)
+	m.Rows += mapBytes(len(s.cols), symbolSize+unsafe.Sizeof((*column)(nil)))
+	for _, tab := range s.tabs.entries {
+		if _, borrowed := s.borrowed[tab.basic]; !borrowed {
+			m.Terms += cap(tab.row.expr.terms) * termSize
+		}
+	}
+	for _, col := range s.cols {
+		m.Rows += int(unsafe.Sizeof(column{})) + cap(col.basics)*int(symbolSize)
+		m.Rows += mapBytes(len(col.set), symbolSize)
+	}
+
+	for _, buf := range s.spare {
+		m.Terms += cap(buf) * termSize
+	}
+	m.Terms += (cap(s.slab.chunk) + cap(s.scratch) + cap(s.objective.terms) + cap(s.artificial.terms)) * termSize
+
+	m.Tags = mapBytes(len(s.tags), symbolSize+unsafe.Sizeof(Tag{}))
+	for _, tag := range s.tags {
+		m.Tags += cap(tag.cell.expr.terms) * termSize
+	}
+
+	m.Edits = mapBytes(len(s.edits)+len(s.stays), symbolSize+unsafe.Sizeof(Edit{}))
+
+	return m
+}
@@ -0,0 +1,154 @@
+package casso
+
+import "sort"
+
+// Merge installs all constraints and edit variables of src into dst. See MergeMarkers.
+func Merge(dst, src *Solver) error {
+	_, err := MergeMarkers(dst, src)
+	return err
+}
+
+// MergeMarkers installs all constraints, edit variables, and stays of src into dst, and returns a
+// mapping of constraint markers in src to the markers of the constraints installed into dst.
+// External symbols created via the package-level New are shared between solvers, and thus refer to
+// the same variables in dst as they do in src. Variables created via Solver.New are only unique to
+// the solver that created them, such that each one of src is merged in as a fresh variable created
+// via dst.New, which the returned mapping also maps it to.
+//
+// Edit variables of src that are not yet registered in dst are registered with the same priority
+// and suggested value. Stays of src are installed into dst, preferring the values of their
+// variables in dst once all constraints are merged. Symbol data and names attached in src are copied
+// over to dst for symbols that do not yet have data or a name attached to them in dst.
+//
+// Should a constraint of src conflict with the constraints of dst, all constraints merged from src
+// are removed from dst and the error is returned. src is left unmodified.
+func MergeMarkers(dst, src *Solver) (map[Symbol]Symbol, error) {
+	return mergeMarkers(dst, src, nil)
+}
+
+// mergeMarkers merges the constraints of src whose markers are in keep into dst, or all constraints
+// of src should keep be nil. See MergeMarkers.
+func mergeMarkers(dst, src *Solver, keep map[Symbol]struct{}) (map[Symbol]Symbol, error) {
+	kept := func(marker Symbol) bool {
+		if keep == nil {
+			return true
+		}
+		_, ok := keep[marker]
+		return ok
+	}
+
+	markers := make(map[Symbol]Symbol, len(src.tags))
+	if dst == src {
+		for marker := range src.tags {
+			markers[marker] = marker
+		}
+		return markers, nil
+	}
+
+	vars := make(map[Symbol]Symbol) // variables created via src.New -> fresh variables of dst
+	variable := func(id Symbol) Symbol {
+		if !id.Local() || !id.External() {
+			return id
+		}
+		if _, ok := vars[id]; !ok {
+			vars[id] = dst.New()
+		}
+		return vars[id]
+	}
+
+	edits := make(map[Symbol]struct{}, len(src.edits)+len(src.stays)) // marker ids of edit and stay constraints
+	for _, edit := range src.edits {
+		edits[edit.tag.marker] = struct{}{}
+	}
+	for _, stay := range src.stays {
+		edits[stay.tag.marker] = struct{}{}
+	}
+
+	// markers are allocated with increasing ids regardless of their kind, such that constraints are
+	// merged in the order they were installed into src
+
+	order := make([]Symbol, 0, len(src.tags))
+	for marker := range src.tags {
+		if _, ok := edits[marker]; !ok && kept(marker) {
+			order = append(order, marker)
+		}
+	}
+	sort.Slice(order, func(i, j int) bool { return order[i].ID() < order[j].ID() })
+
+	for _, marker := range order {
+		tag := src.tags[marker]
+
+		terms := make([]Term, 0, len(tag.cell.expr.terms))
+		for _, term := range tag.cell.expr.terms {
+			terms = append(terms, variable(term.id).T(term.coeff))
+		}
+		cell := NewConstraint(tag.cell.op, tag.cell.expr.constant, terms...)
+
+		merged, err := dst.AddConstraintWithPriority(tag.priority, cell)
+		if err != nil {
+			for _, merged := range markers {
+				_ = dst.RemoveConstraint(merged)
+			}
+			return nil, err
+		}
+		markers[marker] = merged
+	}
+
+	ids := make([]Symbol, 0, len(src.edits))
+	for id, edit := range src.edits {
+		if kept(edit.tag.marker) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		edit := src.edits[id]
+		merged := variable(id)
+		if existing, exists := dst.edits[merged]; exists {
+			markers[edit.tag.marker] = existing.tag.marker
+			continue
+		}
+		if err := dst.Edit(merged, edit.tag.priority); err != nil {
+			return nil, err
+		}
+		if err := dst.Suggest(merged, edit.val); err != nil {
+			return nil, err
+		}
+		markers[edit.tag.marker] = dst.edits[merged].tag.marker
+	}
+
+	ids = ids[:0]
+	for id, stay := range src.stays {
+		if kept(stay.tag.marker) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		stay := src.stays[id]
+		merged := variable(id)
+		if err := dst.AddStay(merged, stay.tag.priority); err != nil {
+			return nil, err
+		}
+		markers[stay.tag.marker] = dst.stays[merged].tag.marker
+	}
+
+	for id, v := range src.data {
+		if _, exists := dst.data[variable(id)]; !exists {
+			dst.SetSymbolData(variable(id), v)
+		}
+	}
+	for id, name := range src.names {
+		if _, exists := dst.names[variable(id)]; !exists {
+			dst.SetName(variable(id), name)
+		}
+	}
+
+	for id, merged := range vars {
+		markers[id] = merged
+	}
+
+	return markers, nil
+}
@@ -0,0 +1,96 @@
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	header := casso.NewSolver()
+	body := casso.NewSolver()
+
+	top := casso.New()
+	split := casso.New()
+	bottom := casso.New()
+
+	_, err := header.AddConstraint(top.EQ(0))
+	require.NoError(t, err)
+	_, err = header.AddConstraint(casso.NewConstraint(casso.EQ, -40, split.T(1), top.T(-1)))
+	require.NoError(t, err)
+
+	_, err = body.AddConstraint(casso.NewConstraint(casso.GTE, -100, bottom.T(1), split.T(-1)))
+	require.NoError(t, err)
+	_, err = body.AddConstraintWithPriority(casso.Weak, bottom.EQ(0))
+	require.NoError(t, err)
+	require.NoError(t, body.Edit(bottom, casso.Strong))
+	require.NoError(t, body.Suggest(bottom, 500))
+	body.SetSymbolData(bottom, "bottom")
+
+	markers, err := casso.MergeMarkers(header, body)
+	require.NoError(t, err)
+	require.Len(t, markers, 3)
+
+	require.EqualValues(t, 0, header.Val(top))
+	require.EqualValues(t, 40, header.Val(split))
+	require.EqualValues(t, 500, header.Val(bottom))
+	require.Equal(t, "bottom", header.SymbolData(bottom))
+
+	require.NoError(t, header.Suggest(bottom, 120))
+	require.EqualValues(t, 140, header.Val(bottom))
+
+	// src is left untouched
+
+	require.EqualValues(t, 500, body.Val(bottom))
+}
+
+func TestMergeConflict(t *testing.T) {
+	dst := casso.NewSolver()
+	src := casso.NewSolver()
+
+	x := casso.New()
+	y := casso.New()
+
+	_, err := dst.AddConstraint(x.EQ(10))
+	require.NoError(t, err)
+
+	_, err = src.AddConstraint(y.EQ(5))
+	require.NoError(t, err)
+	_, err = src.AddConstraint(x.EQ(20))
+	require.NoError(t, err)
+
+	require.Error(t, casso.Merge(dst, src))
+	require.EqualValues(t, 10, dst.Val(x))
+}
+
+func TestMergeLocalVariables(t *testing.T) {
+	dst := casso.NewSolver()
+	src := casso.NewSolver()
+
+	// both solvers count their variables from the same id, such that x and y collide
+
+	x := dst.New()
+	y := src.New()
+	require.Equal(t, x.ID(), y.ID())
+
+	_, err := dst.AddConstraint(x.EQ(10))
+	require.NoError(t, err)
+	_, err = src.AddConstraint(y.EQ(20))
+	require.NoError(t, err)
+	require.NoError(t, src.Edit(y, casso.Weak))
+	src.SetName(y, "y")
+
+	mapping, err := casso.MergeMarkers(dst, src)
+	require.NoError(t, err)
+	require.Len(t, mapping, 3)
+
+	merged, ok := mapping[y]
+	require.True(t, ok)
+	require.NotEqual(t, x, merged)
+
+	require.EqualValues(t, 10, dst.Val(x))
+	require.EqualValues(t, 20, dst.Val(merged))
+	require.True(t, dst.HasEdit(merged))
+	require.Equal(t, "y", dst.Name(merged))
+	require.Empty(t, dst.Name(x))
+}
@@ -0,0 +1,26 @@
+package casso
+
+// NewNamed creates a new external variable, and names it within the solver. Names label the
+// variable wherever the solver renders constraints, such as in reports and in DumpTableau.
+func (s *Solver) NewNamed(name string) Symbol {
+	id := New()
+	s.SetName(id, name)
+	return id
+}
+
+// SetName names a symbol within the solver. Setting an empty name removes the name of the symbol.
+func (s *Solver) SetName(id Symbol, name string) {
+	if name == "" {
+		delete(s.names, id)
+		return
+	}
+	if s.names == nil {
+		s.names = make(map[Symbol]string)
+	}
+	s.names[id] = name
+}
+
+// Name returns the name of a symbol within the solver, or an empty string if it has none.
+func (s *Solver) Name(id Symbol) string {
+	return s.names[id]
+}
@@ -0,0 +1,31 @@
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNewNamed(t *testing.T) {
+	s := casso.NewSolver()
+
+	width := s.NewNamed("width")
+	height := casso.New()
+
+	require.Equal(t, "width", s.Name(width))
+	require.Equal(t, "", s.Name(height))
+
+	_, err := s.AddConstraint(casso.NewConstraint(casso.EQ, 0, width.T(1), height.T(-2)))
+	require.NoError(t, err)
+
+	requireReportContains(t, s, "width - 2 * "+height.String()+" = 0")
+	requireDumpContains(t, s, "width")
+
+	c := s.Clone()
+	s.SetName(width, "")
+	require.Equal(t, "", s.Name(width))
+	require.Equal(t, "width", c.Name(width))
+
+	c.Reset()
+	require.Equal(t, "", c.Name(width))
+}
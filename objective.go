@@ -0,0 +1,77 @@
+package casso
+
+// addObjective adds coeff to the coefficient of id in the objective. The objective grows by one or
+// two error symbols per constraint that is not required, and shrinks as they are removed. Terms
+// whose coefficients cancel out are zeroed rather than deleted, as deleting them would shift all
+// terms past them, and are swept away once they make up half of the terms of the objective.
+func (s *Solver) addObjective(coeff float64, id Symbol) {
+	idx := s.objective.find(id)
+	if idx == -1 {
+		s.objective.addSymbol(coeff, id, s.opts.epsilon)
+		return
+	}
+
+	term := &s.objective.terms[idx]
+	zeroed := term.coeff == 0
+	term.coeff += coeff
+
+	if !s.eqz(term.coeff) {
+		if zeroed {
+			s.zeroed--
+		}
+		return
+	}
+
+	if zeroed {
+		term.coeff = 0
+		return
+	}
+	s.zeroObjective(idx)
+}
+
+// addObjectiveExpr adds expr scaled by coeff to the objective. Should expr be small relative to the
+// objective, its terms are added one by one via addObjective.
+func (s *Solver) addObjectiveExpr(coeff float64, expr Expr) {
+	if len(expr.terms)*mergeRatio >= len(s.objective.terms) {
+		s.objective.addExpr(coeff, expr, s.opts.epsilon)
+		return
+	}
+	s.objective.constant += coeff * expr.constant
+	for _, term := range expr.terms {
+		s.addObjective(coeff*term.coeff, term.id)
+	}
+}
+
+// substituteObjective substitutes expr for id in the objective.
+func (s *Solver) substituteObjective(id Symbol, expr Expr) {
+	idx := s.objective.find(id)
+	if idx == -1 || s.objective.terms[idx].coeff == 0 {
+		return
+	}
+	coeff := s.objective.terms[idx].coeff
+	s.zeroObjective(idx)
+	s.addObjectiveExpr(coeff, expr)
+}
+
+// zeroObjective zeroes the coefficient of the term at idx in the objective, sweeping away zeroed
+// terms should they make up half of the terms of the objective.
+func (s *Solver) zeroObjective(idx int) {
+	s.objective.terms[idx].coeff = 0
+	s.zeroed++
+	if 2*s.zeroed > len(s.objective.terms) {
+		s.sweepObjective()
+	}
+}
+
+// sweepObjective deletes all terms of the objective whose coefficients were zeroed.
+func (s *Solver) sweepObjective() {
+	n := 0
+	for _, term := range s.objective.terms {
+		if term.coeff != 0 {
+			s.objective.terms[n] = term
+			n++
+		}
+	}
+	s.objective.terms = s.objective.terms[:n]
+	s.zeroed = 0
+}
@@ -0,0 +1,41 @@
+package casso
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestObjectiveZeroedTerms(t *testing.T) {
+	s := NewSolver()
+
+	vars := make([]Symbol, 64)
+	markers := make([]Symbol, len(vars))
+	for i := range vars {
+		vars[i] = New()
+
+		var err error
+		markers[i], err = s.AddConstraintWithPriority(Weak, vars[i].EQ(float64(i)))
+		require.NoError(t, err)
+	}
+	require.Len(t, s.objective.terms, 2*len(vars))
+
+	for i := range markers[:len(markers)-1] {
+		require.NoError(t, s.RemoveConstraint(markers[i]))
+
+		// zeroed terms are hidden, and make up at most half of the objective
+
+		for _, term := range s.Tableau().Objective().terms {
+			require.NotZero(t, term.coeff)
+		}
+		require.LessOrEqual(t, 2*s.zeroed, len(s.objective.terms))
+	}
+
+	last := vars[len(vars)-1]
+	require.EqualValues(t, len(vars)-1, s.Val(last))
+	require.Len(t, s.Tableau().Objective().terms, 2)
+
+	require.NoError(t, s.Edit(last, Strong))
+	require.NoError(t, s.Suggest(last, 10))
+	require.EqualValues(t, 10, s.Val(last))
+	require.InDelta(t, float64(len(vars)-11)*float64(Weak), s.ObjectiveValue(), 1e-6)
+}
@@ -0,0 +1,158 @@
+package casso
+
+// Option configures the behavior of a solver upon construction.
+type Option func(o *options)
+
+type options struct {
+	capacity int
+
+	autoEdit         bool
+	autoEditPriority Priority
+
+	pivot PivotRule
+
+	strict bool
+
+	driftEvery int
+	driftFn    func(drift Drift)
+
+	historyDepth int
+
+	manual bool
+	lazy   bool
+
+	deterministic bool
+
+	epsilon float64
+
+	maxIterations int
+
+	conflicts bool
+
+	trace func(trace Trace)
+}
+
+// Options configures a solver upon construction via NewSolverWithOptions. Zero values select the
+// defaults of a solver created using NewSolver.
+type Options struct {
+	// Epsilon is the tolerance below which values are treated as zero. See WithEpsilon.
+	Epsilon float64
+
+	// MaxIterations is the number of pivots after which the solver aborts optimizing. See
+	// WithMaxIterations.
+	MaxIterations int
+}
+
+func (o Options) options() []Option {
+	var opts []Option
+	if o.Epsilon > 0 {
+		opts = append(opts, WithEpsilon(o.Epsilon))
+	}
+	if o.MaxIterations > 0 {
+		opts = append(opts, WithMaxIterations(o.MaxIterations))
+	}
+	return opts
+}
+
+// WithCapacity hints the number of constraints expected to be installed into the solver, such that
+// its internal storage may be allocated upfront.
+func WithCapacity(constraints int) Option {
+	return func(o *options) { o.capacity = constraints }
+}
+
+// WithAutoEdit has Suggest register variables that are not yet registered as edit variables as
+// edit variables of the given priority, rather than returning ErrBadEditVariable.
+func WithAutoEdit(priority Priority) Option {
+	return func(o *options) { o.autoEdit, o.autoEditPriority = true, priority }
+}
+
+// WithPivotRule has the solver select the symbols entering and leaving the basis while optimizing
+// its objective using the given rule. By default, DefaultPivot is used.
+func WithPivotRule(rule PivotRule) Option {
+	return func(o *options) { o.pivot = rule }
+}
+
+// WithStrict has the solver panic with a descriptive message whenever it is misused, rather than
+// returning an error or silently ignoring the misuse. It is intended to be enabled during
+// development, as it keeps track of additional state to detect misuse.
+func WithStrict() Option {
+	return func(o *options) { o.strict = true }
+}
+
+// WithDriftAudit has the solver audit the floating-point drift of its tableau via AuditDrift once
+// every given number of calls to Suggest, and report the results to fn. Solvers created using
+// WithManualSolve instead count calls to Solve that apply suggested values.
+func WithDriftAudit(every int, fn func(drift Drift)) Option {
+	return func(o *options) { o.driftEvery, o.driftFn = every, fn }
+}
+
+// WithHistory has the solver record the last depth constraints added or removed, edit variables
+// registered or unregistered, and values suggested, such that they may be undone and redone via
+// Undo and Redo.
+func WithHistory(depth int) Option {
+	return func(o *options) { o.historyDepth = depth }
+}
+
+// WithManualSolve has the solver only record constraints added or removed, and values suggested,
+// until Solve is called, rather than optimizing after every operation.
+func WithManualSolve() Option {
+	return func(o *options) { o.manual = true }
+}
+
+// WithLazySolve has the solver only record constraints added or removed, and values suggested, as
+// with WithManualSolve, and solve itself once values are next read via Val or Vals. Applications
+// that modify constraints many times between reading values, such as once per frame, then skip
+// optimizing the solver after every modification without having to call Solve themselves.
+// Subscribers and observers are notified of changes once values are read. Should solving fail,
+// values are read as they stand, and the error is returned by the next call to Solve.
+func WithLazySolve() Option {
+	return func(o *options) { o.manual, o.lazy = true, true }
+}
+
+// WithDeterministic has the solver visit the rows of its tableau and its infeasible rows in order of
+// their symbols, such that ties between pivot candidates are broken the same way on every run. By
+// default, rows are visited in no particular order, which may lead the solver to settle on different
+// optimal solutions across runs should a system of constraints have more than one. Removing a
+// constraint picks the row leaving the basis the same way on every run regardless of this option.
+func WithDeterministic() Option {
+	return func(o *options) { o.deterministic = true }
+}
+
+// WithEpsilon has the solver treat values whose magnitude is below eps as zero when cancelling out
+// terms, selecting pivots, testing feasibility, and validating dummy variables. By default,
+// DefaultEpsilon is used. Poorly scaled systems may call for a looser tolerance, while systems
+// whose coefficients span many orders of magnitude may call for a tighter one.
+func WithEpsilon(eps float64) Option {
+	return func(o *options) { o.epsilon = eps }
+}
+
+// Epsilon returns the tolerance below which the solver treats values as zero. See WithEpsilon.
+func (s *Solver) Epsilon() float64 { return s.opts.epsilon }
+
+// WithMaxIterations has the solver abort optimizing its objective once it pivots max times within a
+// single operation, returning ErrMaxIterations, rather than pivoting for as long as it takes. A
+// degenerate system that has the solver cycle would otherwise hang the caller. Once aborted, the
+// tableau remains consistent, though not optimal: constraints installed and values suggested remain
+// so, and later operations resume optimizing from where the solver left off. By default, the
+// number of pivots is not limited.
+func WithMaxIterations(max int) Option {
+	return func(o *options) { o.maxIterations = max }
+}
+
+// WithConflicts has the solver report the installed constraints that a required constraint conflicts
+// with should it fail to be added for being unsatisfiable. The markers of an irreducible set of
+// required constraints the constraint is unsatisfiable alongside are reported via the Conflicts field
+// of the ConstraintError returned. Computing the set re-solves the required constraints related to
+// the constraint from scratch a number of times linear in their number, and is thus intended for
+// diagnosing layouts during development rather than for use in production.
+func WithConflicts() Option {
+	return func(o *options) { o.conflicts = true }
+}
+
+// WithTrace has the solver call fn with every pivot it makes, both while optimizing its objective and
+// while restoring feasibility once values are suggested or constants are updated. It is intended for
+// diagnosing slow or cycling layouts, such as by counting the pivots an operation takes or finding
+// the symbols a degenerate system pivots back and forth between. fn must not modify the solver.
+func WithTrace(fn func(trace Trace)) Option {
+	return func(o *options) { o.trace = fn }
+}
@@ -0,0 +1,182 @@
+package casso
+
+import "math"
+
+// PivotRule selects the symbols entering and leaving the basis while the solver optimizes an
+// objective using the primal simplex method.
+//
+// Coefficients are considered negative only should they be below the negated tolerance of the
+// solver, as returned by Tableau.Epsilon. Entering a symbol whose coefficient is negative only by
+// rounding error makes no progress, and pivoting on a row whose coefficient of the entering symbol
+// is negative only by rounding error blows up the coefficients of the tableau.
+type PivotRule interface {
+	// Entry selects a parametric, non-dummy symbol whose coefficient in the objective is negative to
+	// enter the basis. It returns the zero symbol if no such symbol exists, in which case the objective
+	// is optimal. The objective may hold terms whose coefficients are zero.
+	Entry(t Tableau, objective Expr) Symbol
+
+	// Exit selects the basic symbol of a row to leave the basis in favor of the entering symbol. The
+	// row must be the row of a restricted symbol whose coefficient of the entering symbol is negative,
+	// with the minimum ratio of its constant to that coefficient such that all restricted symbols
+	// remain non-negative. It returns the zero symbol if no such row exists, in which case the
+	// objective is unbounded and the solver gives up optimizing it, returning ErrUnbounded.
+	Exit(t Tableau, entry Symbol) Symbol
+}
+
+// DefaultPivot enters the first symbol in the objective that has a negative coefficient, and exits
+// the row that has a minimum ratio. Of the rows whose ratios differ only by less than the tolerance
+// of the solver, which are common as constraints are churned into degenerate tableaus, the row
+// whose coefficient of the entering symbol is largest in magnitude is exited, such that pivots
+// divide by as large a coefficient as possible and rounding errors are not amplified. Remaining
+// ties are broken in favor of the lowest symbol, such that the row exited does not depend on the
+// order rows are visited in.
+type DefaultPivot struct{}
+
+func (DefaultPivot) Entry(t Tableau, objective Expr) Symbol {
+	eps := t.Epsilon()
+	for _, term := range objective.terms {
+		if term.coeff < -eps && !term.id.Dummy() {
+			return term.id
+		}
+	}
+	return zero
+}
+
+func (DefaultPivot) Exit(t Tableau, entry Symbol) Symbol {
+	eps := t.Epsilon()
+
+	exit := zero
+	ratio := math.MaxFloat64
+	pivot := 0.0
+
+	t.Column(entry, func(row Row) bool {
+		if row.Basic.External() {
+			return true
+		}
+		idx := row.Expr.find(entry)
+		if idx == -1 {
+			return true
+		}
+		coeff := row.Expr.terms[idx].coeff
+		if coeff > -eps {
+			return true
+		}
+		if r := -row.Expr.constant / coeff; precedes(r, ratio, -coeff, -pivot, row.Basic, exit, eps) {
+			ratio, exit, pivot = r, row.Basic, coeff
+		}
+		return true
+	})
+
+	return exit
+}
+
+// BlandPivot implements Bland's rule: it enters the lowest symbol that has a negative coefficient
+// in the objective, and exits the lowest basic symbol amongst the rows that have a minimum ratio.
+// In exact arithmetic it never cycles, at the cost of typically taking more pivots to reach an
+// optimum. With floating-point coefficients, it offers no such guarantee: picking rows by symbol
+// rather than by the magnitude of their coefficients lets rounding errors grow on degenerate
+// tableaus, which may have it cycle or give up with ErrUnbounded. Use WithMaxIterations to bound
+// the number of pivots made should it be relied upon.
+type BlandPivot struct{}
+
+func (BlandPivot) Entry(t Tableau, objective Expr) Symbol {
+	eps := t.Epsilon()
+
+	entry := zero
+	for _, term := range objective.terms {
+		if term.id.Dummy() || term.coeff >= -eps {
+			continue
+		}
+		if entry.Zero() || term.id < entry {
+			entry = term.id
+		}
+	}
+	return entry
+}
+
+func (BlandPivot) Exit(t Tableau, entry Symbol) Symbol {
+	eps := t.Epsilon()
+
+	exit := zero
+	ratio := math.MaxFloat64
+
+	t.Column(entry, func(row Row) bool {
+		if row.Basic.External() {
+			return true
+		}
+		idx := row.Expr.find(entry)
+		if idx == -1 {
+			return true
+		}
+		coeff := row.Expr.terms[idx].coeff
+		if coeff > -eps {
+			return true
+		}
+		r := -row.Expr.constant / coeff
+		if r < ratio || (r == ratio && row.Basic < exit) {
+			ratio, exit = r, row.Basic
+		}
+		return true
+	})
+
+	return exit
+}
+
+// SteepestEdgePivot enters the symbol whose negative coefficient in the objective is largest in
+// magnitude relative to the norm of its column in the tableau, which typically reaches an optimum
+// in fewer pivots at the cost of more work per pivot. Rows are exited as they are with DefaultPivot.
+type SteepestEdgePivot struct{}
+
+func (SteepestEdgePivot) Entry(t Tableau, objective Expr) Symbol {
+	eps := t.Epsilon()
+
+	entry := zero
+	best := 0.0
+
+	for _, term := range objective.terms {
+		if term.id.Dummy() || term.coeff >= -eps {
+			continue
+		}
+
+		norm := 1.0
+		t.Column(term.id, func(row Row) bool {
+			if idx := row.Expr.find(term.id); idx != -1 {
+				norm += row.Expr.terms[idx].coeff * row.Expr.terms[idx].coeff
+			}
+			return true
+		})
+
+		score := term.coeff * term.coeff / norm
+		if score > best || (score == best && term.id < entry) {
+			entry, best = term.id, score
+		}
+	}
+
+	return entry
+}
+
+func (SteepestEdgePivot) Exit(t Tableau, entry Symbol) Symbol {
+	return DefaultPivot{}.Exit(t, entry)
+}
+
+// precedes reports whether a row whose ratio is r and whose coefficient of the entering symbol is
+// coeff in magnitude should leave the basis over the row of best, whose ratio is ratio and whose
+// coefficient is pivot in magnitude. Rows are ordered by their ratios rounded down to multiples of
+// eps, then by the magnitude of their coefficients, then by their symbols. Unlike treating ratios
+// within eps of one another as ties, this is a strict total order, such that the row picked is the
+// same regardless of the order rows are compared in.
+func precedes(r, ratio, coeff, pivot float64, symbol, best Symbol, eps float64) bool {
+	if best.Zero() {
+		return true
+	}
+	if eps > 0 {
+		r, ratio = math.Floor(r/eps), math.Floor(ratio/eps)
+	}
+	if r != ratio {
+		return r < ratio
+	}
+	if coeff != pivot {
+		return coeff > pivot
+	}
+	return symbol < best
+}
@@ -0,0 +1,133 @@
+package casso_test
+
+import (
+	"errors"
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+var pivotRules = []struct {
+	name string
+	rule casso.PivotRule
+}{
+	{"default", casso.DefaultPivot{}},
+	{"bland", casso.BlandPivot{}},
+	{"steepest edge", casso.SteepestEdgePivot{}},
+}
+
+func TestPivotRules(t *testing.T) {
+	for _, test := range pivotRules {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			s := casso.NewSolver(casso.WithPivotRule(test.rule))
+
+			containerWidth := casso.New()
+
+			childX := casso.New()
+			childCompWidth := casso.New()
+
+			child2X := casso.New()
+			child2CompWidth := casso.New()
+
+			c1 := casso.NewConstraint(casso.EQ, 0, childX.T(1.0), containerWidth.T(-50.0/1024))
+			c2 := casso.NewConstraint(casso.EQ, 0, childCompWidth.T(1.0), containerWidth.T(-200.0/1024))
+			c3 := casso.NewConstraint(casso.GTE, -200, childCompWidth.T(1.0))
+			c4 := casso.NewConstraint(casso.EQ, -50, child2X.T(1.0), childX.T(-1.0), childCompWidth.T(-1.0))
+			c5 := casso.NewConstraint(casso.EQ, 50, child2CompWidth.T(1.0), containerWidth.T(-1.0), child2X.T(1.0))
+
+			require.NoError(t, s.Edit(containerWidth, casso.Strong))
+			require.NoError(t, s.Suggest(containerWidth, 2048))
+
+			_, err := s.AddConstraint(c1)
+			require.NoError(t, err)
+
+			_, err = s.AddConstraintWithPriority(casso.Weak, c2)
+			require.NoError(t, err)
+
+			_, err = s.AddConstraintWithPriority(casso.Strong, c3)
+			require.NoError(t, err)
+
+			_, err = s.AddConstraint(c4)
+			require.NoError(t, err)
+
+			_, err = s.AddConstraint(c5)
+			require.NoError(t, err)
+
+			require.EqualValues(t, 2048, s.Val(containerWidth))
+			require.EqualValues(t, 400, s.Val(childCompWidth))
+			require.EqualValues(t, 1448, s.Val(child2CompWidth))
+
+			require.NoError(t, s.Suggest(containerWidth, 500))
+
+			require.EqualValues(t, 500, s.Val(containerWidth))
+			require.EqualValues(t, 200, s.Val(childCompWidth))
+			require.EqualValues(t, 175.5859375, s.Val(child2CompWidth))
+		})
+	}
+}
+
+func TestPivotRulesArtificialVariable(t *testing.T) {
+	for _, test := range pivotRules {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			s := casso.NewSolver(casso.WithPivotRule(test.rule))
+
+			p1 := casso.New()
+			p2 := casso.New()
+			p3 := casso.New()
+
+			container := casso.New()
+
+			require.NoError(t, s.Edit(container, casso.Strong))
+			require.NoError(t, s.Suggest(container, 100.0))
+
+			_, err := s.AddConstraintWithPriority(casso.Strong, casso.NewConstraint(casso.GTE, -30.0, p1.T(1.0)))
+			require.NoError(t, err)
+
+			_, err = s.AddConstraintWithPriority(casso.Medium, casso.NewConstraint(casso.EQ, 0, p1.T(1), p3.T(-1.0)))
+			require.NoError(t, err)
+
+			_, err = s.AddConstraint(casso.NewConstraint(casso.EQ, 0, p2.T(1.0), p1.T(-2.0)))
+			require.NoError(t, err)
+
+			_, err = s.AddConstraint(casso.NewConstraint(casso.EQ, 0.0, container.T(1.0), p1.T(-1.0), p2.T(-1.0), p3.T(-1.0)))
+			require.NoError(t, err)
+
+			require.EqualValues(t, 30, s.Val(p1))
+			require.EqualValues(t, 60, s.Val(p2))
+			require.EqualValues(t, 10, s.Val(p3))
+			require.EqualValues(t, 100, s.Val(container))
+		})
+	}
+}
+
+// unboundedPivot enters symbols as DefaultPivot does, but never finds a row to leave the basis, as
+// though the objective were unbounded.
+type unboundedPivot struct{ casso.DefaultPivot }
+
+func (unboundedPivot) Exit(t casso.Tableau, entry casso.Symbol) casso.Symbol { return casso.Symbol(0) }
+
+func TestPivotRuleUnbounded(t *testing.T) {
+	s := casso.NewSolver(casso.WithPivotRule(unboundedPivot{}))
+	x := s.New()
+
+	_, err := s.AddConstraint(x.GTE(10))
+	require.NoError(t, err)
+
+	before := s.Tableau().Rows()
+
+	marker, err := s.AddConstraintWithPriority(casso.Weak, x.EQ(20))
+	require.True(t, errors.Is(err, casso.ErrUnbounded))
+	require.Contains(t, err.Error(), "entering the basis")
+
+	// the solver gives up optimizing rather than pivoting in a bogus row, and uninstalls the
+	// constraint it could not optimize
+
+	require.False(t, s.HasConstraint(marker))
+	require.Len(t, s.Constraints(), 1)
+	require.Equal(t, before, s.Tableau().Rows())
+	require.EqualValues(t, 10, s.Val(x))
+
+	require.True(t, errors.Is(s.RemoveConstraint(marker), casso.ErrBadConstraintMarker))
+}
@@ -0,0 +1,86 @@
+package casso
+
+// maxSpare is the maximum number of term buffers a solver holds on to for reuse.
+const maxSpare = 256
+
+// spare is a free list of the term buffers of rows removed from the tableau, from which the rows of
+// constraints added later on are allocated. Solvers that repeatedly add and remove constraints, such
+// as those of user interfaces, thus allocate rows only until the free list is warmed up.
+type spare [][]Term
+
+// get returns an empty term buffer with room for at least n terms, or nil should no buffer be spare
+// that has room for n terms. Buffers too small for n terms are left on the list for rows that fit.
+func (p *spare) get(n int) []Term {
+	bufs := *p
+	for i := len(bufs) - 1; i >= 0; i-- {
+		buf := bufs[i]
+		if cap(buf) < n {
+			continue
+		}
+		last := len(bufs) - 1
+		bufs[i], bufs[last] = bufs[last], nil
+		*p = bufs[:last]
+		return buf[:0]
+	}
+	return nil
+}
+
+// put returns a term buffer no longer referenced by the solver for reuse.
+func (p *spare) put(buf []Term) {
+	if cap(buf) == 0 || len(*p) >= maxSpare {
+		return
+	}
+	*p = append(*p, buf[:0])
+}
+
+// minChunk and maxChunk bound the number of terms of the chunks a slab carves term buffers out of.
+const (
+	minChunk = 64
+	maxChunk = 16384
+)
+
+// slab carves term buffers out of chunks of contiguous memory, such that the rows of constraints
+// added one after another lie next to one another rather than in allocations of their own scattered
+// across the heap. Walking the rows of a column, as substitute does, then mostly touches memory that
+// is already cached. A chunk is reclaimed by the garbage collector once no row refers to it.
+type slab struct {
+	chunk []Term // uncarved remainder of the current chunk
+	size  int    // number of terms of the current chunk
+}
+
+// carve returns an empty term buffer with room for exactly n terms. Buffers too large to share a
+// chunk with others are allocated on their own.
+func (b *slab) carve(n int) []Term {
+	if n > cap(b.chunk) {
+		size := 2 * b.size
+		switch {
+		case size < minChunk:
+			size = minChunk
+		case size > maxChunk:
+			size = maxChunk
+		}
+		if n > size/4 {
+			return make([]Term, 0, n)
+		}
+		b.chunk, b.size = make([]Term, 0, size), size
+	}
+	buf := b.chunk[:0:n]
+	b.chunk = b.chunk[n:n]
+	return buf
+}
+
+// alloc returns an empty term buffer with room for at least n terms, reusing the buffer of a removed
+// row should one be spare, and carving one out of the slab of the solver otherwise.
+func (s *Solver) alloc(n int) []Term {
+	if buf := s.spare.get(n); buf != nil {
+		return buf
+	}
+	return s.slab.carve(n)
+}
+
+// discardRow removes the row of basic from the tableau, and returns its term buffer for reuse.
+func (s *Solver) discardRow(basic Symbol) {
+	if row, ok := s.removeRow(basic); ok {
+		s.spare.put(row.expr.terms)
+	}
+}
@@ -0,0 +1,130 @@
+package casso
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSpareRowsReused(t *testing.T) {
+	s := NewSolver()
+	x := New()
+	y := New()
+
+	_, err := s.AddConstraint(NewConstraint(EQ, -10, x.T(1)))
+	require.NoError(t, err)
+
+	marker, err := s.AddConstraint(NewConstraint(GTE, 0, y.T(1), x.T(-1)))
+	require.NoError(t, err)
+	require.NoError(t, s.RemoveConstraint(marker))
+	require.Len(t, s.spare, 1)
+
+	_, err = s.AddConstraint(NewConstraint(LTE, 0, y.T(1), x.T(-2)))
+	require.NoError(t, err)
+	require.Empty(t, s.spare)
+	require.EqualValues(t, 10, s.Val(x))
+
+	s.Reset()
+	require.Len(t, s.spare, 2)
+}
+
+func TestSpareGetKeepsSmallBuffers(t *testing.T) {
+	var p spare
+	p.put(make([]Term, 0, 4))
+	p.put(make([]Term, 0, 1))
+
+	// buffers too small are left on the list, and the one that fits is handed out
+
+	require.Nil(t, p.get(8))
+	require.Len(t, p, 2)
+
+	buf := p.get(2)
+	require.Equal(t, 4, cap(buf))
+	require.Len(t, p, 1)
+
+	buf = p.get(1)
+	require.Equal(t, 1, cap(buf))
+	require.Empty(t, p)
+}
+
+func TestSpareRowsSteadyState(t *testing.T) {
+	s := NewSolver()
+	x := New()
+	y := New()
+
+	_, err := s.AddConstraint(NewConstraint(EQ, -10, x.T(1)))
+	require.NoError(t, err)
+
+	cell := NewConstraint(EQ, 0, y.T(1), x.T(-2))
+	cycle := func() {
+		marker, err := s.AddConstraint(cell)
+		require.NoError(t, err)
+		require.NoError(t, s.RemoveConstraint(marker))
+	}
+	cycle()
+
+	with := testing.AllocsPerRun(100, cycle)
+	s.spare = nil
+	without := testing.AllocsPerRun(100, func() {
+		cycle()
+		s.spare = nil
+	})
+	require.Less(t, with, without)
+}
+
+func TestSpareColumnsReused(t *testing.T) {
+	s := NewSolver()
+	x := New()
+	y := New()
+
+	_, err := s.AddConstraint(NewConstraint(EQ, -10, x.T(1)))
+	require.NoError(t, err)
+
+	cell := NewConstraint(GTE, 0, y.T(1), x.T(-1))
+	marker, err := s.AddConstraint(cell)
+	require.NoError(t, err)
+	require.NoError(t, s.RemoveConstraint(marker))
+	require.NotEmpty(t, s.spareCols)
+	for _, col := range s.spareCols {
+		require.Zero(t, col.len())
+	}
+
+	n := len(s.spareCols)
+	_, err = s.AddConstraint(cell)
+	require.NoError(t, err)
+	require.Less(t, len(s.spareCols), n)
+
+	// columns shared with a fork are left for the fork rather than reused
+
+	f := s.Fork()
+	s.spareCols = nil
+	for id := range s.cols {
+		s.dropColumn(id)
+	}
+	require.Empty(t, s.spareCols)
+	require.NotEmpty(t, f.cols)
+	for _, col := range f.cols {
+		require.NotZero(t, col.len())
+	}
+}
+
+func TestSlabCarve(t *testing.T) {
+	var b slab
+
+	x := b.carve(3)
+	y := b.carve(5)
+	require.Equal(t, 3, cap(x))
+	require.Equal(t, 5, cap(y))
+	require.Equal(t, minChunk-8, cap(b.chunk))
+
+	// buffers carved out of the same chunk may not grow into one another
+
+	y = append(y, Term{coeff: 1})
+	x = append(x, Term{coeff: 2}, Term{coeff: 2}, Term{coeff: 2}, Term{coeff: 2})
+	require.Equal(t, []Term{{coeff: 1}}, y)
+
+	// buffers too large to share a chunk are allocated on their own
+
+	z := b.carve(minChunk)
+	require.Equal(t, minChunk, cap(z))
+	require.Equal(t, minChunk-8, cap(b.chunk))
+}
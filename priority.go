@@ -0,0 +1,81 @@
+package casso
+
+// SetPriority changes the priority of an installed constraint in place by reweighting its error
+// symbols in the objective, rather than removing and reinstalling the constraint. The priority of a
+// required constraint may not be changed, nor may a constraint be made required, as required
+// constraints have no error symbols.
+func (s *Solver) SetPriority(marker Symbol, priority Priority) error {
+	tag, exists := s.tags[marker]
+	if !exists {
+		return ErrBadConstraintMarker
+	}
+	if priority < 0 {
+		return ErrBadPriority
+	}
+	if tag.priority >= Required || priority >= Required {
+		return ErrBadPriorityChange
+	}
+	if priority == tag.priority {
+		return nil
+	}
+
+	if s.watched() {
+		defer s.notify()
+	}
+	if s.history != nil {
+		s.history.record(op{kind: opSetPriority, marker: marker, old: float64(tag.priority), new: float64(priority)})
+	}
+	if s.strict != nil {
+		s.strict.onUpdate(marker, priority, tag.cell)
+	}
+
+	delta := float64(priority - tag.priority)
+	for _, symbol := range [...]Symbol{tag.marker, tag.other} {
+		if !symbol.Error() {
+			continue
+		}
+		if row, exists := s.tabs.get(symbol); exists {
+			s.addObjectiveExpr(delta, row.expr)
+		} else {
+			s.addObjective(delta, symbol)
+		}
+	}
+
+	tag.priority = priority
+	s.tags[marker] = tag
+
+	// edit variables and stays keep copies of the tags of their constraints, which are installed as
+	// their variable being equal to zero
+
+	if len(tag.cell.expr.terms) == 1 {
+		id := tag.cell.expr.terms[0].id
+		if edit, ok := s.edits[id]; ok && edit.tag.marker == marker {
+			edit.tag.priority = priority
+			s.edits[id] = edit
+		} else if stay, ok := s.stays[id]; ok && stay.tag.marker == marker {
+			stay.tag.priority = priority
+			s.stays[id] = stay
+		}
+	}
+
+	if s.opts.manual {
+		s.dirty = true
+		return nil
+	}
+
+	return s.optimizeAgainst(&s.objective)
+}
+
+// SetEditPriority changes the priority of an edit variable in place, keeping the value suggested
+// for it, such as to have a variable being dragged take precedence over other variables until it
+// is released. See SetPriority.
+func (s *Solver) SetEditPriority(id Symbol, priority Priority) error {
+	edit, exists := s.edits[id]
+	if !exists {
+		return ErrBadEditVariable
+	}
+	if priority < 0 || priority >= Required {
+		return ErrBadPriority
+	}
+	return s.SetPriority(edit.tag.marker, priority)
+}
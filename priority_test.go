@@ -0,0 +1,135 @@
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSetPriority(t *testing.T) {
+	s := casso.NewSolver(casso.WithHistory(4), casso.WithStrict())
+
+	x := casso.New()
+
+	low, err := s.AddConstraintWithPriority(casso.Weak, x.EQ(10))
+	require.NoError(t, err)
+	_, err = s.AddConstraintWithPriority(casso.Medium, x.EQ(20))
+	require.NoError(t, err)
+	required, err := s.AddConstraint(x.GTE(0))
+	require.NoError(t, err)
+
+	require.EqualValues(t, 20, s.Val(x))
+
+	// promoting the weak constraint past the medium constraint has it win out
+
+	require.NoError(t, s.SetPriority(low, casso.Strong))
+	require.EqualValues(t, 10, s.Val(x))
+
+	require.NoError(t, s.Undo())
+	require.EqualValues(t, 20, s.Val(x))
+	require.NoError(t, s.Redo())
+	require.EqualValues(t, 10, s.Val(x))
+
+	// the new priority is reported, and the constraint may be removed as usual
+
+	for _, c := range s.Constraints() {
+		if c.Marker == low {
+			require.Equal(t, casso.Strong, c.Priority)
+		}
+	}
+	priority, ok := s.ConstraintPriority(low)
+	require.True(t, ok)
+	require.Equal(t, casso.Strong, priority)
+
+	require.NoError(t, s.RemoveConstraint(low))
+	require.EqualValues(t, 20, s.Val(x))
+
+	_, ok = s.ConstraintPriority(low)
+	require.False(t, ok)
+
+	require.Equal(t, casso.ErrBadConstraintMarker, s.SetPriority(low, casso.Weak))
+	require.Equal(t, casso.ErrBadPriorityChange, s.SetPriority(required, casso.Weak))
+	require.Equal(t, casso.ErrBadPriority, s.SetPriority(required, -1))
+}
+
+func TestSetPriorityOfEdit(t *testing.T) {
+	s := casso.NewSolver()
+
+	x := casso.New()
+
+	_, err := s.AddConstraintWithPriority(casso.Medium, x.EQ(20))
+	require.NoError(t, err)
+
+	require.NoError(t, s.Edit(x, casso.Weak))
+	require.NoError(t, s.Suggest(x, 50))
+	require.EqualValues(t, 20, s.Val(x))
+
+	var marker casso.Symbol
+	for _, c := range s.Constraints() {
+		if c.Edit {
+			marker = c.Marker
+		}
+	}
+
+	require.NoError(t, s.SetPriority(marker, casso.Strong))
+	require.EqualValues(t, 50, s.Val(x))
+
+	require.NoError(t, s.Suggest(x, 70))
+	require.EqualValues(t, 70, s.Val(x))
+}
+
+func TestSetPriorityOfStay(t *testing.T) {
+	s := casso.NewSolver()
+
+	x := casso.New()
+
+	require.NoError(t, s.AddStay(x, casso.Weak))
+
+	var marker casso.Symbol
+	for _, c := range s.Constraints() {
+		if c.Stay {
+			marker = c.Marker
+		}
+	}
+	require.NoError(t, s.SetPriority(marker, casso.Strong))
+
+	// the stay is merged at the priority it was changed to
+
+	dst := casso.NewSolver()
+	require.NoError(t, casso.Merge(dst, s))
+
+	_, err := dst.AddConstraintWithPriority(casso.Medium, x.EQ(20))
+	require.NoError(t, err)
+	require.EqualValues(t, 0, dst.Val(x))
+}
+
+func TestSetEditPriority(t *testing.T) {
+	s := casso.NewSolver(casso.WithStrict())
+
+	x := casso.New()
+
+	_, err := s.AddConstraintWithPriority(casso.Medium, x.EQ(10))
+	require.NoError(t, err)
+	require.NoError(t, s.Edit(x, casso.Weak))
+	require.NoError(t, s.Suggest(x, 50))
+	require.EqualValues(t, 10, s.Val(x))
+
+	// dragging the variable has its suggested value win out, and releasing it reverts it
+
+	require.NoError(t, s.SetEditPriority(x, casso.Strong))
+	require.EqualValues(t, 50, s.Val(x))
+	require.NoError(t, s.Edit(x, casso.Strong))
+
+	priority, ok := s.EditPriority(x)
+	require.True(t, ok)
+	require.Equal(t, casso.Strong, priority)
+
+	require.NoError(t, s.SetEditPriority(x, casso.Weak))
+	require.EqualValues(t, 10, s.Val(x))
+
+	require.Equal(t, casso.ErrBadPriority, s.SetEditPriority(x, casso.Required))
+	require.Equal(t, casso.ErrBadEditVariable, s.SetEditPriority(casso.New(), casso.Strong))
+
+	_, ok = s.EditPriority(casso.New())
+	require.False(t, ok)
+}
@@ -0,0 +1,48 @@
+package casso
+
+import "sort"
+
+// ReleaseVariable removes every installed constraint referencing a variable, unregisters it as an
+// edit variable, removes its stay, and drops its row from the tableau, such that the solver no
+// longer holds any state for it. Named expressions whose defining constraint is removed become
+// undefined. It is to be called once whatever the variable describes is destroyed, such as a
+// widget. Should any constraint fail to be removed, the rest are still removed, and the first error
+// encountered is returned.
+func (s *Solver) ReleaseVariable(id Symbol) error {
+	if s.watched() {
+		defer s.notify()
+	}
+
+	var first error
+	if _, exists := s.edits[id]; exists {
+		first = s.RemoveEdit(id)
+	}
+	if _, exists := s.stays[id]; exists {
+		if err := s.RemoveStay(id); err != nil && first == nil {
+			first = err
+		}
+	}
+
+	var markers []Symbol
+	for marker, tag := range s.tags {
+		if !s.internalMarker(marker) && tag.cell.expr.contains(id) {
+			markers = append(markers, marker)
+		}
+	}
+	sort.Slice(markers, func(i, j int) bool { return markers[i] < markers[j] })
+
+	if err := s.RemoveConstraints(markers...); err != nil && first == nil {
+		first = err
+	}
+
+	for name, def := range s.defs {
+		if !s.HasConstraint(def.marker) {
+			delete(s.defs, name)
+		}
+	}
+
+	s.discardRow(id)
+	delete(s.pending, id)
+
+	return first
+}
@@ -0,0 +1,43 @@
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestReleaseVariable(t *testing.T) {
+	s := casso.NewSolver()
+
+	left := casso.New()
+	width := casso.New()
+	right := casso.New()
+	other := casso.New()
+
+	a, err := s.AddConstraint(casso.NewConstraint(casso.EQ, 0, right.T(1), left.T(-1), width.T(-1)))
+	require.NoError(t, err)
+	b, err := s.AddConstraint(width.GTE(10))
+	require.NoError(t, err)
+	c, err := s.AddConstraint(other.EQ(5))
+	require.NoError(t, err)
+	require.NoError(t, s.Edit(width, casso.Strong))
+	require.NoError(t, s.Suggest(width, 100))
+	require.NoError(t, s.AddStay(width, casso.Weak))
+
+	require.NoError(t, s.ReleaseVariable(width))
+
+	require.False(t, s.HasConstraint(a))
+	require.False(t, s.HasConstraint(b))
+	require.True(t, s.HasConstraint(c))
+	require.False(t, s.HasEdit(width))
+	require.Equal(t, casso.ErrBadStay, s.RemoveStay(width))
+	require.EqualValues(t, 0, s.Val(width))
+	require.EqualValues(t, 5, s.Val(other))
+
+	for _, row := range s.Tableau().Rows() {
+		require.NotEqual(t, width, row.Basic)
+		for _, term := range row.Expr.Terms() {
+			require.NotEqual(t, width, term.Symbol())
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package casso
+
+// BeginRemoval has constraints removed via RemoveConstraint or RemoveConstraints, and by anything
+// built on top of them such as RemoveEdit, RemoveStay, and RemoveGroup, no longer optimize the
+// objective of the solver until EndRemoval is called, such that a whole subtree of constraints may
+// be torn down with the solver optimized once at the end rather than after every removal. Values
+// read in between may not reflect the removals. Calls to BeginRemoval may be nested, in which case
+// the solver is optimized once the outermost call is matched by EndRemoval.
+func (s *Solver) BeginRemoval() {
+	s.removing++
+}
+
+// EndRemoval matches a call to BeginRemoval, and optimizes the objective of the solver should it
+// match the outermost call. Subscribers and observers are notified of the changes caused by the
+// removals once the solver is optimized. Solvers created using WithManualSolve are left to be
+// optimized once Solve is called.
+func (s *Solver) EndRemoval() error {
+	if s.removing == 0 {
+		if s.strict != nil {
+			s.strict.misuse("EndRemoval(): not matched by a call to BeginRemoval")
+		}
+		return nil
+	}
+	s.removing--
+	if s.removing > 0 || s.opts.manual {
+		return nil
+	}
+	if s.watched() {
+		defer s.notify()
+	}
+	return s.optimizeAgainst(&s.objective)
+}
@@ -0,0 +1,45 @@
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestBeginEndRemoval(t *testing.T) {
+	s := casso.NewSolver()
+
+	ch := make(chan []casso.Change, 8)
+	s.Subscribe(ch)
+
+	x := casso.New()
+
+	_, err := s.AddConstraintWithPriority(casso.Weak, x.EQ(10))
+	require.NoError(t, err)
+	a, err := s.AddConstraintWithPriority(casso.Medium, x.EQ(20))
+	require.NoError(t, err)
+	b, err := s.AddConstraintWithPriority(casso.Strong, x.EQ(30))
+	require.NoError(t, err)
+	require.EqualValues(t, 30, s.Val(x))
+	require.Len(t, ch, 3)
+
+	// removals only take effect once the outermost call to BeginRemoval is matched
+
+	s.BeginRemoval()
+	s.BeginRemoval()
+	require.NoError(t, s.RemoveConstraint(b))
+	require.NoError(t, s.EndRemoval())
+	require.NoError(t, s.RemoveConstraints(a))
+	require.Len(t, ch, 3)
+	require.NoError(t, s.EndRemoval())
+
+	require.EqualValues(t, 10, s.Val(x))
+	require.Len(t, ch, 4)
+
+	// unmatched calls to EndRemoval do nothing
+
+	require.NoError(t, s.EndRemoval())
+	_, err = s.AddConstraintWithPriority(casso.Medium, x.EQ(20))
+	require.NoError(t, err)
+	require.EqualValues(t, 20, s.Val(x))
+}
@@ -0,0 +1,169 @@
+//go:build !tinygo && !casso_tiny
+// +build !tinygo,!casso_tiny
+
+package casso
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// ConstraintState describes how an installed constraint relates to the current solution.
+type ConstraintState uint8
+
+const (
+	// Binding constraints hold with equality, and thus determine the values of their variables.
+	Binding ConstraintState = iota
+	// Overridden constraints are violated in favor of constraints of higher priority.
+	Overridden
+	// Satisfied constraints are inequalities that hold with room to spare.
+	Satisfied
+)
+
+var ConstraintStateTable = [...]string{
+	Binding:    "binding",
+	Overridden: "overridden",
+	Satisfied:  "satisfied",
+}
+
+func (c ConstraintState) String() string { return ConstraintStateTable[c] }
+
+// ReportFormat is the format a report is rendered in.
+type ReportFormat uint8
+
+const (
+	ReportText ReportFormat = iota
+	ReportMarkdown
+)
+
+// BoundConstraint is an installed constraint that references a reported variable.
+type BoundConstraint struct {
+	Marker     Symbol
+	Priority   Priority
+	Constraint Constraint
+	State      ConstraintState
+	Edit       bool // whether the constraint is the suggested value of an edit variable
+}
+
+// VariableReport lists the installed constraints referencing an external variable.
+type VariableReport struct {
+	Variable    Symbol
+	Value       float64
+	Constraints []BoundConstraint
+}
+
+// Report lists every external variable referenced by an installed constraint or registered as an
+// edit variable, ordered by symbol, alongside the constraints referencing it. Constraints are
+// ordered such that binding constraints come first, followed by overridden constraints, then by
+// stronger priorities.
+func (s *Solver) Report() []VariableReport {
+	edits := make(map[Symbol]Symbol, len(s.edits)) // marker id -> variable id
+	for id, edit := range s.edits {
+		edits[edit.tag.marker] = id
+	}
+	stays := make(map[Symbol]Symbol, len(s.stays)) // marker id -> variable id
+	for id, stay := range s.stays {
+		stays[stay.tag.marker] = id
+	}
+
+	reports := make(map[Symbol]*VariableReport)
+
+	for marker, tag := range s.tags {
+		bound := BoundConstraint{Marker: marker, Priority: tag.priority, Constraint: tag.cell}
+		if id, ok := edits[marker]; ok {
+			bound.Constraint = id.EQ(s.edits[id].val)
+			bound.Edit = true
+		}
+		if id, ok := stays[marker]; ok {
+			bound.Constraint = id.EQ(s.stays[id].val)
+		}
+		bound.State = s.state(bound.Constraint)
+
+		for _, term := range bound.Constraint.expr.terms {
+			if !term.id.External() {
+				continue
+			}
+			report, exists := reports[term.id]
+			if !exists {
+				report = &VariableReport{Variable: term.id, Value: s.Val(term.id)}
+				reports[term.id] = report
+			}
+			report.Constraints = append(report.Constraints, bound)
+		}
+	}
+
+	res := make([]VariableReport, 0, len(reports))
+	for _, report := range reports {
+		sort.Slice(report.Constraints, func(i, j int) bool {
+			a, b := report.Constraints[i], report.Constraints[j]
+			if a.State != b.State {
+				return a.State < b.State
+			}
+			if a.Priority != b.Priority {
+				return a.Priority > b.Priority
+			}
+			return a.Marker < b.Marker
+		})
+		res = append(res, *report)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Variable < res[j].Variable })
+
+	return res
+}
+
+// WriteReport renders the report of the solver in the given format. Variables are labeled by the
+// user data associated with them if the data is a string or a fmt.Stringer.
+func (s *Solver) WriteReport(w io.Writer, format ReportFormat) error {
+	bw := bufio.NewWriter(w)
+
+	for i, report := range s.Report() {
+		heading := s.label(report.Variable) + " = " + strconv.FormatFloat(report.Value, 'g', -1, 64)
+
+		switch format {
+		case ReportMarkdown:
+			if i > 0 {
+				fmt.Fprintln(bw)
+			}
+			fmt.Fprintf(bw, "### %s\n\n", heading)
+			fmt.Fprintln(bw, "| State | Priority | Constraint |")
+			fmt.Fprintln(bw, "| --- | --- | --- |")
+			for _, c := range report.Constraints {
+				fmt.Fprintf(bw, "| %s | %s | `%s`%s |\n", c.State, c.Priority, s.format(c.Constraint), reportEditSuffix(c))
+			}
+		default:
+			fmt.Fprintln(bw, heading)
+			for _, c := range report.Constraints {
+				fmt.Fprintf(bw, "  %-10s  %-8s  %s%s\n", c.State, c.Priority, s.format(c.Constraint), reportEditSuffix(c))
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+func reportEditSuffix(c BoundConstraint) string {
+	if c.Edit {
+		return " (suggested)"
+	}
+	return ""
+}
+
+// state evaluates a constraint against the current solution.
+func (s *Solver) state(c Constraint) ConstraintState {
+	val := s.eval(c.expr)
+
+	if c.op == LTE {
+		val = -val
+	}
+
+	switch {
+	case s.eqz(val):
+		return Binding
+	case c.op == EQ || val < 0:
+		return Overridden
+	}
+	return Satisfied
+}
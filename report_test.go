@@ -0,0 +1,69 @@
+//go:build !tinygo && !casso_tiny
+// +build !tinygo,!casso_tiny
+
+package casso_test
+
+import (
+	"bytes"
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestReport(t *testing.T) {
+	s := casso.NewSolver()
+
+	width := casso.New()
+	half := casso.New()
+
+	s.SetSymbolData(width, "width")
+	s.SetSymbolData(half, "half")
+
+	require.NoError(t, s.Edit(width, casso.Strong))
+	require.NoError(t, s.Suggest(width, 300))
+
+	binding, err := s.AddConstraint(casso.NewConstraint(casso.EQ, 0, half.T(2), width.T(-1)))
+	require.NoError(t, err)
+
+	overridden, err := s.AddConstraintWithPriority(casso.Weak, half.EQ(100))
+	require.NoError(t, err)
+
+	satisfied, err := s.AddConstraint(half.GTE(10))
+	require.NoError(t, err)
+
+	reports := s.Report()
+	require.Len(t, reports, 2)
+
+	require.EqualValues(t, width, reports[0].Variable)
+	require.EqualValues(t, 300, reports[0].Value)
+	require.Len(t, reports[0].Constraints, 2)
+	require.EqualValues(t, binding, reports[0].Constraints[0].Marker)
+	require.True(t, reports[0].Constraints[1].Edit)
+	require.EqualValues(t, casso.Binding, reports[0].Constraints[1].State)
+
+	require.EqualValues(t, half, reports[1].Variable)
+	require.EqualValues(t, 150, reports[1].Value)
+	require.Len(t, reports[1].Constraints, 3)
+	require.EqualValues(t, binding, reports[1].Constraints[0].Marker)
+	require.EqualValues(t, casso.Binding, reports[1].Constraints[0].State)
+	require.EqualValues(t, overridden, reports[1].Constraints[1].Marker)
+	require.EqualValues(t, casso.Overridden, reports[1].Constraints[1].State)
+	require.EqualValues(t, satisfied, reports[1].Constraints[2].Marker)
+	require.EqualValues(t, casso.Satisfied, reports[1].Constraints[2].State)
+
+	var buf bytes.Buffer
+	require.NoError(t, s.WriteReport(&buf, casso.ReportText))
+	require.Equal(t, `width = 300
+  binding     required  2 * half - width = 0
+  binding     strong    width - 300 = 0 (suggested)
+half = 150
+  binding     required  2 * half - width = 0
+  overridden  weak      half - 100 = 0
+  satisfied   required  half - 10 >= 0
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, s.WriteReport(&buf, casso.ReportMarkdown))
+	require.Contains(t, buf.String(), "### half = 150\n\n| State | Priority | Constraint |\n| --- | --- | --- |\n")
+	require.Contains(t, buf.String(), "| overridden | weak | `half - 100 = 0` |\n")
+}
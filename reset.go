@@ -0,0 +1,69 @@
+package casso
+
+// Reset removes all constraints, edit variables, stays, hints, groups, named expressions, symbol
+// data and names, and history from the solver, such that it may be reused as though it were newly
+// created with the same options. Storage allocated by the solver is retained. Subscribers and
+// observers remain registered, and are notified of the changes in value of all variables caused by
+// the reset. Removals begun via BeginRemoval are ended without optimizing the solver. See
+// ReleaseSolver for reusing solvers across goroutines.
+func (s *Solver) Reset() {
+	for _, tab := range s.tabs.entries {
+		if _, borrowed := s.borrowed[tab.basic]; !borrowed {
+			s.spare.put(tab.row.expr.terms)
+		}
+	}
+	s.tabs.clear()
+	s.borrowed, s.borrowedCols = nil, nil
+	for symbol := range s.cols {
+		delete(s.cols, symbol)
+	}
+	for symbol := range s.edits {
+		delete(s.edits, symbol)
+	}
+	for symbol := range s.stays {
+		delete(s.stays, symbol)
+	}
+	for symbol := range s.tags {
+		delete(s.tags, symbol)
+	}
+	for symbol := range s.data {
+		delete(s.data, symbol)
+	}
+	for symbol := range s.names {
+		delete(s.names, symbol)
+	}
+	for name := range s.defs {
+		delete(s.defs, name)
+	}
+	for g := range s.groups {
+		delete(s.groups, g)
+	}
+	for id := range s.pending {
+		delete(s.pending, id)
+	}
+	s.dirty = false
+	s.removing = 0
+	for id := range s.hints {
+		delete(s.hints, id)
+	}
+
+	s.byValue, s.partition = nil, nil
+	s.infeasible = s.infeasible[:0]
+	for symbol := range s.queued {
+		delete(s.queued, symbol)
+	}
+
+	s.objective.constant, s.objective.terms = 0, s.objective.terms[:0]
+	s.zeroed = 0
+	s.artificial.constant, s.artificial.terms = 0, s.artificial.terms[:0]
+
+	if s.strict != nil {
+		s.strict = newStrict(s)
+	}
+	if s.history != nil {
+		s.history.undo, s.history.redo = s.history.undo[:0], s.history.redo[:0]
+	}
+	s.suggested = 0
+
+	s.publish()
+}
@@ -0,0 +1,87 @@
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestReset(t *testing.T) {
+	s := casso.NewSolver(casso.WithHistory(8))
+
+	x := casso.New()
+	y := casso.New()
+
+	build := func() {
+		_, err := s.AddConstraint(casso.NewConstraint(casso.EQ, -10, y.T(1), x.T(-1)))
+		require.NoError(t, err)
+		_, err = s.AddConstraintWithPriority(casso.Weak, x.GTE(100))
+		require.NoError(t, err)
+		require.NoError(t, s.Edit(x, casso.Strong))
+		require.NoError(t, s.Suggest(x, 50))
+		s.SetSymbolData(x, "x")
+	}
+
+	build()
+	require.EqualValues(t, 60, s.Val(y))
+
+	ch := make(chan []casso.Change, 1)
+	s.Subscribe(ch)
+
+	s.Reset()
+
+	require.Zero(t, s.Tableau().Len())
+	require.EqualValues(t, 0, s.Val(x))
+	require.EqualValues(t, 0, s.Val(y))
+	require.Nil(t, s.SymbolData(x))
+	require.Equal(t, casso.ErrBadEditVariable, s.Suggest(x, 10))
+	require.Equal(t, casso.ErrNothingToUndo, s.Undo())
+	require.Len(t, s.Tableau().Objective().Terms(), 0)
+	require.Len(t, <-ch, 2)
+
+	// the solver may be reused as though it were newly created
+
+	s.Unsubscribe(ch)
+
+	build()
+	require.EqualValues(t, 50, s.Val(x))
+	require.EqualValues(t, 60, s.Val(y))
+}
+
+func TestResetDuringRemoval(t *testing.T) {
+	s := casso.NewSolver()
+
+	x := casso.New()
+	marker, err := s.AddConstraint(x.EQ(10))
+	require.NoError(t, err)
+
+	s.BeginRemoval()
+	require.NoError(t, s.RemoveConstraint(marker))
+	s.Reset()
+
+	// the solver is notified of and optimizes changes once again
+
+	ch := make(chan []casso.Change, 1)
+	s.Subscribe(ch)
+
+	_, err = s.AddConstraint(x.EQ(20))
+	require.NoError(t, err)
+	require.EqualValues(t, 20, s.Val(x))
+	require.Len(t, ch, 1)
+}
+
+func BenchmarkReset(b *testing.B) {
+	s := casso.NewSolver()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		l := casso.New()
+		m := casso.New()
+		r := casso.New()
+		_, _ = s.AddConstraint(casso.NewConstraint(casso.EQ, 0, l.T(1), r.T(1), m.T(-2)))
+		_, _ = s.AddConstraint(casso.NewConstraint(casso.GTE, -10, r.T(1), l.T(-1)))
+		s.Reset()
+	}
+}
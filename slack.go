@@ -0,0 +1,37 @@
+package casso
+
+import "math"
+
+// Slack reports by how much an installed constraint holds given the current values of its
+// variables. Inequalities report their headroom, being how far their expression may move before
+// they become binding. Negative values report by how much a constraint that is not required is
+// violated, which for equalities is the distance between both of their sides. Edit variables and
+// stays are reported against the value suggested or preferred for their variable.
+func (s *Solver) Slack(marker Symbol) (float64, error) {
+	tag, exists := s.tags[marker]
+	if !exists {
+		return 0, ErrBadConstraintMarker
+	}
+
+	cell := tag.cell
+	if len(cell.expr.terms) == 1 {
+		id := cell.expr.terms[0].id
+		if edit, ok := s.edits[id]; ok && edit.tag.marker == marker {
+			cell = id.EQ(edit.val)
+		} else if stay, ok := s.stays[id]; ok && stay.tag.marker == marker {
+			cell = id.EQ(stay.val)
+		}
+	}
+
+	val := s.eval(cell.expr)
+	switch cell.op {
+	case GTE:
+		return val, nil
+	case LTE:
+		return -val, nil
+	}
+	if s.eqz(val) {
+		return 0, nil
+	}
+	return -math.Abs(val), nil
+}
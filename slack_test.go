@@ -0,0 +1,57 @@
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSlack(t *testing.T) {
+	s := casso.NewSolver()
+
+	left := casso.New()
+	right := casso.New()
+
+	min, err := s.AddConstraint(casso.NewConstraint(casso.GTE, -100, left.T(1)))
+	require.NoError(t, err)
+	max, err := s.AddConstraint(casso.NewConstraint(casso.LTE, -400, left.T(1)))
+	require.NoError(t, err)
+	split, err := s.AddConstraintWithPriority(casso.Weak, casso.NewConstraint(casso.EQ, 0, left.T(1), right.T(-1)))
+	require.NoError(t, err)
+	require.NoError(t, s.Edit(left, casso.Strong))
+	require.NoError(t, s.Suggest(left, 150))
+	require.NoError(t, s.Edit(right, casso.Medium))
+	require.NoError(t, s.Suggest(right, 250))
+
+	slack, err := s.Slack(min)
+	require.NoError(t, err)
+	require.EqualValues(t, 50, slack)
+
+	slack, err = s.Slack(max)
+	require.NoError(t, err)
+	require.EqualValues(t, 250, slack)
+
+	slack, err = s.Slack(split)
+	require.NoError(t, err)
+	require.EqualValues(t, -100, slack)
+
+	require.NoError(t, s.Suggest(left, 500))
+
+	slack, err = s.Slack(max)
+	require.NoError(t, err)
+	require.EqualValues(t, 0, slack)
+
+	// left is suggested to be 500, but is capped at 400
+
+	for _, c := range s.Constraints() {
+		if !c.Edit || c.Priority != casso.Strong {
+			continue
+		}
+		slack, err = s.Slack(c.Marker)
+		require.NoError(t, err)
+		require.EqualValues(t, -100, slack)
+	}
+
+	_, err = s.Slack(casso.New())
+	require.Equal(t, casso.ErrBadConstraintMarker, err)
+}
@@ -0,0 +1,59 @@
+package casso
+
+import "sort"
+
+// ValueSnapshot is an immutable copy of the values of the external variables of a solver, taken at
+// one point in time. Unlike the solver it was taken from, a snapshot may be read from any number of
+// goroutines while the solver continues to be modified, such that a render goroutine may read the
+// values of the last frame laid out while a layout goroutine lays out the next one.
+type ValueSnapshot struct {
+	ids  []Symbol  // external variable ids, ordered
+	vals []float64 // values of the variables of ids
+}
+
+// Snapshot captures the values of all external variables referenced by installed constraints, as
+// returned by Vals, into a ValueSnapshot.
+func (s *Solver) Snapshot() ValueSnapshot {
+	vals := s.Vals()
+
+	snap := ValueSnapshot{ids: make([]Symbol, 0, len(vals)), vals: make([]float64, len(vals))}
+	for id := range vals {
+		snap.ids = append(snap.ids, id)
+	}
+	sort.Slice(snap.ids, func(i, j int) bool { return snap.ids[i] < snap.ids[j] })
+	for i, id := range snap.ids {
+		snap.vals[i] = vals[id]
+	}
+
+	return snap
+}
+
+// Val returns the value id had when the snapshot was taken, or zero should id not have been
+// referenced by any constraint of the solver at the time.
+func (v ValueSnapshot) Val(id Symbol) float64 {
+	val, _ := v.Lookup(id)
+	return val
+}
+
+// Lookup returns the value id had when the snapshot was taken, and reports whether id was referenced
+// by any constraint of the solver at the time.
+func (v ValueSnapshot) Lookup(id Symbol) (float64, bool) {
+	i := sort.Search(len(v.ids), func(i int) bool { return v.ids[i] >= id })
+	if i == len(v.ids) || v.ids[i] != id {
+		return 0, false
+	}
+	return v.vals[i], true
+}
+
+// Len returns the number of variables captured by the snapshot.
+func (v ValueSnapshot) Len() int { return len(v.ids) }
+
+// Range calls fn for every variable captured by the snapshot, ordered by symbol, until fn returns
+// false.
+func (v ValueSnapshot) Range(fn func(id Symbol, val float64) bool) {
+	for i, id := range v.ids {
+		if !fn(id, v.vals[i]) {
+			return
+		}
+	}
+}
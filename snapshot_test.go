@@ -0,0 +1,61 @@
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+)
+
+func TestSnapshot(t *testing.T) {
+	s := casso.NewSolver()
+
+	x := casso.New()
+	y := casso.New()
+	z := casso.New()
+
+	_, err := s.AddConstraint(casso.NewConstraint(casso.EQ, -10, y.T(1), x.T(-1)))
+	require.NoError(t, err)
+	require.NoError(t, s.Edit(x, casso.Strong))
+	require.NoError(t, s.Suggest(x, 20))
+
+	snap := s.Snapshot()
+	require.Equal(t, 2, snap.Len())
+	require.EqualValues(t, 20, snap.Val(x))
+	require.EqualValues(t, 30, snap.Val(y))
+
+	_, ok := snap.Lookup(z)
+	require.False(t, ok)
+	require.EqualValues(t, 0, snap.Val(z))
+
+	vals := make(map[casso.Symbol]float64)
+	snap.Range(func(id casso.Symbol, val float64) bool {
+		vals[id] = val
+		return true
+	})
+	require.Equal(t, s.Vals(), vals)
+
+	// snapshots may be read while the solver they were taken from is modified
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	diffs := make([]float64, 100)
+	go func() {
+		defer wg.Done()
+		for i := range diffs {
+			diffs[i] = snap.Val(y) - snap.Val(x)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		require.NoError(t, s.Suggest(x, float64(i)))
+	}
+	wg.Wait()
+
+	for _, diff := range diffs {
+		require.EqualValues(t, 10, diff)
+	}
+
+	require.EqualValues(t, 20, snap.Val(x))
+	require.EqualValues(t, 99, s.Val(x))
+}
@@ -0,0 +1,147 @@
+package casso
+
+import "sort"
+
+// Solve optimizes a solver created using WithManualSolve after constraints were added or removed,
+// and applies all values suggested since Solve was last called, such that the solver is optimized
+// once rather than after every operation. Until Solve is called, values returned by Val may not
+// reflect the operations made to the solver. Solvers created using WithLazySolve call Solve once
+// values are next read. Solve does nothing for other solvers, as they are optimized after every
+// operation.
+func (s *Solver) Solve() error {
+	if !s.opts.manual {
+		return nil
+	}
+
+	if err := s.optimizeAgainst(&s.objective); err != nil {
+		return err
+	}
+
+	ids := make([]Symbol, 0, len(s.pending))
+	for id := range s.pending {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		if edit, ok := s.edits[id]; ok {
+			s.edits[id] = s.suggest(edit, s.pending[id])
+		}
+		delete(s.pending, id)
+	}
+
+	if err := s.optimizeDualObjective(); err != nil {
+		return err
+	}
+
+	s.dirty = false
+
+	if len(ids) > 0 && s.opts.driftEvery > 0 && s.opts.driftFn != nil {
+		s.auditDrift()
+	}
+
+	s.publish()
+
+	return nil
+}
+
+// SolvePartial solves a solver created using WithManualSolve as Solve does, but only the portion of
+// its tableau reachable from the given variables: the rows referencing them, the rows referencing
+// the symbols of those rows, and so on, which hold the variables related to them through the
+// constraints installed. Rows of unrelated portions are not pivoted, such as those laying out other
+// panels than the one being queried, and values suggested for edit variables outside of the portion
+// remain pending until Solve is called. SolvePartial does nothing for other solvers, as they are
+// optimized after every operation.
+func (s *Solver) SolvePartial(ids ...Symbol) error {
+	if !s.opts.manual {
+		return nil
+	}
+
+	reach := s.reachable(ids)
+
+	if err := s.optimizeWithin(&s.objective, reach); err != nil {
+		return err
+	}
+
+	suggested := make([]Symbol, 0, len(s.pending))
+	for id := range s.pending {
+		if _, ok := reach[id]; ok {
+			suggested = append(suggested, id)
+		}
+	}
+	sort.Slice(suggested, func(i, j int) bool { return suggested[i] < suggested[j] })
+
+	for _, id := range suggested {
+		if edit, ok := s.edits[id]; ok {
+			s.edits[id] = s.suggest(edit, s.pending[id])
+		}
+		delete(s.pending, id)
+	}
+
+	// leave infeasible rows outside of the portion queued for Solve to optimize away
+
+	var rest []Symbol
+	infeasible := make([]Symbol, 0, len(s.infeasible))
+	for _, symbol := range s.infeasible {
+		if _, ok := reach[symbol]; ok {
+			infeasible = append(infeasible, symbol)
+		} else {
+			rest = append(rest, symbol)
+		}
+	}
+	s.infeasible = infeasible
+
+	err := s.optimizeDualObjective()
+	s.infeasible = append(s.infeasible, rest...)
+	if err != nil {
+		return err
+	}
+
+	s.publish()
+
+	return nil
+}
+
+// reachable returns the symbols reachable from ids through the rows of the tableau: ids, the basic
+// symbols of the rows referencing them, the symbols those rows reference, and so on.
+func (s *Solver) reachable(ids []Symbol) map[Symbol]struct{} {
+	reach := make(map[Symbol]struct{}, len(ids))
+
+	var queue []Symbol
+	visit := func(id Symbol) {
+		if _, ok := reach[id]; ok {
+			return
+		}
+		reach[id] = struct{}{}
+		queue = append(queue, id)
+	}
+
+	for _, id := range ids {
+		visit(id)
+	}
+	for len(queue) > 0 {
+		id := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+
+		if row, ok := s.tabs.get(id); ok {
+			for _, term := range row.expr.terms {
+				visit(term.id)
+			}
+		}
+		for _, basic := range s.cols[id].symbols() {
+			visit(basic)
+		}
+	}
+
+	return reach
+}
+
+// pend records a value suggested for an edit variable of a solver created using WithManualSolve, to
+// be applied once Solve is called.
+func (s *Solver) pend(id Symbol, val float64) {
+	if s.pending == nil {
+		s.pending = make(map[Symbol]float64)
+	}
+	s.pending[id] = val
+	s.dirty = true
+}
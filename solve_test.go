@@ -0,0 +1,186 @@
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestManualSolve(t *testing.T) {
+	build := func(s *casso.Solver, l, m, r casso.Symbol) {
+		_, err := s.AddConstraint(casso.NewConstraint(casso.EQ, 0, r.T(1), l.T(1), m.T(-2)))
+		require.NoError(t, err)
+		_, err = s.AddConstraint(casso.NewConstraint(casso.GTE, -100, r.T(1), l.T(-1)))
+		require.NoError(t, err)
+		_, err = s.AddConstraint(l.GTE(0))
+		require.NoError(t, err)
+		_, err = s.AddConstraintWithPriority(casso.Weak, r.LTE(500))
+		require.NoError(t, err)
+		require.NoError(t, s.Edit(l, casso.Strong))
+		require.NoError(t, s.Edit(m, casso.Medium))
+		require.NoError(t, s.Suggest(l, 40))
+		require.NoError(t, s.SuggestAll(map[casso.Symbol]float64{l: 100, m: 300}))
+	}
+
+	l, m, r := casso.New(), casso.New(), casso.New()
+
+	auto := casso.NewSolver()
+	build(auto, l, m, r)
+
+	manual := casso.NewSolver(casso.WithManualSolve())
+
+	ch := make(chan []casso.Change, 4)
+	manual.Subscribe(ch)
+
+	build(manual, l, m, r)
+	require.Len(t, ch, 0)
+
+	val, ok := manual.Suggested(l)
+	require.True(t, ok)
+	require.EqualValues(t, 100, val)
+
+	require.NoError(t, manual.Solve())
+	require.Len(t, ch, 1)
+
+	for _, id := range []casso.Symbol{l, m, r} {
+		require.InDelta(t, auto.Val(id), manual.Val(id), 1e-9)
+	}
+	require.EqualValues(t, 100, manual.Val(l))
+	require.EqualValues(t, 300, manual.Val(m))
+	require.EqualValues(t, 500, manual.Val(r))
+
+	// suggestions only take effect once solved
+
+	require.NoError(t, manual.Suggest(m, 150))
+	require.EqualValues(t, 300, manual.Val(m))
+	require.NoError(t, manual.Solve())
+	require.EqualValues(t, 150, manual.Val(m))
+	require.EqualValues(t, 200, manual.Val(r))
+
+	// solving without any changes does nothing
+
+	require.NoError(t, manual.Solve())
+	require.NoError(t, auto.Solve())
+}
+
+func TestLazySolve(t *testing.T) {
+	s := casso.NewSolver(casso.WithLazySolve())
+
+	ch := make(chan []casso.Change, 4)
+	s.Subscribe(ch)
+
+	l, r := casso.New(), casso.New()
+
+	_, err := s.AddConstraint(casso.NewConstraint(casso.GTE, -100, r.T(1), l.T(-1)))
+	require.NoError(t, err)
+	_, err = s.AddConstraintWithPriority(casso.Weak, r.LTE(500))
+	require.NoError(t, err)
+	require.NoError(t, s.Edit(l, casso.Strong))
+	require.NoError(t, s.Suggest(l, 40))
+	require.NoError(t, s.Suggest(l, 100))
+	require.Len(t, ch, 0)
+
+	// reading values solves the solver
+
+	require.EqualValues(t, 100, s.Val(l))
+	require.EqualValues(t, 500, s.Val(r))
+	require.Len(t, ch, 1)
+
+	require.NoError(t, s.Suggest(l, 450))
+	require.EqualValues(t, map[casso.Symbol]float64{l: 450, r: 550}, s.Vals())
+	require.Len(t, ch, 2)
+
+	// reading values again without any changes does not solve the solver
+
+	require.EqualValues(t, 450, s.Val(l))
+	require.Len(t, ch, 2)
+
+	// expressions registered for editing start out at their value once the solver is solved
+
+	require.NoError(t, s.Suggest(l, 0))
+	h, err := s.EditExpr(l.Expr(), casso.Medium)
+	require.NoError(t, err)
+	val, ok := s.Suggested(h.Symbol())
+	require.True(t, ok)
+	require.EqualValues(t, 0, val)
+}
+
+func TestSolvePartial(t *testing.T) {
+	s := casso.NewSolver(casso.WithManualSolve())
+
+	// lay out two unrelated panels, each holding a box that spans from the left of the panel to an
+	// edge being dragged
+
+	type panel struct{ left, right, edge casso.Symbol }
+
+	panels := [2]panel{}
+	for i := range panels {
+		p := panel{left: casso.New(), right: casso.New(), edge: casso.New()}
+		_, err := s.AddConstraint(p.left.EQ(float64(100 * i)))
+		require.NoError(t, err)
+		_, err = s.AddConstraint(casso.NewConstraint(casso.GTE, -10, p.right.T(1), p.left.T(-1)))
+		require.NoError(t, err)
+		_, err = s.AddConstraintWithPriority(casso.Medium, casso.NewConstraint(casso.EQ, 0, p.right.T(1), p.edge.T(-1)))
+		require.NoError(t, err)
+		require.NoError(t, s.Edit(p.edge, casso.Strong))
+		panels[i] = p
+	}
+	require.NoError(t, s.Solve())
+
+	a, b := panels[0], panels[1]
+
+	require.NoError(t, s.Suggest(a.edge, 50))
+	require.NoError(t, s.Suggest(b.edge, 150))
+
+	// rows laying out the other panel are left untouched, and its suggestion remains pending
+
+	var rows []casso.Row
+	for _, row := range s.Tableau().Rows() {
+		for _, term := range append(row.Expr.Terms(), row.Basic.T(1)) {
+			if id := term.Symbol(); id == b.left || id == b.right || id == b.edge {
+				rows = append(rows, row)
+				break
+			}
+		}
+	}
+	require.NotEmpty(t, rows)
+
+	require.NoError(t, s.SolvePartial(a.right))
+	require.EqualValues(t, 50, s.Val(a.right))
+	require.EqualValues(t, 110, s.Val(b.right))
+
+	for _, row := range rows {
+		other, ok := s.Tableau().Row(row.Basic)
+		require.True(t, ok)
+		require.Equal(t, row, other)
+	}
+
+	val, ok := s.Suggested(b.edge)
+	require.True(t, ok)
+	require.EqualValues(t, 150, val)
+
+	require.NoError(t, s.Solve())
+	require.EqualValues(t, 50, s.Val(a.right))
+	require.EqualValues(t, 150, s.Val(b.right))
+}
+
+func benchmarkChain(b *testing.B, opts ...casso.Option) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		s := casso.NewSolver(opts...)
+		prev := casso.New()
+		_, _ = s.AddConstraint(prev.EQ(0))
+		for j := 0; j < 100; j++ {
+			next := casso.New()
+			_, _ = s.AddConstraint(casso.NewConstraint(casso.GTE, -10, next.T(1), prev.T(-1)))
+			_, _ = s.AddConstraintWithPriority(casso.Weak, casso.NewConstraint(casso.EQ, -20, next.T(1), prev.T(-1)))
+			prev = next
+		}
+		_ = s.Solve()
+	}
+}
+
+func BenchmarkChainAutoSolve(b *testing.B)   { benchmarkChain(b) }
+func BenchmarkChainManualSolve(b *testing.B) { benchmarkChain(b, casso.WithManualSolve()) }
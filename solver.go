@@ -2,13 +2,16 @@ package casso
 
 import (
 	"errors"
+	"fmt"
 	"math"
+	"sort"
 )
 
 type Tag struct {
 	priority Priority
 	marker   Symbol
 	other    Symbol
+	cell     Constraint // constraint as originally supplied
 }
 
 type Edit struct {
@@ -16,60 +19,293 @@ type Edit struct {
 	val float64
 }
 
+// Solver keeps the rows of its tableau densely in a slice, and the terms of rows as slices ordered
+// by symbol. Symbols created by the solver are counted densely, such that the rows of their symbols
+// are located by indexing a slice by their id rather than through a map; see tabs. Symbols rather
+// than positions in the slice are handed out to users, as rows move within it as they are removed.
 type Solver struct {
-	tabs  map[Symbol]Constraint // symbol id -> constraint
-	edits map[Symbol]Edit       // variable id -> value
-	tags  map[Symbol]Tag        // marker id -> tag
+	tabs  tabs               // rows of the tableau, by basic symbol
+	cols  map[Symbol]*column // parametric symbol id -> basic symbols of rows referencing it
+	edits map[Symbol]Edit    // variable id -> value
+	stays map[Symbol]Edit    // variable id -> value preferred by stay
+	tags  map[Symbol]Tag     // marker id -> tag
 
-	infeasible []Symbol
+	infeasible []Symbol            // rows queued to be optimized away by optimizeDualObjective
+	queued     map[Symbol]struct{} // symbols of the rows in infeasible
 
 	objective  Expr
 	artificial Expr
+
+	data  map[Symbol]interface{} // symbol id -> user data
+	names map[Symbol]string      // symbol id -> name
+	defs  map[string]definition  // name -> named expression
+
+	byValue   map[string][]Symbol // normalized constraint key -> markers, indexed upon first use
+	partition *partition          // connected components of installed constraints, partitioned upon first use
+
+	groups    map[Group]*group
+	lastGroup Group
+
+	pending map[Symbol]float64 // edit variable id -> value suggested since the last call to Solve
+	dirty   bool               // whether the solver was modified since the last call to Solve
+
+	removing int                 // depth of calls to BeginRemoval not yet matched by EndRemoval
+	hints    map[Symbol]struct{} // variable ids whose stays were installed by Hint
+
+	subs      []chan<- []Change
+	observers map[Symbol][]*observer // variable id -> observers
+	values    map[Symbol]float64     // external variable id -> value last sent to subscribers and observers
+
+	fetched map[Symbol]float64 // external variable id -> value last returned by FetchChanges
+
+	opts      options
+	pooled    bool // whether the solver was acquired via AcquireSolver
+	strict    *strict
+	history   *history
+	suggested int // number of suggestions made, counted for drift audits
+
+	count     uint64    // number of symbols created by the solver
+	zeroed    int       // upper bound on the number of terms of the objective whose coefficients are zeroed
+	spare     spare     // term buffers of removed rows, reused by rows added later on
+	spareCols []*column // emptied columns, reused by symbols indexed later on
+	slab      slab      // chunks of contiguous memory the term buffers of rows are carved out of
+	scratch   []Term    // terms rows are built in by addConstraint before being installed
+	negative  []Symbol  // basic symbols of rows made infeasible by substitute, collected before being queued
+
+	referenced []bool   // whether the row being substituted into referenced each symbol substituted in
+	ranged     []Symbol // basic symbols of the rows of a column being ranged over via Tableau.Column
+
+	borrowed     map[Symbol]struct{} // basic symbols of rows whose terms are shared with a fork
+	borrowedCols map[Symbol]struct{} // parametric symbols whose columns are shared with a fork
 }
 
-func NewSolver() *Solver {
-	return &Solver{
-		tabs:  make(map[Symbol]Constraint),
+// minInfeasible is the number of infeasible rows solvers are allocated room for upfront, such that
+// suggesting values for edit variables does not allocate.
+const minInfeasible = 8
+
+// minScratch is the number of terms solvers are allocated room for upfront to build rows in, which
+// suffices for most constraints of layouts.
+const minScratch = 8
+
+func NewSolver(opts ...Option) *Solver {
+	o := options{pivot: DefaultPivot{}, epsilon: DefaultEpsilon}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return newSolver(o)
+}
+
+func newSolver(o options) *Solver {
+	s := &Solver{
+		tabs:  newTabs(o.capacity),
+		cols:  make(map[Symbol]*column, o.capacity),
 		edits: make(map[Symbol]Edit),
-		tags:  make(map[Symbol]Tag),
+		tags:  make(map[Symbol]Tag, o.capacity),
+		opts:  o,
+
+		infeasible: make([]Symbol, 0, minInfeasible),
+		scratch:    make([]Term, 0, minScratch),
+	}
+	if o.strict {
+		s.strict = newStrict(s)
+	}
+	if o.historyDepth > 0 {
+		s.history = &history{depth: o.historyDepth}
 	}
+	return s
 }
 
+// NewSolverWithOptions creates a solver configured by o, along with any further options given.
+func NewSolverWithOptions(o Options, opts ...Option) *Solver {
+	return NewSolver(append(o.options(), opts...)...)
+}
+
+// Val returns the value of id. Values of external variables are cached as they are read, and are
+// served from the cache for as long as the constants of their rows do not change, such that render
+// loops reading the same values frame after frame do not look them up in the tableau. See Changed.
 func (s *Solver) Val(id Symbol) float64 {
-	row, ok := s.tabs[id]
+	if s.dirty && s.opts.lazy {
+		_ = s.Solve()
+	}
+	if s.strict != nil {
+		s.strict.checkVal(id)
+	}
+	return s.tabs.read(id)
+}
+
+// val returns the value of id, being the constant of its row should id be basic, and zero otherwise.
+func (s *Solver) val(id Symbol) float64 {
+	row, ok := s.tabs.get(id)
 	if !ok {
 		return 0
 	}
 	return row.expr.constant
 }
 
+// Vals returns the values of all external variables referenced by installed constraints, including
+// those of edit variables and stays. Variables that are parametric in the tableau have a value of
+// zero.
+func (s *Solver) Vals() map[Symbol]float64 {
+	if s.dirty && s.opts.lazy {
+		_ = s.Solve()
+	}
+	vals := make(map[Symbol]float64, len(s.tags))
+	for _, tag := range s.tags {
+		for _, term := range tag.cell.expr.terms {
+			if term.id.External() {
+				vals[term.id] = 0
+			}
+		}
+	}
+	for _, tab := range s.tabs.entries {
+		if tab.basic.External() {
+			vals[tab.basic] = tab.row.expr.constant
+		}
+	}
+	return vals
+}
+
+// ObjectiveValue returns the value of the objective the solver minimizes: the sum of the errors of
+// all constraints that are not required, each weighted by the priority of its constraint. It is
+// zero should all constraints be satisfied, and grows the more constraints are violated, and the
+// stronger and more severely violated they are.
+//
+// The value is summed from the error symbols of constraints rather than read off the constant of
+// the objective, as suggesting values for edit variables shifts the constants of rows but not of
+// the objective.
+func (s *Solver) ObjectiveValue() float64 {
+	sum := 0.0
+	for _, tag := range s.tags {
+		for _, symbol := range [...]Symbol{tag.marker, tag.other} {
+			if !symbol.Error() {
+				continue
+			}
+			if row, exists := s.tabs.get(symbol); exists {
+				sum += float64(tag.priority) * row.expr.constant
+			}
+		}
+	}
+	return sum
+}
+
+// ErrorMagnitude returns the sum of the errors of all constraints that are not required, without
+// weighing them by priority. Errors are expressed in the units of the variables of their
+// constraints, such that it is the total distance by which constraints are violated.
+func (s *Solver) ErrorMagnitude() float64 {
+	sum := 0.0
+	for _, tag := range s.tags {
+		for _, symbol := range [...]Symbol{tag.marker, tag.other} {
+			if !symbol.Error() {
+				continue
+			}
+			if row, exists := s.tabs.get(symbol); exists {
+				sum += row.expr.constant
+			}
+		}
+	}
+	return sum
+}
+
+// SetSymbolData associates arbitrary user data with a symbol, such as the widget or model object a
+// variable describes. Setting nil data removes any data associated with the symbol.
+func (s *Solver) SetSymbolData(id Symbol, v interface{}) {
+	if v == nil {
+		delete(s.data, id)
+		return
+	}
+	if s.data == nil {
+		s.data = make(map[Symbol]interface{})
+	}
+	s.data[id] = v
+}
+
+// SymbolData returns the user data associated with a symbol, or nil if there is none.
+func (s *Solver) SymbolData(id Symbol) interface{} {
+	return s.data[id]
+}
+
 func (s *Solver) AddConstraint(cell Constraint) (Symbol, error) {
 	return s.AddConstraintWithPriority(Required, cell)
 }
 
+// AddConstraintWithPriority installs a constraint at the given priority and optimizes the objective
+// of the solver, returning the marker of the constraint. Should the objective be left unbounded, the
+// constraint is uninstalled and ErrUnbounded is returned. Should the solver give up optimizing after
+// WithMaxIterations pivots instead, the constraint remains installed and ErrMaxIterations is
+// returned.
 func (s *Solver) AddConstraintWithPriority(priority Priority, cell Constraint) (Symbol, error) {
-	tag := Tag{priority: priority}
+	if s.strict != nil {
+		s.strict.checkAdd(priority, cell)
+	}
+	if s.watched() {
+		defer s.notify()
+	}
+	marker, err := s.addConstraint(priority, cell)
+	if err != nil {
+		_ = s.optimize() // pivots made trying to install the constraint may have left the objective unoptimized
+		err = s.constraintError(marker, priority, cell, err)
+		if s.opts.conflicts && priority >= Required && (errors.Is(err, ErrUnsatisfiable) || errors.Is(err, ErrBadDummyVariable)) {
+			err.(*ConstraintError).Conflicts = s.conflicts(cell)
+		}
+		return marker, err
+	}
+	err = s.optimize()
+	if errors.Is(err, ErrUnbounded) {
+		tag := s.tags[marker]
+		if s.partition != nil {
+			s.partition.leave(marker)
+		}
+		delete(s.tags, marker)
+		s.uninstall(tag)
+		_ = s.optimize() // the error of the constraint is reported over any error optimizing without it
+		return marker, s.constraintError(marker, priority, cell, err)
+	}
+	if s.strict != nil {
+		s.strict.onAdd(priority, cell, marker)
+	}
+	if s.history != nil {
+		s.history.record(op{kind: opAdd, marker: marker, priority: priority, cell: cell.clone()})
+	}
+	if s.byValue != nil {
+		s.onAddValue(marker, cell)
+	}
+	return marker, s.constraintError(marker, priority, cell, err)
+}
+
+// addConstraint installs a constraint into the tableau without optimizing the objective of the
+// solver, such that callers may finish registering the constraint before optimizing it via optimize.
+func (s *Solver) addConstraint(priority Priority, cell Constraint) (Symbol, error) {
+	return s.install(Tag{priority: priority, cell: cell.clone()})
+}
+
+// install installs the constraint of tag into the tableau without optimizing the objective of the
+// solver. The marker and error symbols of tag are allocated unless tag already carries them, as it
+// does when a constraint is reinstalled in place.
+func (s *Solver) install(tag Tag) (Symbol, error) {
+	cell, priority := tag.cell, tag.priority
+
+	// build the row in scratch, which is only copied into a buffer of its own once installed
 
 	c := cell
-	c.expr.terms = make([]Term, 0, len(c.expr.terms))
+	c.expr.terms = s.scratch[:0]
 
 	// 1. filter away terms with coefficients that are zero
 	// 2. check that all variables in the constraint are registered
 	// 3. replace variables with their values if they have values assigned to them
 
 	for _, term := range cell.expr.terms {
-		if eqz(term.coeff) {
+		if s.eqz(term.coeff) {
 			continue
 		}
 		if term.id.Zero() {
 			return zero, ErrBadTermInConstraint
 		}
-		resolved, exists := s.tabs[term.id]
+		resolved, exists := s.tabs.get(term.id)
 		if !exists {
-			c.expr.addSymbol(term.coeff, term.id)
+			c.expr.addSymbol(term.coeff, term.id, s.opts.epsilon)
 			continue
 		}
-		c.expr.addExpr(term.coeff, resolved.expr)
+		c.expr.addExpr(term.coeff, resolved.expr, s.opts.epsilon)
 	}
 
 	// convert constraint to augmented simplex form
@@ -81,27 +317,27 @@ func (s *Solver) AddConstraintWithPriority(priority Priority, cell Constraint) (
 			coeff = -1.0
 		}
 
-		tag.marker = next(Slack)
-		c.expr.addSymbol(coeff, tag.marker)
+		tag.marker = s.reuse(tag.marker, Slack)
+		c.expr.addSymbol(coeff, tag.marker, s.opts.epsilon)
 
 		if priority < Required {
-			tag.other = next(Error)
-			c.expr.addSymbol(-coeff, tag.other)
-			s.objective.addSymbol(float64(priority), tag.other)
+			tag.other = s.reuse(tag.other, Error)
+			c.expr.addSymbol(-coeff, tag.other, s.opts.epsilon)
+			s.addObjective(float64(priority), tag.other)
 		}
 	case EQ:
 		if priority < Required {
-			tag.marker = next(Error)
-			tag.other = next(Error)
+			tag.marker = s.reuse(tag.marker, Error)
+			tag.other = s.reuse(tag.other, Error)
 
-			c.expr.addSymbol(-1.0, tag.marker)
-			c.expr.addSymbol(1.0, tag.other)
+			c.expr.addSymbol(-1.0, tag.marker, s.opts.epsilon)
+			c.expr.addSymbol(1.0, tag.other, s.opts.epsilon)
 
-			s.objective.addSymbol(float64(priority), tag.marker)
-			s.objective.addSymbol(float64(priority), tag.other)
+			s.addObjective(float64(priority), tag.marker)
+			s.addObjective(float64(priority), tag.other)
 		} else {
-			tag.marker = next(Dummy)
-			c.expr.addSymbol(1.0, tag.marker)
+			tag.marker = s.reuse(tag.marker, Dummy)
+			c.expr.addSymbol(1.0, tag.marker, s.opts.epsilon)
 		}
 	}
 
@@ -109,6 +345,8 @@ func (s *Solver) AddConstraintWithPriority(priority Priority, cell Constraint) (
 		c.expr.negate()
 	}
 
+	s.scratch = c.expr.terms[:0]
+
 	// find a subject variable to pivot on
 
 	subject, err := s.findSubject(c, tag)
@@ -117,8 +355,9 @@ func (s *Solver) AddConstraintWithPriority(priority Priority, cell Constraint) (
 	}
 
 	if subject.Zero() {
-		err := s.augmentArtificialVariable(c)
+		err := s.augmentArtificialVariable(c, tag.marker)
 		if err != nil {
+			s.uninstall(tag) // pivots made to satisfy the constraint left it in the tableau
 			return tag.marker, err
 		}
 	} else {
@@ -128,71 +367,248 @@ func (s *Solver) AddConstraintWithPriority(priority Priority, cell Constraint) (
 		c.expr.solveFor(subject)
 
 		s.substitute(subject, c.expr)
-		s.tabs[subject] = c
+
+		c.expr.terms = append(s.alloc(len(c.expr.terms)), c.expr.terms...)
+		s.insertRow(subject, c)
 	}
 
 	s.tags[tag.marker] = tag
+	if s.partition != nil {
+		s.joinComponent(tag.marker, tag.cell)
+	}
 
-	return tag.marker, s.optimizeAgainst(&s.objective)
+	return tag.marker, nil
+}
+
+// reuse returns symbol, or a new symbol of the given kind should symbol be zero.
+func (s *Solver) reuse(symbol Symbol, typ SymbolKind) Symbol {
+	if symbol.Zero() {
+		return s.next(typ)
+	}
+	return symbol
+}
+
+// optimize optimizes the objective of the solver once a constraint is installed, unless the solver
+// was created using WithManualSolve.
+func (s *Solver) optimize() error {
+	if s.opts.manual {
+		s.dirty = true
+		return nil
+	}
+	return s.optimizeAgainst(&s.objective)
+}
+
+// ConstraintInfo describes an installed constraint.
+type ConstraintInfo struct {
+	Marker     Symbol
+	Priority   Priority
+	Constraint Constraint // constraint as supplied, or the value suggested or preferred for a variable
+	Edit       bool       // whether the constraint is the suggested value of an edit variable
+	Stay       bool       // whether the constraint is the value a stay prefers for its variable
+}
+
+// Constraints returns all installed constraints in the order they were installed in. The
+// constraints of edit variables and stays are included, expressed as their variable being equal to
+// the value suggested for it or preferred by the stay.
+func (s *Solver) Constraints() []ConstraintInfo {
+	res := make([]ConstraintInfo, 0, len(s.tags))
+	for _, tag := range s.tags {
+		res = append(res, s.info(tag))
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Marker.ID() < res[j].Marker.ID() })
+	return res
+}
+
+// info describes the constraint of tag, expressing the constraints of edit variables and stays as
+// their variable being equal to the value suggested for it or preferred by the stay.
+func (s *Solver) info(tag Tag) ConstraintInfo {
+	info := ConstraintInfo{Marker: tag.marker, Priority: tag.priority, Constraint: tag.cell.clone()}
+	if len(tag.cell.expr.terms) != 1 {
+		return info
+	}
+	id := tag.cell.expr.terms[0].id
+	if edit, ok := s.edits[id]; ok && edit.tag.marker == tag.marker {
+		info.Constraint, info.Edit = id.EQ(edit.val), true
+	} else if stay, ok := s.stays[id]; ok && stay.tag.marker == tag.marker {
+		info.Constraint, info.Stay = id.EQ(stay.val), true
+	}
+	return info
+}
+
+// AddConstraints adds constraints with the given priority, and returns their markers in the order
+// the constraints were given. Either all constraints are added, or none are: should a constraint
+// fail to be added, all constraints added before it are removed and the error is returned.
+func (s *Solver) AddConstraints(priority Priority, cells ...Constraint) ([]Symbol, error) {
+	markers := make([]Symbol, 0, len(cells))
+	for _, cell := range cells {
+		marker, err := s.AddConstraintWithPriority(priority, cell)
+		if err != nil {
+			if s.HasConstraint(marker) {
+				markers = append(markers, marker) // installed, though the solver gave up optimizing it
+			}
+			for i := len(markers) - 1; i >= 0; i-- {
+				_ = s.RemoveConstraint(markers[i])
+			}
+			return nil, err
+		}
+		markers = append(markers, marker)
+	}
+	return markers, nil
+}
+
+// HasConstraint reports whether marker refers to a constraint that is installed in the solver.
+func (s *Solver) HasConstraint(marker Symbol) bool {
+	_, exists := s.tags[marker]
+	return exists
+}
+
+// Constraint returns the constraint marker refers to as it was supplied to the solver, prior to its
+// conversion into augmented simplex form. It reports false if marker does not refer to a constraint
+// installed in the solver.
+func (s *Solver) Constraint(marker Symbol) (Constraint, bool) {
+	tag, exists := s.tags[marker]
+	if !exists {
+		return Constraint{}, false
+	}
+	return tag.cell.clone(), true
 }
 
 func (s *Solver) RemoveConstraint(marker Symbol) error {
+	if s.watched() {
+		defer s.notify()
+	}
+
+	tag, err := s.removeConstraint(marker)
+	if err != nil {
+		return err
+	}
+
+	if s.opts.manual || s.removing > 0 {
+		s.dirty = true
+		return nil
+	}
+
+	return s.constraintError(tag.marker, tag.priority, tag.cell, s.optimizeAgainst(&s.objective))
+}
+
+// RemoveConstraints removes several constraints, optimizing the objective of the solver once all of
+// them are removed rather than after every removal. Should any of them fail to be removed, the rest
+// are still removed, and the first error encountered is returned.
+func (s *Solver) RemoveConstraints(markers ...Symbol) error {
+	if s.watched() {
+		defer s.notify()
+	}
+
+	var first error
+	for _, marker := range markers {
+		if _, err := s.removeConstraint(marker); err != nil && first == nil {
+			first = err
+		}
+	}
+
+	if s.opts.manual || s.removing > 0 {
+		s.dirty = true
+		return first
+	}
+
+	if err := s.optimizeAgainst(&s.objective); err != nil && first == nil {
+		first = err
+	}
+	return first
+}
+
+// removeConstraint removes a constraint from the tableau without optimizing the objective of the
+// solver, and returns the tag of the constraint removed.
+func (s *Solver) removeConstraint(marker Symbol) (Tag, error) {
 	tag, exists := s.tags[marker]
 	if !exists {
-		return ErrBadConstraintMarker
+		if s.strict != nil {
+			s.strict.checkRemove(marker)
+		}
+		return tag, s.constraintError(marker, 0, Constraint{}, ErrBadConstraintMarker)
+	}
+
+	if s.strict != nil {
+		s.strict.onRemove(marker)
+	}
+	if s.history != nil && !s.internalMarker(marker) {
+		s.history.record(op{kind: opRemove, marker: marker, priority: tag.priority, cell: tag.cell})
+	}
+	if s.byValue != nil && !s.internalMarker(marker) {
+		s.onRemoveValue(marker, tag.cell)
+	}
+
+	if s.partition != nil {
+		s.partition.leave(marker)
 	}
 
 	delete(s.tags, tag.marker)
+	s.uninstall(tag)
+
+	return tag, nil
+}
 
+// uninstall removes the row of the constraint of tag from the tableau, along with the weight of its
+// error symbols in the objective, without optimizing the objective of the solver.
+func (s *Solver) uninstall(tag Tag) {
 	if tag.marker.Error() {
-		row, exists := s.tabs[tag.marker]
+		row, exists := s.tabs.get(tag.marker)
 		if exists {
-			s.objective.addExpr(float64(-tag.priority), row.expr)
+			s.addObjectiveExpr(float64(-tag.priority), row.expr)
 		} else {
-			s.objective.addSymbol(float64(-tag.priority), tag.marker)
+			s.addObjective(float64(-tag.priority), tag.marker)
 		}
 	}
 
 	if tag.other.Error() {
-		row, exists := s.tabs[tag.other]
+		row, exists := s.tabs.get(tag.other)
 		if exists {
-			s.objective.addExpr(float64(-tag.priority), row.expr)
+			s.addObjectiveExpr(float64(-tag.priority), row.expr)
 		} else {
-			s.objective.addSymbol(float64(-tag.priority), tag.other)
+			s.addObjective(float64(-tag.priority), tag.other)
 		}
 	}
 
-	row, exists := s.tabs[tag.marker]
+	row, exists := s.tabs.get(tag.marker)
 	if !exists {
-		r1 := math.MaxFloat64
-		r2 := math.MaxFloat64
+		r1, c1 := math.MaxFloat64, 0.0
+		r2, c2 := math.MaxFloat64, 0.0
 
 		exit := zero
 		first := zero
 		second := zero
 		third := zero
 
-		for symbol, row := range s.tabs {
+		// restricted rows the marker decreases leave the basis at the smallest -constant/coeff, and
+		// otherwise those it increases leave at the smallest constant/coeff, such that no other
+		// restricted row is left negative. ratios are compared by multiples of epsilon, such that the
+		// row whose coefficient of the marker is largest in magnitude leaves amongst those whose
+		// ratios differ only by rounding error, and rounding errors are not amplified. remaining ties
+		// are broken in favor of the lowest symbol, such that the row picked to leave the basis does
+		// not depend on the order the column is iterated in
+
+		for _, symbol := range s.column(tag.marker) {
+			row, _ := s.tabs.get(symbol)
 			idx := row.expr.find(tag.marker)
 			if idx == -1 {
 				continue
 			}
 
 			coeff := row.expr.terms[idx].coeff
-			if eqz(coeff) {
+			if s.eqz(coeff) {
 				continue
 			}
 
 			if symbol.External() {
-				third = symbol
+				if third.Zero() || symbol < third {
+					third = symbol
+				}
 			} else {
-				r := -row.expr.constant / coeff
-
-				switch {
-				case coeff < 0 && r < r1:
-					r1, first = r, symbol
-				case coeff >= 0 && r < r2:
-					r2, second = r, symbol
+				switch r := row.expr.constant / coeff; {
+				case coeff < 0 && precedes(-r, r1, -coeff, c1, symbol, first, s.opts.epsilon):
+					r1, c1, first = -r, -coeff, symbol
+				case coeff >= 0 && precedes(r, r2, coeff, c2, symbol, second, s.opts.epsilon):
+					r2, c2, second = r, coeff, symbol
 				}
 			}
 		}
@@ -206,71 +622,277 @@ func (s *Solver) RemoveConstraint(marker Symbol) error {
 			exit = third
 		}
 
-		row = s.tabs[exit]
-		delete(s.tabs, exit)
+		row, _ = s.removeRow(exit)
 
-		row.expr.solveForSymbols(exit, tag.marker)
+		row.expr.solveForSymbols(exit, tag.marker, s.opts.epsilon)
 		s.substitute(tag.marker, row.expr)
-
-		return s.optimizeAgainst(&s.objective)
+		s.spare.put(row.expr.terms)
+	} else {
+		s.discardRow(tag.marker)
 	}
 
-	delete(s.tabs, tag.marker)
+	// the other error symbol of the constraint may be left referenced by no row, in which case any
+	// weight left of it in the objective is floating-point error that would have it enter the basis
+	// with no row to bound it
 
-	return s.optimizeAgainst(&s.objective)
+	if tag.other.Error() && s.cols[tag.other].len() == 0 {
+		if idx := s.objective.find(tag.other); idx != -1 && s.objective.terms[idx].coeff != 0 {
+			s.zeroObjective(idx)
+		}
+	}
+}
+
+// ConstraintPriority returns the priority of a constraint, and whether marker refers to an installed
+// constraint.
+func (s *Solver) ConstraintPriority(marker Symbol) (Priority, bool) {
+	tag, exists := s.tags[marker]
+	if !exists {
+		return 0, false
+	}
+	return tag.priority, true
 }
 
 func (s *Solver) Edit(id Symbol, priority Priority) error {
+	if s.strict != nil {
+		s.strict.checkEdit(id, priority)
+	}
 	if priority < 0 || priority >= Required {
 		return ErrBadPriority
 	}
 	if _, exists := s.edits[id]; exists {
 		return nil
 	}
-	constraint := Constraint{op: EQ, expr: NewExpr(0.0, id.T(1.0))}
-	marker, err := s.AddConstraintWithPriority(priority, constraint)
+	if s.watched() {
+		defer s.notify()
+	}
+
+	// install the constraint of a hinted variable as id = hint, and record it as id = 0 with the hint
+	// suggested, such that the hint is in place without having to be suggested afterwards
+
+	val := 0.0
+	if _, hinted := s.hints[id]; hinted {
+		val = s.stays[id].val
+	}
+
+	constraint := Constraint{op: EQ, expr: NewExpr(-val, id.T(1.0))}
+	marker, err := s.addConstraint(priority, constraint)
 	if err != nil {
 		return err
 	}
-	s.edits[id] = Edit{tag: s.tags[marker], val: 0.0}
+
+	tag := s.tags[marker]
+	tag.cell = Constraint{op: EQ, expr: NewExpr(0.0, id.T(1.0))}
+	s.tags[marker] = tag
+
+	s.edits[id] = Edit{tag: tag, val: val}
+	if s.history != nil {
+		s.history.record(op{kind: opEdit, id: id, priority: priority, old: val})
+	}
+
+	// the edit constraint takes over from the stay of a hinted variable
+
+	if _, hinted := s.hints[id]; hinted {
+		if _, err := s.removeConstraint(s.stays[id].tag.marker); err != nil {
+			return err
+		}
+		delete(s.stays, id)
+		delete(s.hints, id)
+	}
+
+	return s.optimize()
+}
+
+// RemoveEdit unregisters an edit variable, removing the constraint through which values were
+// suggested for it. The variable is then only determined by the remaining constraints referencing
+// it, and may be registered again via Edit.
+func (s *Solver) RemoveEdit(id Symbol) error {
+	edit, exists := s.edits[id]
+	if !exists {
+		if s.strict != nil {
+			s.strict.misuse("RemoveEdit(%s): symbol is not registered as an edit variable", id)
+		}
+		return ErrBadEditVariable
+	}
+	val, _ := s.Suggested(id)
+	if err := s.RemoveConstraint(edit.tag.marker); err != nil {
+		return err
+	}
+	delete(s.edits, id)
+	delete(s.pending, id)
+	if s.history != nil {
+		s.history.record(op{kind: opRemoveEdit, id: id, priority: edit.tag.priority, old: val})
+	}
 	return nil
 }
 
+// EditPriority returns the priority of an edit variable, and whether id is registered as an edit
+// variable.
+func (s *Solver) EditPriority(id Symbol) (Priority, bool) {
+	edit, exists := s.edits[id]
+	if !exists {
+		return 0, false
+	}
+	return s.tags[edit.tag.marker].priority, true
+}
+
+// HasEdit reports whether id is registered as an edit variable.
+func (s *Solver) HasEdit(id Symbol) bool {
+	_, exists := s.edits[id]
+	return exists
+}
+
+// Suggested returns the value last suggested for an edit variable, and whether id is registered as
+// an edit variable. Values suggested for edit variables default to zero.
+func (s *Solver) Suggested(id Symbol) (float64, bool) {
+	edit, exists := s.edits[id]
+	if val, pending := s.pending[id]; pending {
+		return val, exists
+	}
+	return edit.val, exists
+}
+
+// EditSuggest registers id as an edit variable of the given priority should it not be registered
+// already, suggests val for it, and returns the value it settles at. See SuggestResolved.
+func (s *Solver) EditSuggest(id Symbol, priority Priority, val float64) (float64, error) {
+	if err := s.Edit(id, priority); err != nil {
+		return s.Val(id), err
+	}
+	return s.SuggestResolved(id, val)
+}
+
+// SuggestResolved suggests val for an edit variable as Suggest does, and returns the value the
+// variable settles at once the solver is optimized. The value differs from val should constraints
+// of higher priority override the suggestion. Solvers created using WithManualSolve only apply the
+// suggestion once Solve is called, and thus return the value of the variable prior to it.
+func (s *Solver) SuggestResolved(id Symbol, val float64) (float64, error) {
+	if err := s.Suggest(id, val); err != nil {
+		return s.Val(id), err
+	}
+	return s.Val(id), nil
+}
+
 func (s *Solver) Suggest(id Symbol, val float64) error {
 	edit, ok := s.edits[id]
+	if !ok && s.opts.autoEdit {
+		if err := s.Edit(id, s.opts.autoEditPriority); err != nil {
+			return err
+		}
+		edit, ok = s.edits[id]
+	}
 	if !ok {
+		if s.strict != nil {
+			s.strict.misuse("Suggest(%s, %g): symbol must be registered as an edit variable via Edit before suggesting values for it", id, val)
+		}
 		return ErrBadEditVariable
 	}
 
-	defer s.optimizeDualObjective()
+	if s.history != nil {
+		old, _ := s.Suggested(id)
+		s.history.record(op{kind: opSuggest, id: id, old: old, new: val})
+	}
 
-	delta := val - edit.val
+	if s.opts.manual {
+		s.pend(id, val)
+		return nil
+	}
+
+	if s.opts.driftEvery > 0 && s.opts.driftFn != nil {
+		defer s.auditDrift()
+	}
+	if s.watched() {
+		defer s.notify()
+	}
+	s.edits[id] = s.suggest(edit, val)
+
+	return s.optimizeDualObjective()
+}
+
+// SuggestAll suggests values for many edit variables at once, optimizing the solver once after all
+// values are suggested rather than once per value. Should any of the variables not be registered as
+// an edit variable, ErrBadEditVariable is returned and no values are suggested.
+func (s *Solver) SuggestAll(vals map[Symbol]float64) error {
+	ids := make([]Symbol, 0, len(vals))
+	for id := range vals {
+		if _, ok := s.edits[id]; !ok && !s.opts.autoEdit {
+			if s.strict != nil {
+				s.strict.misuse("SuggestAll(%s: %g): symbol must be registered as an edit variable via Edit before suggesting values for it", id, vals[id])
+			}
+			return ErrBadEditVariable
+		}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		if _, ok := s.edits[id]; ok {
+			continue
+		}
+		if err := s.Edit(id, s.opts.autoEditPriority); err != nil {
+			return err
+		}
+	}
+
+	if s.history != nil {
+		batch := make([]op, 0, len(ids))
+		for _, id := range ids {
+			old, _ := s.Suggested(id)
+			batch = append(batch, op{kind: opSuggest, id: id, old: old, new: vals[id]})
+		}
+		s.history.record(op{kind: opSuggestAll, batch: batch})
+	}
 
+	if s.opts.manual {
+		for _, id := range ids {
+			s.pend(id, vals[id])
+		}
+		return nil
+	}
+
+	if s.opts.driftEvery > 0 && s.opts.driftFn != nil {
+		defer s.auditDrift()
+	}
+	if s.watched() {
+		defer s.notify()
+	}
+	for _, id := range ids {
+		s.edits[id] = s.suggest(s.edits[id], vals[id])
+	}
+
+	return s.optimizeDualObjective()
+}
+
+// suggest applies a value suggested for an edit variable to the tableau, marks rows that were made
+// infeasible to be optimized away by optimizeDualObjective, and returns the updated edit.
+func (s *Solver) suggest(edit Edit, val float64) Edit {
+	delta := val - edit.val
 	edit.val = val
-	s.edits[id] = edit
 
-	row, exists := s.tabs[edit.tag.marker]
+	row, exists := s.tabs.get(edit.tag.marker)
 	if exists {
 		row.expr.constant -= delta
 		if row.expr.constant < 0.0 {
-			s.infeasible = append(s.infeasible, edit.tag.marker)
+			s.markInfeasible(edit.tag.marker)
 		}
-		s.tabs[edit.tag.marker] = row
-		return nil
+		s.tabs.set(edit.tag.marker, row)
+		return edit
 	}
 
-	row, exists = s.tabs[edit.tag.other]
+	// the other error symbol of the edit is the marker negated, such that its row shifts the other
+	// way around, as it does in kiwi
+
+	row, exists = s.tabs.get(edit.tag.other)
 	if exists {
-		row.expr.constant -= delta
+		row.expr.constant += delta
 		if row.expr.constant < 0.0 {
-			s.infeasible = append(s.infeasible, edit.tag.other)
+			s.markInfeasible(edit.tag.other)
 		}
-		s.tabs[edit.tag.other] = row
-		return nil
+		s.tabs.set(edit.tag.other, row)
+		return edit
 	}
 
-	for symbol := range s.tabs {
-		row := s.tabs[symbol]
+	for _, symbol := range s.cols[edit.tag.marker].symbols() {
+		i, _ := s.tabs.pos(symbol)
+		row := &s.tabs.entries[i].row // only its constant is shifted
 
 		idx := row.expr.find(edit.tag.marker)
 		if idx == -1 {
@@ -278,12 +900,12 @@ func (s *Solver) Suggest(id Symbol, val float64) error {
 		}
 
 		coeff := row.expr.terms[idx].coeff
-		if eqz(coeff) {
+		if s.eqz(coeff) {
 			continue
 		}
 
 		row.expr.constant += coeff * delta
-		s.tabs[symbol] = row
+		s.tabs.invalidate(symbol)
 
 		if row.expr.constant >= 0.0 {
 			continue
@@ -293,17 +915,25 @@ func (s *Solver) Suggest(id Symbol, val float64) error {
 			continue
 		}
 
-		s.infeasible = append(s.infeasible, symbol)
+		s.markInfeasible(symbol)
 	}
 
-	return nil
+	return edit
 }
 
 // findSubject finds a subject variable to pivot on. It must either:
 // 1. be an external variable,
 // 2. be a negative slack/error variable, or
 // 3. be a dummy variable that has previously been cancelled out
+//
+// External variables are preferred in the order they are written in the constraint as provided,
+// as the terms of cell are ordered by symbol.
 func (s *Solver) findSubject(cell Constraint, tag Tag) (Symbol, error) {
+	for _, term := range tag.cell.expr.terms {
+		if term.id.External() && cell.expr.find(term.id) != -1 {
+			return term.id, nil
+		}
+	}
 	for _, term := range cell.expr.terms {
 		if term.id.External() {
 			return term.id, nil
@@ -330,164 +960,291 @@ func (s *Solver) findSubject(cell Constraint, tag Tag) (Symbol, error) {
 		}
 	}
 
-	if !eqz(cell.expr.constant) {
+	if !s.eqz(cell.expr.constant) {
 		return zero, ErrBadDummyVariable
 	}
 
 	return tag.marker, nil
 }
 
+// substitute substitutes expr for id in every row referencing id, the objective, and the artificial
+// objective. The symbol id must have been solved out of expr, such that no row references id once
+// substituted.
 func (s *Solver) substitute(id Symbol, expr Expr) {
-	for symbol := range s.tabs {
-		row := s.tabs[symbol]
-		row.expr.substitute(id, expr)
-		s.tabs[symbol] = row
-		if symbol.External() || row.expr.constant >= 0.0 {
-			continue
+	negative := s.negative[:0]
+	for _, symbol := range s.cols[id].symbols() {
+		i, _ := s.tabs.pos(symbol)
+		row := s.ownRow(symbol, s.tabs.entries[i].row, len(expr.terms))
+
+		s.referenced = referenced(s.referenced[:0], row.expr, expr)
+
+		// grow the row within the slab rather than have it reallocated onto the heap by append, to
+		// fit the terms of expr the row does not reference yet
+
+		added := 0
+		for _, ok := range s.referenced {
+			if !ok {
+				added++
+			}
+		}
+		if n := len(row.expr.terms) + added; n > cap(row.expr.terms) {
+			if n < 2*cap(row.expr.terms) {
+				n = 2 * cap(row.expr.terms)
+			}
+			terms := append(s.alloc(n), row.expr.terms...)
+			s.spare.put(row.expr.terms)
+			row.expr.terms = terms
+		}
+		row.expr.substitute(id, expr, added, s.opts.epsilon)
+		if s.tabs.entries[i].row.expr.constant != row.expr.constant {
+			s.tabs.invalidate(symbol)
+		}
+		s.tabs.entries[i].row = row
+		s.relink(symbol, row.expr, expr, s.referenced)
+
+		if !symbol.External() && row.expr.constant < 0.0 {
+			negative = append(negative, symbol)
 		}
-		s.infeasible = append(s.infeasible, symbol)
 	}
-	s.objective.substitute(id, expr)
-	s.artificial.substitute(id, expr)
+
+	// drop the column of id as a whole rather than unlinking it from every row in turn
+
+	s.dropColumn(id)
+
+	s.markInfeasibles(negative)
+	s.negative = negative[:0]
+
+	s.substituteObjective(id, expr)
+	s.artificial.substitute(id, expr, len(expr.terms), s.opts.epsilon)
 }
 
 func (s *Solver) optimizeAgainst(objective *Expr) error {
-	for {
-		entry := zero
-		exit := zero
+	return s.optimizeWithin(objective, nil)
+}
 
-		for _, term := range objective.terms {
-			if !term.id.Dummy() && term.coeff < 0.0 {
-				entry = term.id
-				break
+// optimizeWithin optimizes objective as optimizeAgainst does, but for only symbols of reach being
+// allowed to enter the basis should reach not be nil. As the rows pivoted are those referencing the
+// entering symbol, only rows reachable from reach are pivoted; see reachable.
+func (s *Solver) optimizeWithin(objective *Expr, reach map[Symbol]struct{}) error {
+	tab := s.Tableau()
+
+	var part Expr
+	for pivots := 0; ; pivots++ {
+		candidates := *objective
+		if reach != nil {
+			part.terms = part.terms[:0]
+			for _, term := range objective.terms {
+				if _, ok := reach[term.id]; ok {
+					part.terms = append(part.terms, term)
+				}
 			}
+			candidates = part
 		}
+
+		entry := s.opts.pivot.Entry(tab, candidates)
 		if entry.Zero() {
 			return nil
 		}
+		if s.opts.maxIterations > 0 && pivots == s.opts.maxIterations {
+			return fmt.Errorf("%w: %d pivots made optimizing the objective, next entering %s", ErrMaxIterations, pivots, s.label(entry))
+		}
 
-		ratio := math.MaxFloat64
+		exit := s.opts.pivot.Exit(tab, entry)
+		if exit.Zero() {
+			return fmt.Errorf("%w: no restricted row bounds %s entering the basis", ErrUnbounded, s.label(entry))
+		}
 
-		for symbol := range s.tabs {
-			if symbol.External() {
-				continue
-			}
-			idx := s.tabs[symbol].expr.find(entry)
-			if idx == -1 {
-				continue
-			}
-			coeff := s.tabs[symbol].expr.terms[idx].coeff
-			if coeff >= 0.0 {
-				continue
-			}
-			r := -s.tabs[symbol].expr.constant / coeff
-			if r < ratio {
-				ratio, exit = r, symbol
-			}
+		if s.opts.trace != nil {
+			s.opts.trace(Trace{Entry: entry, Exit: exit})
 		}
 
-		row := s.tabs[exit]
-		delete(s.tabs, exit)
+		row, _ := s.removeRow(exit)
 
-		row.expr.solveForSymbols(exit, entry)
+		row.expr.solveForSymbols(exit, entry, s.opts.epsilon)
 
 		s.substitute(entry, row.expr)
-		s.tabs[entry] = row
+		s.insertRow(entry, row)
 	}
 }
 
-func (s *Solver) augmentArtificialVariable(row Constraint) error {
-	art := next(Slack)
+// augmentArtificialVariable installs row, for which no subject could be found, by minimizing an
+// artificial variable equal to its expression. Should installing row fail, the row is left in the
+// tableau expressed in terms of marker for the caller to uninstall.
+func (s *Solver) augmentArtificialVariable(row Constraint, marker Symbol) error {
+	art := s.next(Slack)
 
-	s.tabs[art] = row.clone()
+	s.insertRow(art, row.clone())
 	s.artificial = row.expr.clone()
 
 	err := s.optimizeAgainst(&s.artificial)
-	if err != nil {
-		return err
+	if err == nil && !s.eqz(s.artificial.constant) {
+		err = ErrUnsatisfiable
 	}
-
-	success := eqz(s.artificial.constant)
 	s.artificial = NewExpr(0.0)
 
-	artificial, ok := s.tabs[art]
-	if ok {
-		delete(s.tabs, art)
+	artificial, ok := s.removeRow(art)
+	if ok && len(artificial.expr.terms) > 0 {
 
-		if len(artificial.expr.terms) == 0 {
-			return nil
-		}
+		// the artificial variable never left the basis, such that its row is the only row to refer
+		// to marker, which is pivoted in for the constraint to be uninstalled should it have failed
 
-		entry := zero
-		for _, term := range artificial.expr.terms {
-			if term.id.Restricted() {
-				entry = term.id
-				break
+		entry := marker
+		if err == nil {
+			entry = zero
+			for _, term := range artificial.expr.terms {
+				if term.id.Restricted() {
+					entry = term.id
+					break
+				}
+			}
+			if entry.Zero() {
+				entry, err = marker, ErrUnsatisfiable
 			}
-		}
-		if entry.Zero() {
-			return errors.New("unsatisfiable")
 		}
 
-		artificial.expr.solveForSymbols(art, entry)
+		artificial.expr.solveForSymbols(art, entry, s.opts.epsilon)
 
 		s.substitute(entry, artificial.expr)
-		s.tabs[entry] = artificial
+		s.insertRow(entry, artificial)
 	}
 
-	for symbol, row := range s.tabs {
+	for _, symbol := range s.cols[art].symbols() {
+		row, _ := s.tabs.get(symbol)
 		idx := row.expr.find(art)
 		if idx == -1 {
 			continue
 		}
+		row = s.ownRow(symbol, row, 0)
 		row.expr.delete(idx)
-		s.tabs[symbol] = row
+		s.tabs.set(symbol, row)
 	}
+	s.dropColumn(art)
 
 	idx := s.objective.find(art)
 	if idx != -1 {
 		s.objective.delete(idx)
 	}
 
-	if !success {
-		return errors.New("unsatisfiable")
+	return err
+}
+
+// markInfeasible queues the row of symbol to be optimized away by optimizeDualObjective, unless it
+// is already queued.
+func (s *Solver) markInfeasible(symbol Symbol) {
+	if _, queued := s.queued[symbol]; queued {
+		return
 	}
-	return nil
+	if s.queued == nil {
+		s.queued = make(map[Symbol]struct{})
+	}
+	s.queued[symbol] = struct{}{}
+	s.infeasible = append(s.infeasible, symbol)
 }
 
-// optimizeDualObjective optimizes away infeasible constraints.
-func (s *Solver) optimizeDualObjective() {
+// markInfeasibles queues the rows of symbols to be optimized away, growing the queue at most once.
+func (s *Solver) markInfeasibles(symbols []Symbol) {
+	if len(symbols) == 0 {
+		return
+	}
+	if n := len(s.infeasible) + len(symbols); n > cap(s.infeasible) {
+		if n < 2*cap(s.infeasible) {
+			n = 2 * cap(s.infeasible)
+		}
+		infeasible := make([]Symbol, len(s.infeasible), n)
+		copy(infeasible, s.infeasible)
+		s.infeasible = infeasible
+	}
+	for _, symbol := range symbols {
+		s.markInfeasible(symbol)
+	}
+}
+
+// popInfeasible dequeues the next infeasible row to optimize away: the row whose constant is most
+// negative, such that the most violated row is pivoted first, or the row of lowest symbol should
+// the solver be created using WithDeterministic. Rows are picked by scanning rather than kept in a
+// heap, as the constants of rows change as other rows are pivoted.
+func (s *Solver) popInfeasible() Symbol {
+	best := len(s.infeasible) - 1
+	for i, symbol := range s.infeasible {
+		if s.opts.deterministic {
+			if symbol < s.infeasible[best] {
+				best = i
+			}
+			continue
+		}
+		row, _ := s.tabs.get(symbol)
+		if other, _ := s.tabs.get(s.infeasible[best]); row.expr.constant < other.expr.constant {
+			best = i
+		}
+	}
+
+	symbol := s.infeasible[best]
+	s.infeasible[best] = s.infeasible[len(s.infeasible)-1]
+	s.infeasible = s.infeasible[:len(s.infeasible)-1]
+	delete(s.queued, symbol)
+
+	return symbol
+}
+
+// optimizeDualObjective optimizes away infeasible constraints. Rows left infeasible should the
+// solver pivot the maximum number of times remain queued.
+func (s *Solver) optimizeDualObjective() error {
+	pivots := 0
 	for len(s.infeasible) > 0 {
-		exit := s.infeasible[len(s.infeasible)-1]
-		s.infeasible = s.infeasible[:len(s.infeasible)-1]
+		exit := s.popInfeasible()
 
-		row, exists := s.tabs[exit]
-		if !exists || row.expr.constant >= 0.0 {
+		if row, exists := s.tabs.get(exit); !exists || row.expr.constant >= 0.0 {
 			continue
 		}
 
-		delete(s.tabs, exit)
+		if s.opts.maxIterations > 0 && pivots == s.opts.maxIterations {
+			s.markInfeasible(exit)
+			return fmt.Errorf("%w: %d pivots made restoring feasibility, %d infeasible rows left", ErrMaxIterations, pivots, len(s.infeasible))
+		}
+		pivots++
+
+		row, _ := s.removeRow(exit)
 
 		entry := zero
 		ratio := math.MaxFloat64
 
+		// symbols with no weight in the objective, such as the slacks of required inequalities, may
+		// enter the basis at a ratio of zero, as they do in kiwi
+
 		for _, term := range row.expr.terms {
-			if term.coeff <= 0.0 || term.id.Dummy() {
+			if term.coeff < s.opts.epsilon || term.id.Dummy() {
 				continue
 			}
-			idx := s.objective.find(term.id)
-			if idx == -1 {
-				continue
+			r := 0.0
+			if idx := s.objective.find(term.id); idx != -1 {
+				r = s.objective.terms[idx].coeff / term.coeff
 			}
-			r := s.objective.terms[idx].coeff / term.coeff
 			if r < ratio {
 				entry, ratio = term.id, r
 			}
 		}
 
-		row.expr.solveForSymbols(exit, entry)
+		// no symbol may enter the basis should the constants of required constraints have been
+		// changed such that they contradict one another
+
+		if entry.Zero() {
+			s.insertRow(exit, row)
+			s.markInfeasible(exit)
+			return ErrUnsatisfiable
+		}
+		if s.opts.trace != nil {
+			s.opts.trace(Trace{Entry: entry, Exit: exit, Dual: true})
+		}
+
+		row.expr.solveForSymbols(exit, entry, s.opts.epsilon)
 
 		s.substitute(entry, row.expr)
-		s.tabs[entry] = row
+		s.insertRow(entry, row)
 	}
+	return nil
+}
+
+// eqz reports whether val is zero within the tolerance of the solver.
+func (s *Solver) eqz(val float64) bool {
+	return nearZero(val, s.opts.epsilon)
 }
@@ -1,6 +1,7 @@
 package casso_test
 
 import (
+	"errors"
 	"github.com/lithdew/casso"
 	"github.com/stretchr/testify/require"
 	"testing"
@@ -46,8 +47,113 @@ func TestRemoveConstraint(t *testing.T) {
 	c2t, err := s.AddConstraint(c2)
 	require.NoError(t, err)
 
+	require.True(t, s.HasConstraint(c1t))
+	require.True(t, s.HasConstraint(c2t))
+	require.False(t, s.HasConstraint(l))
+
 	require.NoError(t, s.RemoveConstraint(c1t))
+	require.False(t, s.HasConstraint(c1t))
+	require.True(t, s.HasConstraint(c2t))
+
 	require.NoError(t, s.RemoveConstraint(c2t))
+	require.False(t, s.HasConstraint(c2t))
+	require.True(t, errors.Is(s.RemoveConstraint(c2t), casso.ErrBadConstraintMarker))
+}
+
+func TestRemoveConstraintStaysFeasible(t *testing.T) {
+	s := casso.NewSolver()
+	x := s.New()
+
+	// x rests on its lower bound, which the slacks of the two looser bounds follow at 5 and 10 above
+	// zero. Removing the lower bound must pivot on the slack of the tightest of the two, or else the
+	// other is left negative.
+
+	lower, err := s.AddConstraint(x.GTE(0))
+	require.NoError(t, err)
+	_, err = s.AddConstraint(x.GTE(-5))
+	require.NoError(t, err)
+	_, err = s.AddConstraint(x.GTE(-10))
+	require.NoError(t, err)
+
+	require.NoError(t, s.RemoveConstraint(lower))
+	require.GreaterOrEqual(t, s.Val(x), -5.0)
+
+	for _, row := range s.Tableau().Rows() {
+		if row.Basic.Restricted() {
+			require.GreaterOrEqual(t, row.Expr.Constant(), 0.0, row.Basic.String())
+		}
+	}
+}
+
+func TestRemoveConstraintTieBreak(t *testing.T) {
+	remove := func() []casso.Symbol {
+		s := casso.NewSolver()
+		x, y, z := s.New(), s.New(), s.New()
+
+		// y and z follow x, which is bounded above by a limit and below by zero, such that the
+		// slacks of all three lower bounds are tied to leave the basis once the limit is removed.
+
+		limit, err := s.AddConstraint(casso.NewConstraint(casso.LTE, -10, x.T(1)))
+		require.NoError(t, err)
+		for _, cell := range []casso.Constraint{
+			casso.NewConstraint(casso.EQ, 0, y.T(1), x.T(-1)),
+			casso.NewConstraint(casso.EQ, 0, z.T(1), x.T(-1)),
+			casso.NewConstraint(casso.GTE, 0, x.T(1)),
+			casso.NewConstraint(casso.GTE, 0, y.T(1)),
+			casso.NewConstraint(casso.GTE, 0, z.T(1)),
+		} {
+			_, err := s.AddConstraint(cell)
+			require.NoError(t, err)
+		}
+		require.NoError(t, s.RemoveConstraint(limit))
+
+		var basic []casso.Symbol
+		for _, row := range s.Tableau().Rows() {
+			basic = append(basic, row.Basic)
+		}
+		return basic
+	}
+
+	basic := remove()
+	for i := 0; i < 100; i++ {
+		require.Equal(t, basic, remove())
+	}
+}
+
+func TestRemoveConstraintNearTie(t *testing.T) {
+	remove := func() []casso.Symbol {
+		s := casso.NewSolver(casso.WithEpsilon(1e-3))
+		x, y, z := s.New(), s.New(), s.New()
+
+		// as with TestRemoveConstraintTieBreak, though y and z scale x, such that the slacks of the
+		// lower bounds leave at ratios of 5, 5.0008, and 5.0016 with coefficients of 1, 2, and 3.
+		// adjacent ratios are within epsilon of one another while the outer two are not.
+
+		limit, err := s.AddConstraint(casso.NewConstraint(casso.LTE, -10, x.T(1)))
+		require.NoError(t, err)
+		for _, cell := range []casso.Constraint{
+			casso.NewConstraint(casso.EQ, 0, y.T(1), x.T(-2)),
+			casso.NewConstraint(casso.EQ, 0, z.T(1), x.T(-3)),
+			casso.NewConstraint(casso.GTE, -5, x.T(1)),
+			casso.NewConstraint(casso.GTE, -9.9984, y.T(1)),
+			casso.NewConstraint(casso.GTE, -14.9952, z.T(1)),
+		} {
+			_, err := s.AddConstraint(cell)
+			require.NoError(t, err)
+		}
+		require.NoError(t, s.RemoveConstraint(limit))
+
+		var basic []casso.Symbol
+		for _, row := range s.Tableau().Rows() {
+			basic = append(basic, row.Basic)
+		}
+		return basic
+	}
+
+	basic := remove()
+	for i := 0; i < 100; i++ {
+		require.Equal(t, basic, remove())
+	}
 }
 
 func TestEditableConstraint(t *testing.T) {
@@ -79,6 +185,48 @@ func TestEditableConstraint(t *testing.T) {
 	require.EqualValues(t, 200, s.Val(r))
 }
 
+func TestRemoveEdit(t *testing.T) {
+	s := casso.NewSolver()
+	x := casso.New()
+
+	_, err := s.AddConstraintWithPriority(casso.Weak, x.EQ(10))
+	require.NoError(t, err)
+
+	require.Equal(t, casso.ErrBadEditVariable, s.RemoveEdit(x))
+	require.False(t, s.HasEdit(x))
+
+	require.NoError(t, s.Edit(x, casso.Strong))
+	require.True(t, s.HasEdit(x))
+
+	val, ok := s.Suggested(x)
+	require.True(t, ok)
+	require.EqualValues(t, 0, val)
+
+	require.NoError(t, s.Suggest(x, 50))
+	require.EqualValues(t, 50, s.Val(x))
+
+	val, ok = s.Suggested(x)
+	require.True(t, ok)
+	require.EqualValues(t, 50, val)
+
+	// Once unregistered, the variable is free again, and values may no longer be suggested for it.
+
+	require.NoError(t, s.RemoveEdit(x))
+	require.EqualValues(t, 10, s.Val(x))
+	require.Equal(t, casso.ErrBadEditVariable, s.Suggest(x, 20))
+	require.Equal(t, casso.ErrBadEditVariable, s.RemoveEdit(x))
+	require.False(t, s.HasEdit(x))
+
+	_, ok = s.Suggested(x)
+	require.False(t, ok)
+
+	// The variable may be registered again as an edit variable.
+
+	require.NoError(t, s.Edit(x, casso.Medium))
+	require.NoError(t, s.Suggest(x, 30))
+	require.EqualValues(t, 30, s.Val(x))
+}
+
 func TestConstraintRequiringArtificialVariable(t *testing.T) {
 	s := casso.NewSolver()
 
@@ -114,6 +262,95 @@ func TestConstraintRequiringArtificialVariable(t *testing.T) {
 	require.EqualValues(t, 100, s.Val(container))
 }
 
+func TestConstraintRequiringArtificialVariableUnsatisfiable(t *testing.T) {
+	s := casso.NewSolver()
+	x, y, z := s.New(), s.New(), s.New()
+
+	_, err := s.AddConstraints(casso.Required,
+		x.GTE(0), y.GTE(0), z.GTE(0),
+		casso.NewConstraint(casso.GTE, -5, x.T(1), y.T(1)),
+		casso.NewConstraint(casso.GTE, -3, y.T(1), z.T(1)),
+	)
+	require.NoError(t, err)
+
+	// the sum is installed through an artificial variable which cannot be brought down to zero, and
+	// the pivots made trying to are undone
+
+	sum, err := s.AddConstraint(casso.NewConstraint(casso.EQ, -2, x.T(1), y.T(1), z.T(1)))
+	require.True(t, errors.Is(err, casso.ErrUnsatisfiable))
+	require.False(t, s.HasConstraint(sum))
+	requireInstalledOnly(t, s)
+
+	require.GreaterOrEqual(t, s.Val(x)+s.Val(y), 5.0)
+	require.GreaterOrEqual(t, s.Val(y)+s.Val(z), 3.0)
+
+	_, err = s.AddConstraint(casso.NewConstraint(casso.EQ, -8, x.T(1), y.T(1), z.T(1)))
+	require.NoError(t, err)
+	require.InDelta(t, 8, s.Val(x)+s.Val(y)+s.Val(z), 1e-9)
+	requireInstalledOnly(t, s)
+}
+
+func TestRejectedConstraintKeepsSolution(t *testing.T) {
+	for _, op := range []casso.Op{casso.EQ, casso.LTE} {
+		s := casso.NewSolver()
+		x, y := s.New(), s.New()
+
+		_, err := s.AddConstraints(casso.Required,
+			x.GTE(0), y.GTE(0),
+			casso.NewConstraint(casso.LTE, -100, x.T(1), y.T(1)),
+		)
+		require.NoError(t, err)
+		require.NoError(t, s.Edit(x, casso.Strong))
+		require.NoError(t, s.Suggest(x, 60))
+		_, err = s.AddConstraintWithPriority(casso.Weak, y.EQ(30))
+		require.NoError(t, err)
+
+		require.EqualValues(t, 60, s.Val(x))
+		require.EqualValues(t, 30, s.Val(y))
+		require.EqualValues(t, 0, s.ObjectiveValue())
+
+		// the pivots made trying to satisfy the rejected constraint are optimized away once it is
+		// uninstalled, such that the solution is left as it was
+
+		_, err = s.AddConstraint(casso.NewConstraint(op, 5, x.T(1), y.T(1)))
+		require.True(t, errors.Is(err, casso.ErrUnsatisfiable), op.String())
+
+		require.EqualValues(t, 60, s.Val(x), op.String())
+		require.EqualValues(t, 30, s.Val(y), op.String())
+		require.InDelta(t, 0, s.ObjectiveValue(), 1e-9, op.String())
+	}
+}
+
+// requireInstalledOnly requires the tableau and objective of s to only refer to external symbols
+// and the markers of installed constraints.
+func requireInstalledOnly(t *testing.T, s *casso.Solver) {
+	t.Helper()
+
+	markers := make(map[casso.Symbol]struct{})
+	for _, info := range s.Constraints() {
+		markers[info.Marker] = struct{}{}
+	}
+
+	for _, row := range s.Tableau().Rows() {
+		symbols := []casso.Symbol{row.Basic}
+		for _, term := range row.Expr.Terms() {
+			symbols = append(symbols, term.Symbol())
+		}
+		for _, symbol := range symbols {
+			if symbol.External() {
+				continue
+			}
+			_, installed := markers[symbol]
+			require.True(t, installed, "%s is left in the tableau", symbol)
+		}
+	}
+
+	for _, term := range s.Tableau().Objective().Terms() {
+		_, installed := markers[term.Symbol()]
+		require.True(t, installed, "%s is left in the objective", term.Symbol())
+	}
+}
+
 func TestPaddingUI(t *testing.T) {
 	s := casso.NewSolver()
 
@@ -229,3 +466,538 @@ func BenchmarkAddConstraint(b *testing.B) {
 		_, _ = s.AddConstraint(b)
 	}
 }
+
+func TestAutoEdit(t *testing.T) {
+	x := casso.New()
+	y := casso.New()
+
+	s := casso.NewSolver()
+	require.Equal(t, casso.ErrBadEditVariable, s.Suggest(x, 10))
+
+	s = casso.NewSolver(casso.WithCapacity(16), casso.WithAutoEdit(casso.Strong))
+
+	_, err := s.AddConstraint(casso.NewConstraint(casso.EQ, 0, y.T(1), x.T(-2)))
+	require.NoError(t, err)
+
+	require.NoError(t, s.Suggest(x, 10))
+	require.EqualValues(t, 10, s.Val(x))
+	require.EqualValues(t, 20, s.Val(y))
+
+	s = casso.NewSolver(casso.WithAutoEdit(casso.Required))
+	require.Equal(t, casso.ErrBadPriority, s.Suggest(x, 10))
+}
+
+func TestWithTrace(t *testing.T) {
+	var traces []casso.Trace
+	s := casso.NewSolver(casso.WithTrace(func(trace casso.Trace) { traces = append(traces, trace) }))
+	x := casso.New()
+
+	_, err := s.AddConstraint(x.LTE(100))
+	require.NoError(t, err)
+	_, err = s.AddConstraintWithPriority(casso.Weak, x.EQ(50))
+	require.NoError(t, err)
+	require.NoError(t, s.Edit(x, casso.Strong))
+
+	// suggesting past the bound has the solver pivot to restore feasibility
+
+	traces = traces[:0]
+	require.NoError(t, s.Suggest(x, 200))
+	require.EqualValues(t, 100, s.Val(x))
+
+	require.NotEmpty(t, traces)
+	for _, trace := range traces {
+		require.True(t, trace.Dual)
+		require.False(t, trace.Entry.Zero())
+		require.False(t, trace.Exit.Zero())
+	}
+
+	// tightening the bound has the solver pivot to optimize its objective
+
+	traces = traces[:0]
+	_, err = s.AddConstraint(x.LTE(60))
+	require.NoError(t, err)
+	require.EqualValues(t, 60, s.Val(x))
+
+	require.NotEmpty(t, traces)
+	for _, trace := range traces {
+		require.False(t, trace.Dual)
+		require.False(t, trace.Entry.Zero())
+		require.False(t, trace.Exit.Zero())
+	}
+}
+
+func TestSymbolData(t *testing.T) {
+	s := casso.NewSolver()
+
+	x := casso.New()
+	y := casso.New()
+
+	require.Nil(t, s.SymbolData(x))
+
+	s.SetSymbolData(x, "sidebar.width")
+	s.SetSymbolData(y, 42)
+
+	require.EqualValues(t, "sidebar.width", s.SymbolData(x))
+	require.EqualValues(t, 42, s.SymbolData(y))
+
+	s.SetSymbolData(x, nil)
+	require.Nil(t, s.SymbolData(x))
+	require.EqualValues(t, 42, s.SymbolData(y))
+
+	require.Nil(t, casso.NewSolver().SymbolData(y))
+}
+
+func TestSuggestAll(t *testing.T) {
+	build := func() (*casso.Solver, casso.Symbol, casso.Symbol, casso.Symbol) {
+		s := casso.NewSolver(casso.WithHistory(4))
+		width := casso.New()
+		height := casso.New()
+		area := casso.New()
+
+		_, err := s.AddConstraint(casso.NewConstraint(casso.EQ, 0, area.T(1), width.T(-2), height.T(-3)))
+		require.NoError(t, err)
+		_, err = s.AddConstraint(width.GTE(10))
+		require.NoError(t, err)
+		_, err = s.AddConstraint(height.LTE(500))
+		require.NoError(t, err)
+
+		require.NoError(t, s.Edit(width, casso.Strong))
+		require.NoError(t, s.Edit(height, casso.Strong))
+
+		return s, width, height, area
+	}
+
+	a, width, height, area := build()
+	require.NoError(t, a.SuggestAll(map[casso.Symbol]float64{width: 5, height: 600}))
+
+	b, bw, bh, ba := build()
+	require.NoError(t, b.Suggest(bw, 5))
+	require.NoError(t, b.Suggest(bh, 600))
+
+	require.EqualValues(t, 10, a.Val(width))
+	require.EqualValues(t, 500, a.Val(height))
+	require.EqualValues(t, 1520, a.Val(area))
+	require.EqualValues(t, a.Val(width), b.Val(bw))
+	require.EqualValues(t, a.Val(height), b.Val(bh))
+	require.EqualValues(t, a.Val(area), b.Val(ba))
+
+	// no values are suggested should any variable not be an edit variable
+
+	require.Equal(t, casso.ErrBadEditVariable, a.SuggestAll(map[casso.Symbol]float64{width: 50, area: 10}))
+	val, _ := a.Suggested(width)
+	require.EqualValues(t, 5, val)
+
+	// values suggested together are undone together, and lowering the suggested height below its
+	// upper bound takes effect
+
+	require.NoError(t, a.SuggestAll(map[casso.Symbol]float64{width: 20, height: 30}))
+	require.EqualValues(t, 130, a.Val(area))
+	require.NoError(t, a.Undo())
+	require.EqualValues(t, 1520, a.Val(area))
+	require.NoError(t, a.Redo())
+	require.EqualValues(t, 130, a.Val(area))
+}
+
+func TestSuggestBelowBound(t *testing.T) {
+	s := casso.NewSolver()
+	x := casso.New()
+
+	_, err := s.AddConstraint(x.LTE(500))
+	require.NoError(t, err)
+	require.NoError(t, s.Edit(x, casso.Strong))
+
+	// suggesting past the bound leaves the negative error of the suggestion basic, which shifts
+	// along with the value suggested as suggestions are lowered back below the bound
+
+	require.NoError(t, s.Suggest(x, 600))
+	require.EqualValues(t, 500, s.Val(x))
+
+	require.NoError(t, s.Suggest(x, 550))
+	require.EqualValues(t, 500, s.Val(x))
+
+	require.NoError(t, s.Suggest(x, 30))
+	require.EqualValues(t, 30, s.Val(x))
+}
+
+func TestSuggestDoesNotAllocate(t *testing.T) {
+	for _, opts := range [][]casso.Option{nil, {casso.WithDeterministic()}} {
+		s := casso.NewSolver(opts...)
+		x := casso.New()
+		y := casso.New()
+
+		_, err := s.AddConstraint(x.GTE(0))
+		require.NoError(t, err)
+		_, err = s.AddConstraint(casso.NewConstraint(casso.EQ, 0, y.T(1), x.T(-2)))
+		require.NoError(t, err)
+		require.NoError(t, s.Edit(x, casso.Strong))
+		require.NoError(t, s.Edit(y, casso.Weak))
+
+		val := 0.0
+		allocs := testing.AllocsPerRun(100, func() {
+			val++
+			require.NoError(t, s.Suggest(x, val))
+			require.NoError(t, s.Suggest(y, val))
+		})
+		require.Zero(t, allocs)
+	}
+}
+
+func BenchmarkSuggestAll(b *testing.B) {
+	s := casso.NewSolver()
+	ids := make([]casso.Symbol, 3)
+	for i := range ids {
+		ids[i] = casso.New()
+		_, _ = s.AddConstraint(ids[i].GTE(0))
+		_ = s.Edit(ids[i], casso.Strong)
+	}
+	vals := make(map[casso.Symbol]float64, len(ids))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for j, id := range ids {
+			vals[id] = float64(i + j)
+		}
+		_ = s.SuggestAll(vals)
+	}
+}
+
+func TestVals(t *testing.T) {
+	s := casso.NewSolver()
+
+	x := casso.New()
+	y := casso.New()
+	z := casso.New()
+
+	require.Empty(t, s.Vals())
+
+	_, err := s.AddConstraint(casso.NewConstraint(casso.GTE, 0, y.T(1), x.T(-1)))
+	require.NoError(t, err)
+	require.NoError(t, s.Edit(z, casso.Strong))
+	require.NoError(t, s.Suggest(z, 5))
+
+	vals := s.Vals()
+	require.Len(t, vals, 3)
+	for id, val := range vals {
+		require.EqualValues(t, s.Val(id), val)
+	}
+	require.EqualValues(t, 5, vals[z])
+}
+
+func TestDeterministic(t *testing.T) {
+	solve := func() (float64, float64) {
+		s := casso.NewSolver(casso.WithDeterministic())
+
+		x := casso.New()
+		y := casso.New()
+
+		// x and y share a fixed budget while weakly preferring to take all of it, such that every
+		// split of the budget is optimal.
+
+		_, err := s.AddConstraint(casso.NewConstraint(casso.EQ, -10, x.T(1), y.T(1)))
+		require.NoError(t, err)
+		_, err = s.AddConstraint(casso.NewConstraint(casso.GTE, 0, x.T(1)))
+		require.NoError(t, err)
+		_, err = s.AddConstraint(casso.NewConstraint(casso.GTE, 0, y.T(1)))
+		require.NoError(t, err)
+		_, err = s.AddConstraintWithPriority(casso.Weak, casso.NewConstraint(casso.EQ, -10, x.T(1)))
+		require.NoError(t, err)
+		marker, err := s.AddConstraintWithPriority(casso.Weak, casso.NewConstraint(casso.EQ, -10, y.T(1)))
+		require.NoError(t, err)
+		_, err = s.AddConstraintWithPriority(casso.Weak, casso.NewConstraint(casso.EQ, -5, x.T(1)))
+		require.NoError(t, err)
+		require.NoError(t, s.RemoveConstraint(marker))
+
+		return s.Val(x), s.Val(y)
+	}
+
+	x, y := solve()
+	require.EqualValues(t, 10, x+y)
+
+	for i := 0; i < 100; i++ {
+		xi, yi := solve()
+		require.EqualValues(t, x, xi)
+		require.EqualValues(t, y, yi)
+	}
+}
+
+func TestEpsilon(t *testing.T) {
+	x := casso.New()
+	c := casso.NewConstraint(casso.EQ, -1, x.T(1e-9))
+
+	_, err := casso.NewSolver().AddConstraint(c)
+	require.Error(t, err)
+
+	s := casso.NewSolverWithOptions(casso.Options{Epsilon: 1e-12})
+	_, err = s.AddConstraint(c)
+	require.NoError(t, err)
+	require.InDelta(t, 1e9, s.Val(x), 1e-3)
+
+	s = casso.NewSolver(casso.WithEpsilon(1e-12))
+	_, err = s.AddConstraint(c)
+	require.NoError(t, err)
+	require.InDelta(t, 1e9, s.Val(x), 1e-3)
+
+	require.EqualValues(t, 1e-12, s.Epsilon())
+	require.EqualValues(t, casso.DefaultEpsilon, casso.NewSolver().Epsilon())
+}
+
+func TestConstraintError(t *testing.T) {
+	s := casso.NewSolver()
+
+	width := casso.New()
+	s.SetSymbolData(width, "width")
+
+	_, err := s.AddConstraint(width.EQ(100))
+	require.NoError(t, err)
+
+	conflict := width.EQ(200)
+	_, err = s.AddConstraint(conflict)
+	require.Error(t, err)
+
+	var cerr *casso.ConstraintError
+	require.True(t, errors.As(err, &cerr))
+	require.Equal(t, conflict, cerr.Constraint)
+	require.Equal(t, casso.Required, cerr.Priority)
+	require.Contains(t, err.Error(), "width - 200 = 0")
+
+	marker := casso.New()
+	err = s.RemoveConstraint(marker)
+	require.True(t, errors.As(err, &cerr))
+	require.Equal(t, marker, cerr.Marker)
+	require.True(t, errors.Is(err, casso.ErrBadConstraintMarker))
+}
+
+func TestConstraintLookup(t *testing.T) {
+	s := casso.NewSolver()
+
+	x := casso.New()
+	y := casso.New()
+
+	c := casso.NewConstraint(casso.GTE, -10, x.T(2), y.T(-1))
+	marker, err := s.AddConstraintWithPriority(casso.Strong, c)
+	require.NoError(t, err)
+
+	got, ok := s.Constraint(marker)
+	require.True(t, ok)
+	require.Equal(t, c, got)
+
+	require.NoError(t, s.RemoveConstraint(marker))
+	_, ok = s.Constraint(marker)
+	require.False(t, ok)
+}
+
+func TestAddConstraints(t *testing.T) {
+	s := casso.NewSolver()
+
+	x := casso.New()
+	y := casso.New()
+
+	markers, err := s.AddConstraints(casso.Required, x.GTE(10), casso.NewConstraint(casso.EQ, 0, y.T(1), x.T(-2)))
+	require.NoError(t, err)
+	require.Len(t, markers, 2)
+	require.EqualValues(t, 10, s.Val(x))
+	require.EqualValues(t, 20, s.Val(y))
+
+	_, err = s.AddConstraints(casso.Required, x.LTE(50), y.LTE(50), y.GTE(60))
+	require.Error(t, err)
+	require.EqualValues(t, 2*s.Val(x), s.Val(y))
+
+	// none of the batch is left installed to bound x and y from above
+
+	_, err = s.AddConstraint(x.EQ(100))
+	require.NoError(t, err)
+	require.EqualValues(t, 200, s.Val(y))
+}
+
+func TestRemoveConstraints(t *testing.T) {
+	s := casso.NewSolver()
+
+	x := casso.New()
+	y := casso.New()
+
+	markers, err := s.AddConstraints(casso.Strong, x.EQ(10), y.EQ(20), casso.NewConstraint(casso.EQ, 0, x.T(1), y.T(-1)))
+	require.NoError(t, err)
+	_, err = s.AddConstraintWithPriority(casso.Weak, x.EQ(5))
+	require.NoError(t, err)
+
+	require.NoError(t, s.RemoveConstraints(markers[0], markers[2]))
+	require.False(t, s.HasConstraint(markers[0]))
+	require.True(t, s.HasConstraint(markers[1]))
+	require.False(t, s.HasConstraint(markers[2]))
+	require.EqualValues(t, 5, s.Val(x))
+	require.EqualValues(t, 20, s.Val(y))
+
+	err = s.RemoveConstraints(markers[0], markers[1])
+	require.True(t, errors.Is(err, casso.ErrBadConstraintMarker))
+	require.False(t, s.HasConstraint(markers[1]))
+	require.EqualValues(t, 0, s.Val(y))
+}
+
+func TestEditSuggest(t *testing.T) {
+	s := casso.NewSolver()
+
+	x := casso.New()
+
+	_, err := s.AddConstraint(x.LTE(100))
+	require.NoError(t, err)
+
+	val, err := s.EditSuggest(x, casso.Strong, 50)
+	require.NoError(t, err)
+	require.EqualValues(t, 50, val)
+	require.True(t, s.HasEdit(x))
+
+	val, err = s.SuggestResolved(x, 150)
+	require.NoError(t, err)
+	require.EqualValues(t, 100, val)
+
+	_, err = s.EditSuggest(x, casso.Required, 10)
+	require.Equal(t, casso.ErrBadPriority, err)
+}
+
+func TestObjectiveValue(t *testing.T) {
+	s := casso.NewSolver()
+
+	x := casso.New()
+
+	require.EqualValues(t, 0, s.ObjectiveValue())
+	require.EqualValues(t, 0, s.ErrorMagnitude())
+
+	_, err := s.AddConstraint(x.LTE(100))
+	require.NoError(t, err)
+	_, err = s.AddConstraintWithPriority(casso.Weak, x.EQ(50))
+	require.NoError(t, err)
+
+	require.EqualValues(t, 0, s.ObjectiveValue())
+	require.EqualValues(t, 0, s.ErrorMagnitude())
+
+	// a strong preference for x to be 110 is violated by 10, and the weak preference by 50
+
+	_, err = s.AddConstraintWithPriority(casso.Strong, x.EQ(110))
+	require.NoError(t, err)
+	require.EqualValues(t, 100, s.Val(x))
+
+	require.InDelta(t, float64(10*casso.Strong+50*casso.Weak), s.ObjectiveValue(), 1e-6)
+	require.InDelta(t, 60, s.ErrorMagnitude(), 1e-9)
+
+	// suggesting values for edit variables is accounted for
+
+	y := casso.New()
+	_, err = s.AddConstraintWithPriority(casso.Weak, y.GTE(100))
+	require.NoError(t, err)
+	require.NoError(t, s.Edit(y, casso.Strong))
+	require.NoError(t, s.Suggest(y, 80))
+	require.EqualValues(t, 80, s.Val(y))
+	require.InDelta(t, float64(10*casso.Strong+70*casso.Weak), s.ObjectiveValue(), 1e-6)
+	require.InDelta(t, 80, s.ErrorMagnitude(), 1e-9)
+}
+
+func TestConstraints(t *testing.T) {
+	s := casso.NewSolver()
+
+	x := casso.New()
+	y := casso.New()
+
+	require.Empty(t, s.Constraints())
+
+	a, err := s.AddConstraint(casso.NewConstraint(casso.GTE, 0, y.T(1), x.T(-1)))
+	require.NoError(t, err)
+	require.NoError(t, s.Edit(x, casso.Strong))
+	require.NoError(t, s.Suggest(x, 10))
+	b, err := s.AddConstraintWithPriority(casso.Weak, y.EQ(5))
+	require.NoError(t, err)
+	require.NoError(t, s.AddStay(y, casso.Medium))
+
+	infos := s.Constraints()
+	require.Len(t, infos, 4)
+
+	require.Equal(t, casso.ConstraintInfo{Marker: a, Priority: casso.Required, Constraint: casso.NewConstraint(casso.GTE, 0, y.T(1), x.T(-1))}, infos[0])
+	require.True(t, infos[1].Edit)
+	require.Equal(t, x.EQ(10), infos[1].Constraint)
+	require.Equal(t, casso.Strong, infos[1].Priority)
+	require.Equal(t, b, infos[2].Marker)
+	require.False(t, infos[2].Edit || infos[2].Stay)
+	require.True(t, infos[3].Stay)
+	require.Equal(t, y.EQ(10), infos[3].Constraint)
+
+	// constraints are ordered by installation regardless of the kind of their markers
+
+	s = casso.NewSolver()
+	c, err := s.AddConstraint(x.EQ(1))
+	require.NoError(t, err)
+	d, err := s.AddConstraintWithPriority(casso.Weak, y.EQ(2))
+	require.NoError(t, err)
+
+	infos = s.Constraints()
+	require.Equal(t, []casso.Symbol{c, d}, []casso.Symbol{infos[0].Marker, infos[1].Marker})
+}
+
+func TestMaxIterations(t *testing.T) {
+	s := casso.NewSolver(casso.WithMaxIterations(1))
+
+	left := casso.New()
+	width := casso.New()
+	right := casso.New()
+	window := casso.New()
+
+	_, err := s.AddConstraints(casso.Required,
+		left.GTE(0),
+		casso.NewConstraint(casso.EQ, 0, right.T(1), left.T(-1), width.T(-1)),
+		casso.NewConstraint(casso.LTE, 0, right.T(1), window.T(-1)),
+		width.GTE(100),
+	)
+	require.NoError(t, err)
+	_, err = s.AddConstraintWithPriority(casso.Weak, width.EQ(300))
+	require.NoError(t, err)
+	_, err = s.AddConstraintWithPriority(casso.Medium, left.EQ(50))
+	require.NoError(t, err)
+
+	// the solver gives up optimizing after a single pivot, though the edit variable remains registered
+
+	err = s.Edit(window, casso.Strong)
+	require.True(t, errors.Is(err, casso.ErrMaxIterations))
+	require.Contains(t, err.Error(), "1 pivots made optimizing the objective")
+
+	err = s.Suggest(window, 800)
+	require.True(t, errors.Is(err, casso.ErrMaxIterations))
+	require.Contains(t, err.Error(), "infeasible rows left")
+
+	// by default, the solver pivots for as long as it takes
+
+	c := casso.NewSolver()
+	require.NoError(t, casso.Merge(c, s))
+	require.NoError(t, c.Suggest(window, 120))
+	require.EqualValues(t, 20, c.Val(left))
+	require.EqualValues(t, 120, c.Val(right))
+}
+
+func TestMaxIterationsArtificial(t *testing.T) {
+	s := casso.NewSolver(casso.WithMaxIterations(1))
+	x, y, z := s.New(), s.New(), s.New()
+
+	_, err := s.AddConstraints(casso.Required,
+		x.GTE(0), y.GTE(0), z.GTE(0),
+		casso.NewConstraint(casso.GTE, -5, x.T(1), y.T(1)),
+		casso.NewConstraint(casso.GTE, -3, y.T(1), z.T(1)),
+	)
+	require.NoError(t, err)
+
+	// no subject is found for the sum, such that it is installed through an artificial variable
+	// whose optimization is aborted, and rolled back
+
+	sum, err := s.AddConstraint(casso.NewConstraint(casso.EQ, -20, x.T(1), y.T(1), z.T(1)))
+	require.True(t, errors.Is(err, casso.ErrMaxIterations))
+	require.False(t, s.HasConstraint(sum))
+
+	require.Len(t, s.Constraints(), 5)
+	requireInstalledOnly(t, s)
+
+	// the installed constraints still hold, and further constraints may be installed
+
+	require.GreaterOrEqual(t, s.Val(x)+s.Val(y), 5.0)
+	require.GreaterOrEqual(t, s.Val(y)+s.Val(z), 3.0)
+
+	_, err = s.AddConstraint(x.LTE(100))
+	require.NoError(t, err)
+}
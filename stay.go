@@ -0,0 +1,115 @@
+package casso
+
+import "sort"
+
+// AddStay installs a constraint of the given priority preferring a variable to keep its current
+// value, such that it does not drift when unrelated constraints are added or values are suggested.
+// The value a stay prefers is updated to the current value of its variable via UpdateStays. Should
+// the variable be hinted via Hint, its hint is replaced by the stay.
+func (s *Solver) AddStay(id Symbol, priority Priority) error {
+	if priority < 0 || priority >= Required {
+		return ErrBadPriority
+	}
+	_, hinted := s.hints[id]
+	if _, exists := s.stays[id]; exists && !hinted {
+		return nil
+	}
+	if s.strict != nil {
+		s.strict.checkStay("AddStay", id)
+	}
+	if s.dirty && s.opts.lazy {
+		_ = s.Solve()
+	}
+
+	val := s.val(id) // id need not be referenced by any constraint yet
+	if hinted {
+		if err := s.RemoveStay(id); err != nil {
+			return err
+		}
+	}
+	return s.addStay(id, priority, val)
+}
+
+// addStay installs a stay of the given priority preferring id to take on val.
+func (s *Solver) addStay(id Symbol, priority Priority, val float64) error {
+	// install the stay as id = 0 before shifting it to id = val, such that its constant may be
+	// shifted again by UpdateStays in the same manner as values are suggested for edit variables
+
+	cell := Constraint{op: EQ, expr: NewExpr(-val, id.T(1.0))}
+	marker, err := s.addConstraint(priority, cell)
+	if err != nil {
+		return err
+	}
+
+	tag := s.tags[marker]
+	tag.cell = Constraint{op: EQ, expr: NewExpr(0.0, id.T(1.0))}
+	s.tags[marker] = tag
+
+	if s.stays == nil {
+		s.stays = make(map[Symbol]Edit)
+	}
+	s.stays[id] = Edit{tag: tag, val: val}
+
+	return s.optimize()
+}
+
+// RemoveStay removes the stay of a variable, including one installed via Hint.
+func (s *Solver) RemoveStay(id Symbol) error {
+	stay, exists := s.stays[id]
+	if !exists {
+		return ErrBadStay
+	}
+	if err := s.RemoveConstraint(stay.tag.marker); err != nil {
+		return err
+	}
+	delete(s.stays, id)
+	delete(s.hints, id)
+	return nil
+}
+
+// UpdateStays has every stay prefer the current value of its variable. It is to be called once an
+// interaction that moved variables with stays completes, such as once the user stops dragging a
+// widget, so that the variables stay at their new values.
+func (s *Solver) UpdateStays() error {
+	if s.opts.manual {
+		if err := s.Solve(); err != nil {
+			return err
+		}
+	}
+
+	ids := make([]Symbol, 0, len(s.stays))
+	for id := range s.stays {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	vals := make([]float64, len(ids))
+	for i, id := range ids {
+		vals[i] = s.val(id)
+	}
+
+	if s.watched() {
+		defer s.publish()
+	}
+
+	for i, id := range ids {
+		s.stays[id] = s.suggest(s.stays[id], vals[i])
+	}
+	return s.optimizeDualObjective()
+}
+
+// internalMarker reports whether marker refers to a constraint installed on behalf of an edit
+// variable or a stay, rather than a constraint added by the user.
+func (s *Solver) internalMarker(marker Symbol) bool {
+	for _, edit := range s.edits {
+		if edit.tag.marker == marker {
+			return true
+		}
+	}
+	for _, stay := range s.stays {
+		if stay.tag.marker == marker {
+			return true
+		}
+	}
+	return false
+}
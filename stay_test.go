@@ -0,0 +1,64 @@
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestStay(t *testing.T) {
+	s := casso.NewSolver(casso.WithDriftAudit(1, func(drift casso.Drift) {}))
+
+	left := casso.New()
+	right := casso.New()
+	width := casso.New()
+
+	_, err := s.AddConstraint(casso.NewConstraint(casso.EQ, 0, width.T(1), right.T(-1), left.T(1)))
+	require.NoError(t, err)
+	_, err = s.AddConstraintWithPriority(casso.Weak, left.EQ(10))
+	require.NoError(t, err)
+	_, err = s.AddConstraintWithPriority(casso.Weak, width.EQ(100))
+	require.NoError(t, err)
+
+	require.EqualValues(t, 110, s.Val(right))
+
+	require.NoError(t, s.AddStay(left, casso.Strong))
+	require.NoError(t, s.AddStay(width, casso.Medium))
+
+	// dragging the right edge changes the width, as the left edge stays put
+
+	require.NoError(t, s.Edit(right, casso.Strong))
+	require.NoError(t, s.Suggest(right, 200))
+
+	require.EqualValues(t, 10, s.Val(left))
+	require.EqualValues(t, 190, s.Val(width))
+
+	// once the drag ends, the width stays at its new value rather than snapping back
+
+	require.NoError(t, s.UpdateStays())
+	require.NoError(t, s.RemoveEdit(right))
+
+	require.EqualValues(t, 10, s.Val(left))
+	require.EqualValues(t, 190, s.Val(width))
+	require.EqualValues(t, 200, s.Val(right))
+
+	requireNoDrift(t, s)
+	requireReportContains(t, s, "medium    v", " - 190 = 0\n")
+
+	require.NoError(t, s.RemoveStay(width))
+	require.EqualValues(t, 100, s.Val(width))
+	require.Equal(t, casso.ErrBadStay, s.RemoveStay(width))
+
+	require.Equal(t, casso.ErrBadPriority, s.AddStay(width, casso.Required))
+}
+
+func TestStayStrict(t *testing.T) {
+	s := casso.NewSolver(casso.WithStrict())
+	x := casso.New()
+
+	// stays may be put on variables no constraint references yet
+
+	require.NoError(t, s.AddStay(x, casso.Weak))
+	require.EqualValues(t, 0, s.Val(x))
+	require.NoError(t, s.UpdateStays())
+}
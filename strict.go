@@ -0,0 +1,157 @@
+package casso
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// strict keeps track of the state needed to detect misuse of a solver created using WithStrict.
+type strict struct {
+	s *Solver
+
+	seen    map[Symbol]struct{} // external symbols referenced by the solver
+	keys    map[string]Symbol   // constraint key -> marker
+	markers map[Symbol]string   // marker -> constraint key
+}
+
+func newStrict(s *Solver) *strict {
+	return &strict{
+		s:       s,
+		seen:    make(map[Symbol]struct{}),
+		keys:    make(map[string]Symbol),
+		markers: make(map[Symbol]string),
+	}
+}
+
+func (st *strict) misuse(format string, args ...interface{}) {
+	panic("casso: strict: " + fmt.Sprintf(format, args...))
+}
+
+func (st *strict) checkExternal(op string, id Symbol) {
+	if id.Zero() {
+		return
+	}
+	if !id.External() {
+		st.misuse("%s: %s is a %s symbol internal to the solver; only external symbols created via New may be used", op, id, id.Kind())
+	}
+}
+
+func (st *strict) checkAdd(priority Priority, cell Constraint) {
+	for _, term := range cell.expr.terms {
+		st.checkExternal("AddConstraint", term.id)
+	}
+	key := strictKey(priority, cell)
+	if marker, exists := st.keys[key]; exists {
+		st.misuse("AddConstraint(%s): an identical constraint is already installed under marker %s", key, marker)
+	}
+}
+
+func (st *strict) onAdd(priority Priority, cell Constraint, marker Symbol) {
+	for _, term := range cell.expr.terms {
+		if !term.id.Zero() {
+			st.seen[term.id] = struct{}{}
+		}
+	}
+	key := strictKey(priority, cell)
+	st.keys[key] = marker
+	st.markers[marker] = key
+}
+
+// checkRemove reports the removal of a marker that names no installed constraint. Markers are
+// allocated by the solver, such that any marker the solver allocated but no longer knows of was
+// removed, without the solver having to remember every marker it ever removed.
+func (st *strict) checkRemove(marker Symbol) {
+	if marker.Local() && !marker.External() && marker.ID() <= st.s.count {
+		st.misuse("RemoveConstraint(%s): constraint was already removed", marker)
+	}
+	st.misuse("RemoveConstraint(%s): symbol is not a marker returned by AddConstraint on this solver", marker)
+}
+
+func (st *strict) onRemove(marker Symbol) {
+	if key, exists := st.markers[marker]; exists {
+		delete(st.keys, key)
+		delete(st.markers, marker)
+	}
+}
+
+func (st *strict) checkEdit(id Symbol, priority Priority) {
+	st.checkExternal("Edit", id)
+	if edit, exists := st.s.edits[id]; exists && edit.tag.priority != priority {
+		st.misuse("Edit(%s, %s): symbol is already registered as an edit variable with priority %s", id, priority, edit.tag.priority)
+	}
+	st.seen[id] = struct{}{}
+}
+
+func (st *strict) checkStay(op string, id Symbol) {
+	st.checkExternal(op, id)
+	st.seen[id] = struct{}{}
+}
+
+func (st *strict) checkVal(id Symbol) {
+	st.checkExternal("Val", id)
+	if _, seen := st.seen[id]; !seen {
+		st.misuse("Val(%s): symbol is not referenced by any constraint or edit variable of this solver", id)
+	}
+}
+
+// strictKey returns a key identifying a constraint and its priority irrespective of the order of its
+// terms. Terms of the same symbol are merged, and terms that cancel out are dropped.
+func strictKey(priority Priority, cell Constraint) string {
+	merged := make([]Term, len(cell.expr.terms))
+	copy(merged, cell.expr.terms)
+	sort.Slice(merged, func(i, j int) bool { return merged[i].id < merged[j].id })
+
+	terms := make([]string, 0, len(merged))
+	for i := 0; i < len(merged); {
+		id, coeff := merged[i].id, 0.0
+		for ; i < len(merged) && merged[i].id == id; i++ {
+			coeff += merged[i].coeff
+		}
+		if coeff == 0 {
+			continue
+		}
+		terms = append(terms, strconv.FormatFloat(coeff, 'g', -1, 64)+"*"+strconv.FormatUint(uint64(id), 10))
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.Join(terms, " + "))
+	b.WriteString(" + ")
+	b.WriteString(strconv.FormatFloat(cell.expr.constant, 'g', -1, 64))
+	b.WriteString(" ")
+	b.WriteString(cell.op.String())
+	b.WriteString(" 0 @ ")
+	b.WriteString(priority.String())
+	return b.String()
+}
+
+func (st *strict) clone(s *Solver) *strict {
+	res := newStrict(s)
+	for symbol := range st.seen {
+		res.seen[symbol] = struct{}{}
+	}
+	for key, marker := range st.keys {
+		res.keys[key] = marker
+	}
+	for marker, key := range st.markers {
+		res.markers[marker] = key
+	}
+	return res
+}
+
+func (st *strict) onUpdate(marker Symbol, priority Priority, cell Constraint) {
+	for _, term := range cell.expr.terms {
+		if !term.id.Zero() {
+			st.seen[term.id] = struct{}{}
+		}
+	}
+	key, exists := st.markers[marker]
+	if !exists {
+		return
+	}
+	delete(st.keys, key)
+	key = strictKey(priority, cell)
+	st.keys[key] = marker
+	st.markers[marker] = key
+}
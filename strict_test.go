@@ -0,0 +1,111 @@
+package casso_test
+
+import (
+	"errors"
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestStrict(t *testing.T) {
+	s := casso.NewSolver(casso.WithStrict())
+
+	x := casso.New()
+	y := casso.New()
+
+	c := casso.NewConstraint(casso.EQ, 0, y.T(1), x.T(-2))
+
+	marker, err := s.AddConstraint(c)
+	require.NoError(t, err)
+
+	// Re-adding an identical constraint, even with its terms reordered.
+
+	require.Panics(t, func() { _, _ = s.AddConstraint(casso.NewConstraint(casso.EQ, 0, x.T(-2), y.T(1))) })
+
+	// Re-adding an identical constraint with its terms of the same symbol split up.
+
+	require.Panics(t, func() { _, _ = s.AddConstraint(casso.NewConstraint(casso.EQ, 0, y.T(1), x.T(-1), x.T(-1))) })
+
+	// Identical constraints of different priorities are fine.
+
+	_, err = s.AddConstraintWithPriority(casso.Weak, c)
+	require.NoError(t, err)
+
+	// Suggesting before Edit.
+
+	require.PanicsWithValue(t,
+		"casso: strict: Suggest("+x.String()+", 10): symbol must be registered as an edit variable via Edit before suggesting values for it",
+		func() { _ = s.Suggest(x, 10) },
+	)
+
+	// Re-registering an edit variable with a different priority.
+
+	require.NoError(t, s.Edit(x, casso.Strong))
+	require.NoError(t, s.Edit(x, casso.Strong))
+	require.Panics(t, func() { _ = s.Edit(x, casso.Weak) })
+
+	require.NoError(t, s.Suggest(x, 10))
+	require.EqualValues(t, 20, s.Val(y))
+
+	// Using non-external symbols.
+
+	require.Panics(t, func() { _ = s.Val(marker) })
+	require.Panics(t, func() { _ = s.Edit(marker, casso.Strong) })
+	require.Panics(t, func() { _, _ = s.AddConstraint(marker.EQ(0)) })
+
+	// Using symbols foreign to the solver.
+
+	require.Panics(t, func() { _ = s.Val(casso.New()) })
+	require.Panics(t, func() { _ = s.RemoveConstraint(casso.New()) })
+
+	// Removing a constraint twice.
+
+	require.NoError(t, s.RemoveConstraint(marker))
+	require.Panics(t, func() { _ = s.RemoveConstraint(marker) })
+
+	// Once removed, the constraint may be re-added.
+
+	_, err = s.AddConstraint(c)
+	require.NoError(t, err)
+
+	// Misuse without strict mode is reported through errors, or silently ignored.
+
+	s = casso.NewSolver()
+	require.Equal(t, casso.ErrBadEditVariable, s.Suggest(x, 10))
+	require.True(t, errors.Is(s.RemoveConstraint(marker), casso.ErrBadConstraintMarker))
+	require.EqualValues(t, 0, s.Val(x))
+}
+
+func TestStrictRemovedMarkers(t *testing.T) {
+	s := casso.NewSolver(casso.WithStrict())
+	x := casso.New()
+
+	// markers removed long ago are still reported as removed, however many constraints churn
+
+	first, err := s.AddConstraint(x.EQ(0))
+	require.NoError(t, err)
+	require.NoError(t, s.RemoveConstraint(first))
+
+	for i := 0; i < 100; i++ {
+		marker, err := s.AddConstraint(x.EQ(float64(i)))
+		require.NoError(t, err)
+		require.NoError(t, s.RemoveConstraint(marker))
+	}
+
+	require.PanicsWithValue(t,
+		"casso: strict: RemoveConstraint("+first.String()+"): constraint was already removed",
+		func() { _ = s.RemoveConstraint(first) },
+	)
+}
+
+func TestStrictLocal(t *testing.T) {
+	s := casso.NewSolver(casso.WithStrict())
+	g, l := collidingSymbols(s)
+
+	// constraints on different variables whose symbols print the same are not identical
+
+	_, err := s.AddConstraint(g.EQ(10))
+	require.NoError(t, err)
+	require.NotPanics(t, func() { _, err = s.AddConstraint(l.EQ(10)) })
+	require.NoError(t, err)
+}
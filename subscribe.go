@@ -0,0 +1,161 @@
+package casso
+
+import "sort"
+
+// Change describes the change in value of a variable caused by an operation on a solver.
+type Change struct {
+	Variable Symbol
+	Old      float64
+	New      float64
+}
+
+// Subscribe has the solver send the variables whose values changed, ordered by symbol, to ch after
+// every operation that modifies the solver: adding or removing constraints, and registering,
+// unregistering, or suggesting values for edit variables. Solvers created using WithManualSolve
+// instead send changes after every call to Solve. Operations that change no values send
+// nothing. Sends block, such that ch should either be buffered or be drained by another goroutine.
+func (s *Solver) Subscribe(ch chan<- []Change) {
+	if s.values == nil {
+		s.values = s.externals()
+	}
+	s.subs = append(s.subs, ch)
+}
+
+// Unsubscribe stops the solver from sending changes to ch.
+func (s *Solver) Unsubscribe(ch chan<- []Change) {
+	for i, sub := range s.subs {
+		if sub != ch {
+			continue
+		}
+		s.subs = append(s.subs[:i], s.subs[i+1:]...)
+		break
+	}
+	if !s.watched() {
+		s.values = nil
+	}
+}
+
+type observer struct {
+	fn func(old, new float64)
+}
+
+// Observe has the solver call fn with the old and new value of a variable every time an operation
+// modifying the solver changes its value, once the operation has completed. fn must not modify the
+// solver. The returned function stops fn from being called.
+func (s *Solver) Observe(id Symbol, fn func(old, new float64)) (cancel func()) {
+	if s.values == nil {
+		s.values = s.externals()
+	}
+	if s.observers == nil {
+		s.observers = make(map[Symbol][]*observer)
+	}
+
+	o := &observer{fn: fn}
+	s.observers[id] = append(s.observers[id], o)
+
+	return func() {
+		observers := make([]*observer, 0, len(s.observers[id]))
+		for _, other := range s.observers[id] {
+			if other != o {
+				observers = append(observers, other)
+			}
+		}
+		if len(observers) == 0 {
+			delete(s.observers, id)
+		} else {
+			s.observers[id] = observers
+		}
+		if !s.watched() {
+			s.values = nil
+		}
+	}
+}
+
+// watched reports whether any subscribers or observers are to be notified of changes.
+func (s *Solver) watched() bool {
+	return len(s.subs) > 0 || len(s.observers) > 0
+}
+
+// externals returns the values of all external variables that are basic in the tableau. All other
+// external variables are parametric, and thus have a value of zero.
+func (s *Solver) externals() map[Symbol]float64 {
+	values := make(map[Symbol]float64)
+	for _, tab := range s.tabs.entries {
+		if tab.basic.External() && !s.eqz(tab.row.expr.constant) {
+			values[tab.basic] = tab.row.expr.constant
+		}
+	}
+	return values
+}
+
+// notify publishes changes after an operation. Solvers created using WithManualSolve only publish
+// changes once Solve is called, and changes caused by removals between BeginRemoval and EndRemoval
+// are only published once EndRemoval is called.
+func (s *Solver) notify() {
+	if s.opts.manual || s.removing > 0 {
+		return
+	}
+	s.publish()
+}
+
+// publish sends the variables whose values changed since the last publish to all subscribers and
+// observers.
+func (s *Solver) publish() {
+	if !s.watched() {
+		return
+	}
+
+	values := s.externals()
+
+	var changes []Change
+	for symbol, val := range values {
+		if old := s.values[symbol]; !s.eqz(val - old) {
+			changes = append(changes, Change{Variable: symbol, Old: old, New: val})
+		}
+	}
+	for symbol, old := range s.values {
+		if _, ok := values[symbol]; !ok {
+			changes = append(changes, Change{Variable: symbol, Old: old, New: 0})
+		}
+	}
+
+	s.values = values
+
+	if len(changes) == 0 {
+		return
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Variable < changes[j].Variable })
+
+	for _, change := range changes {
+		for _, o := range s.observers[change.Variable] {
+			o.fn(change.Old, change.New)
+		}
+	}
+
+	for _, sub := range s.subs {
+		sub <- changes
+	}
+}
+
+// FetchChanges returns the values of all variables whose values changed since FetchChanges was
+// last called, or since the solver was created should FetchChanges not have been called before.
+func (s *Solver) FetchChanges() map[Symbol]float64 {
+	values := s.externals()
+
+	changes := make(map[Symbol]float64)
+	for symbol, val := range values {
+		if !s.eqz(val - s.fetched[symbol]) {
+			changes[symbol] = val
+		}
+	}
+	for symbol := range s.fetched {
+		if _, ok := values[symbol]; !ok {
+			changes[symbol] = 0
+		}
+	}
+
+	s.fetched = values
+
+	return changes
+}
@@ -0,0 +1,107 @@
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSubscribe(t *testing.T) {
+	s := casso.NewSolver()
+
+	left := casso.New()
+	right := casso.New()
+	width := casso.New()
+
+	_, err := s.AddConstraint(left.EQ(10))
+	require.NoError(t, err)
+
+	ch := make(chan []casso.Change, 8)
+	s.Subscribe(ch)
+
+	_, err = s.AddConstraint(casso.NewConstraint(casso.EQ, 0, right.T(1), left.T(-1), width.T(-1)))
+	require.NoError(t, err)
+	require.NoError(t, s.Edit(width, casso.Strong))
+	require.Len(t, ch, 1)
+
+	changes := <-ch
+	require.Len(t, changes, 1)
+	require.Equal(t, casso.Change{Variable: right, Old: 0, New: 10}, changes[0])
+
+	require.NoError(t, s.Suggest(width, 90))
+	changes = <-ch
+	require.Len(t, changes, 2)
+	require.Equal(t, casso.Change{Variable: right, Old: 10, New: 100}, changes[0])
+	require.Equal(t, casso.Change{Variable: width, Old: 0, New: 90}, changes[1])
+
+	// suggesting the same value changes nothing, and sends nothing
+
+	require.NoError(t, s.Suggest(width, 90))
+	require.Len(t, ch, 0)
+
+	s.Unsubscribe(ch)
+
+	require.NoError(t, s.Suggest(width, 50))
+	require.Len(t, ch, 0)
+}
+
+func TestFetchChanges(t *testing.T) {
+	s := casso.NewSolver()
+
+	x := casso.New()
+	y := casso.New()
+	z := casso.New()
+
+	require.Empty(t, s.FetchChanges())
+
+	_, err := s.AddConstraint(casso.NewConstraint(casso.EQ, 0, y.T(1), x.T(-2)))
+	require.NoError(t, err)
+	_, err = s.AddConstraint(z.EQ(0))
+	require.NoError(t, err)
+	require.NoError(t, s.Edit(x, casso.Strong))
+	require.NoError(t, s.Suggest(x, 10))
+
+	require.Equal(t, map[casso.Symbol]float64{x: 10, y: 20}, s.FetchChanges())
+	require.Empty(t, s.FetchChanges())
+
+	require.NoError(t, s.Suggest(x, 10))
+	require.Empty(t, s.FetchChanges())
+
+	require.NoError(t, s.RemoveEdit(x))
+	require.Equal(t, map[casso.Symbol]float64{x: 0, y: 0}, s.FetchChanges())
+}
+
+func TestObserve(t *testing.T) {
+	s := casso.NewSolver(casso.WithManualSolve())
+
+	x := casso.New()
+	y := casso.New()
+
+	type call struct{ old, new float64 }
+
+	var calls []call
+	cancel := s.Observe(y, func(old, new float64) {
+		// observers are called once the solver is consistent
+		require.EqualValues(t, new, s.Val(y))
+		calls = append(calls, call{old, new})
+	})
+
+	_, err := s.AddConstraint(casso.NewConstraint(casso.EQ, -5, y.T(1), x.T(-1)))
+	require.NoError(t, err)
+	require.NoError(t, s.Edit(x, casso.Strong))
+	require.NoError(t, s.Suggest(x, 10))
+	require.Empty(t, calls)
+
+	require.NoError(t, s.Solve())
+	require.Equal(t, []call{{0, 15}}, calls)
+
+	require.NoError(t, s.Suggest(x, 20))
+	require.NoError(t, s.Solve())
+	require.Equal(t, []call{{0, 15}, {15, 25}}, calls)
+
+	cancel()
+
+	require.NoError(t, s.Suggest(x, 30))
+	require.NoError(t, s.Solve())
+	require.Len(t, calls, 2)
+}
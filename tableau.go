@@ -0,0 +1,126 @@
+package casso
+
+import "sort"
+
+// Tableau is a read-only view of the rows and objective of a solver. A tableau remains valid only
+// until the solver it was taken from is next modified.
+type Tableau struct {
+	s *Solver
+}
+
+// Row is a row of the tableau, expressing the value of its basic symbol in terms of parametric
+// symbols. Parametric symbols take on a value of zero, such that the value of the basic symbol is
+// the constant of the row's expression.
+type Row struct {
+	Basic Symbol
+	Expr  Expr
+}
+
+func (s *Solver) Tableau() Tableau { return Tableau{s: s} }
+
+func (t Tableau) Len() int { return t.s.tabs.len() }
+
+// Rows returns all rows of the tableau, ordered by basic symbol.
+func (t Tableau) Rows() []Row {
+	rows := make([]Row, 0, t.s.tabs.len())
+	for _, tab := range t.s.tabs.entries {
+		rows = append(rows, Row{Basic: tab.basic, Expr: tab.row.expr})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Basic < rows[j].Basic })
+	return rows
+}
+
+// Range calls fn for every row of the tableau until fn returns false. Rows are ranged over in no
+// particular order, unless the solver was created using WithDeterministic, in which case they are
+// ordered by basic symbol.
+func (t Tableau) Range(fn func(row Row) bool) {
+	if t.s.opts.deterministic {
+		for _, symbol := range t.s.tabs.order() {
+			row, _ := t.s.tabs.get(symbol)
+			if !fn(Row{Basic: symbol, Expr: row.expr}) {
+				return
+			}
+		}
+		return
+	}
+	for _, tab := range t.s.tabs.entries {
+		if !fn(Row{Basic: tab.basic, Expr: tab.row.expr}) {
+			return
+		}
+	}
+}
+
+// Column calls fn for every row of the tableau that references id until fn returns false, and
+// visits only those rows rather than every row as Range does. Rows are ordered as they are with
+// Range.
+func (t Tableau) Column(id Symbol, fn func(row Row) bool) {
+	col := t.s.cols[id]
+	if !t.s.opts.deterministic {
+		for _, basic := range col.symbols() {
+			row, _ := t.s.tabs.get(basic)
+			if !fn(Row{Basic: basic, Expr: row.expr}) {
+				return
+			}
+		}
+		return
+	}
+
+	// take the buffer of the solver for the duration of the call, such that a call made from within
+	// fn allocates a buffer of its own
+
+	basics := t.s.ranged[:0]
+	t.s.ranged = nil
+	defer func() { t.s.ranged = basics[:0] }()
+
+	basics = append(basics, col.symbols()...)
+	sort.Slice(basics, func(i, j int) bool { return basics[i] < basics[j] })
+
+	for _, basic := range basics {
+		row, _ := t.s.tabs.get(basic)
+		if !fn(Row{Basic: basic, Expr: row.expr}) {
+			return
+		}
+	}
+}
+
+// basics returns the basic symbols of all rows of the tableau, ordered by symbol.
+func (s *Solver) basics() []Symbol {
+	symbols := make([]Symbol, 0, s.tabs.len())
+	for _, tab := range s.tabs.entries {
+		symbols = append(symbols, tab.basic)
+	}
+	sort.Slice(symbols, func(i, j int) bool { return symbols[i] < symbols[j] })
+	return symbols
+}
+
+// Row returns the row whose basic symbol is id, if id is basic.
+func (t Tableau) Row(id Symbol) (Row, bool) {
+	row, ok := t.s.tabs.get(id)
+	if !ok {
+		return Row{}, false
+	}
+	return Row{Basic: id, Expr: row.expr}, true
+}
+
+// Basic reports whether id is the basic symbol of a row.
+func (t Tableau) Basic(id Symbol) bool {
+	return t.s.tabs.has(id)
+}
+
+// Epsilon returns the tolerance below which the solver treats values as zero; see WithEpsilon.
+func (t Tableau) Epsilon() float64 { return t.s.opts.epsilon }
+
+// Objective returns the objective function being minimized, which is expressed in terms of
+// parametric error symbols weighted by the priorities of their constraints.
+func (t Tableau) Objective() Expr {
+	if t.s.zeroed == 0 {
+		return t.s.objective
+	}
+	res := Expr{constant: t.s.objective.constant, terms: make([]Term, 0, len(t.s.objective.terms))}
+	for _, term := range t.s.objective.terms {
+		if term.coeff != 0 {
+			res.terms = append(res.terms, term)
+		}
+	}
+	return res
+}
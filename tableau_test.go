@@ -0,0 +1,144 @@
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestTableau(t *testing.T) {
+	s := casso.NewSolver()
+
+	x := casso.New()
+	y := casso.New()
+
+	_, err := s.AddConstraint(casso.NewConstraint(casso.EQ, 0, y.T(1), x.T(-2)))
+	require.NoError(t, err)
+
+	_, err = s.AddConstraintWithPriority(casso.Weak, x.EQ(10))
+	require.NoError(t, err)
+
+	tab := s.Tableau()
+	require.EqualValues(t, 2, tab.Len())
+
+	rows := tab.Rows()
+	require.Len(t, rows, tab.Len())
+	require.True(t, rows[0].Basic < rows[1].Basic)
+
+	for _, row := range rows {
+		require.True(t, tab.Basic(row.Basic))
+		require.EqualValues(t, s.Val(row.Basic), row.Expr.Constant())
+	}
+
+	row, ok := tab.Row(y)
+	require.True(t, ok)
+	require.EqualValues(t, 20, row.Expr.Constant())
+
+	// The weak constraint is satisfied, leaving both of its error symbols parametric.
+
+	objective := tab.Objective()
+	require.EqualValues(t, 0, objective.Constant())
+	require.Len(t, objective.Terms(), 2)
+	for _, term := range objective.Terms() {
+		require.True(t, term.Symbol().Error())
+		require.False(t, tab.Basic(term.Symbol()))
+		require.EqualValues(t, casso.Weak, term.Coeff())
+	}
+}
+
+func TestTableauColumn(t *testing.T) {
+	s := casso.NewSolver(casso.WithDeterministic())
+
+	x := casso.New()
+	_, err := s.AddConstraintWithPriority(casso.Weak, x.EQ(10))
+	require.NoError(t, err)
+
+	ys := make([]casso.Symbol, 8)
+	markers := make([]casso.Symbol, len(ys))
+	for i := range ys {
+		ys[i] = casso.New()
+		markers[i], err = s.AddConstraint(casso.NewConstraint(casso.GTE, -float64(i), ys[i].T(1), x.T(-1)))
+		require.NoError(t, err)
+	}
+
+	// rows are ranged over in order of their basic symbols as they are added and removed
+
+	ranged := func(tab casso.Tableau) {
+		var basics []casso.Symbol
+		tab.Range(func(row casso.Row) bool {
+			basics = append(basics, row.Basic)
+			return true
+		})
+		require.Len(t, basics, tab.Len())
+		for i, row := range tab.Rows() {
+			require.Equal(t, row.Basic, basics[i])
+		}
+	}
+
+	ranged(s.Tableau())
+	for i := 0; i < len(markers); i += 3 {
+		require.NoError(t, s.RemoveConstraint(markers[i]))
+		ranged(s.Tableau())
+	}
+	_, err = s.AddConstraint(casso.NewConstraint(casso.LTE, -20, x.T(1)))
+	require.NoError(t, err)
+	ranged(s.Tableau())
+
+	tab := s.Tableau()
+	visited := 0
+
+	// only and all rows referencing a symbol are visited for its column, in order
+
+	for _, term := range tab.Objective().Terms() {
+		var column []casso.Symbol
+		tab.Column(term.Symbol(), func(row casso.Row) bool {
+			column = append(column, row.Basic)
+			return true
+		})
+
+		var expected []casso.Symbol
+		for _, row := range tab.Rows() {
+			for _, other := range row.Expr.Terms() {
+				if other.Symbol() == term.Symbol() {
+					expected = append(expected, row.Basic)
+				}
+			}
+		}
+		require.Equal(t, expected, column)
+		visited += len(column)
+	}
+	require.NotZero(t, visited)
+}
+
+func TestMemoryStats(t *testing.T) {
+	s := casso.NewSolver()
+	empty := s.MemoryStats()
+	require.Zero(t, empty.Rows)
+	require.Zero(t, empty.Tags)
+	require.Zero(t, empty.Edits)
+
+	prev := casso.New()
+	for i := 0; i < 100; i++ {
+		next := casso.New()
+		_, err := s.AddConstraint(casso.NewConstraint(casso.GTE, -10, next.T(1), prev.T(-1)))
+		require.NoError(t, err)
+		prev = next
+	}
+	require.NoError(t, s.Edit(prev, casso.Strong))
+
+	stats := s.MemoryStats()
+	require.Greater(t, stats.Rows, 0)
+	require.Greater(t, stats.Terms, empty.Terms)
+	require.Greater(t, stats.Tags, 0)
+	require.Greater(t, stats.Edits, 0)
+	require.Equal(t, stats.Rows+stats.Terms+stats.Tags+stats.Edits, stats.Total())
+
+	// storage retained by a reset solver is counted, while constraints are not
+
+	s.Reset()
+	reset := s.MemoryStats()
+	require.Less(t, reset.Rows, stats.Rows)
+	require.Zero(t, reset.Tags)
+	require.Zero(t, reset.Edits)
+	require.Greater(t, reset.Terms, 0)
+}
@@ -0,0 +1,239 @@
+package casso
+
+import "sort"
+
+// tabs holds the rows of the tableau densely in a slice, indexed by basic symbol. Rows are visited
+// by walking the slice rather than by iterating over a map, which dominated the time spent selecting
+// pivots for medium-sized systems. Removing a row moves the last row of the slice into its place.
+//
+// Symbols created by the solver, which include all of its slack, error, and dummy symbols, are
+// counted densely by the solver, such that their rows are located by indexing a slice by their id.
+// The slice only grows to cover ids up to a multiple of the number of rows, as solvers churning
+// through constraints keep counting ids upwards while holding few rows. The rows of symbols with ids
+// past it, and of symbols created via New, whose ids are counted process-wide and thus sparse, are
+// located through a map.
+type tabs struct {
+	entries []tab
+	dense   []int32        // id of basic symbol created by the solver -> position of its row in entries plus one, or zero
+	index   map[Symbol]int // other basic symbols -> position of its row in entries
+
+	// sorted holds the basic symbols of all rows ordered by symbol once requested via order, and is
+	// kept up to date as rows are set and deleted from then on, rather than being sorted anew for
+	// every pivot made by solvers created using WithDeterministic.
+	sorted []Symbol
+	cached bool
+
+	// vals caches the values of external symbols as last read via Solver.Val, with an entry marked
+	// stale once the constant of the row of its symbol changes, or once its symbol enters or leaves
+	// the basis, such that the entries that are not stale are the values of their symbols.
+	vals map[Symbol]cachedVal
+}
+
+// cachedVal is the value of an external symbol as last read, and whether it may since have changed.
+type cachedVal struct {
+	val   float64
+	stale bool
+}
+
+// minDense is the number of ids the dense index of rows may cover regardless of the number of rows.
+const minDense = 64
+
+// denseLimit returns the number of ids the dense index of rows may grow to cover given n rows.
+func denseLimit(n int) uint64 { return uint64(4*n + minDense) }
+
+// tab is a row of the tableau along with its basic symbol.
+type tab struct {
+	basic Symbol
+	row   Constraint
+}
+
+func newTabs(capacity int) tabs {
+	return tabs{entries: make([]tab, 0, capacity), index: make(map[Symbol]int)}
+}
+
+// len returns the number of rows of the tableau.
+func (t *tabs) len() int { return len(t.entries) }
+
+// pos returns the position of the row of basic in entries, and whether basic has a row.
+func (t *tabs) pos(basic Symbol) (int, bool) {
+	if id := basic.ID(); basic.Local() && id < uint64(len(t.dense)) && t.dense[id] != 0 {
+		return int(t.dense[id] - 1), true
+	}
+	if len(t.index) == 0 {
+		return 0, false
+	}
+	i, exists := t.index[basic]
+	return i, exists
+}
+
+// place records i as the position of the row of basic in entries. A row stays wherever it was first
+// placed, as the dense index may since have grown to cover an id placed into the map, or the number
+// of rows may since have shrunk below that which allowed an id into the dense index.
+func (t *tabs) place(basic Symbol, i int) {
+	id := basic.ID()
+	if basic.Local() && id < uint64(len(t.dense)) && t.dense[id] != 0 {
+		t.dense[id] = int32(i + 1)
+		return
+	}
+	if _, exists := t.index[basic]; exists || !basic.Local() {
+		t.index[basic] = i
+		return
+	}
+	switch {
+	case id < uint64(len(t.dense)):
+	case id < denseLimit(len(t.entries)):
+		t.dense = append(t.dense, make([]int32, id+1-uint64(len(t.dense)))...)
+	default:
+		t.index[basic] = i
+		return
+	}
+	t.dense[id] = int32(i + 1)
+}
+
+// unplace forgets the position of the row of basic.
+func (t *tabs) unplace(basic Symbol) {
+	if id := basic.ID(); basic.Local() && id < uint64(len(t.dense)) && t.dense[id] != 0 {
+		t.dense[id] = 0
+		return
+	}
+	delete(t.index, basic)
+}
+
+// get returns the row of basic, and whether basic has a row.
+func (t *tabs) get(basic Symbol) (Constraint, bool) {
+	i, exists := t.pos(basic)
+	if !exists {
+		return Constraint{}, false
+	}
+	return t.entries[i].row, true
+}
+
+// has reports whether basic has a row.
+func (t *tabs) has(basic Symbol) bool {
+	_, exists := t.pos(basic)
+	return exists
+}
+
+// set stores row as the row of basic, replacing any row basic already has.
+func (t *tabs) set(basic Symbol, row Constraint) {
+	if i, exists := t.pos(basic); exists {
+		if t.entries[i].row.expr.constant != row.expr.constant {
+			t.invalidate(basic)
+		}
+		t.entries[i].row = row
+		return
+	}
+	t.invalidate(basic)
+	t.place(basic, len(t.entries))
+	t.entries = append(t.entries, tab{basic: basic, row: row})
+
+	if t.cached {
+		i := t.search(basic)
+		t.sorted = append(t.sorted, zero)
+		copy(t.sorted[i+1:], t.sorted[i:])
+		t.sorted[i] = basic
+	}
+}
+
+// delete removes the row of basic, should it have one.
+func (t *tabs) delete(basic Symbol) {
+	i, exists := t.pos(basic)
+	if !exists {
+		return
+	}
+	last := len(t.entries) - 1
+	if i != last {
+		t.entries[i] = t.entries[last]
+		t.place(t.entries[i].basic, i)
+	}
+	t.entries[last] = tab{}
+	t.entries = t.entries[:last]
+	t.unplace(basic)
+	t.invalidate(basic)
+
+	if t.cached {
+		i := t.search(basic)
+		t.sorted = append(t.sorted[:i], t.sorted[i+1:]...)
+	}
+}
+
+// clear removes all rows, retaining the storage of the slices and map.
+func (t *tabs) clear() {
+	for i := range t.entries {
+		t.unplace(t.entries[i].basic)
+		t.invalidate(t.entries[i].basic)
+		t.entries[i] = tab{}
+	}
+	t.entries = t.entries[:0]
+	t.sorted, t.cached = t.sorted[:0], false
+}
+
+// order returns the basic symbols of all rows ordered by symbol. The slice returned is owned by t,
+// and is only valid until rows are next set or deleted.
+func (t *tabs) order() []Symbol {
+	if !t.cached {
+		t.sorted = t.sorted[:0]
+		for _, tab := range t.entries {
+			t.sorted = append(t.sorted, tab.basic)
+		}
+		sort.Slice(t.sorted, func(i, j int) bool { return t.sorted[i] < t.sorted[j] })
+		t.cached = true
+	}
+	return t.sorted
+}
+
+// search returns the position of basic in sorted, or where it would be inserted.
+func (t *tabs) search(basic Symbol) int {
+	return sort.Search(len(t.sorted), func(i int) bool { return t.sorted[i] >= basic })
+}
+
+// read returns the value of id, being the constant of its row should id be basic, and zero
+// otherwise. The values of external symbols are served from, and recorded into, the cache of values
+// read.
+func (t *tabs) read(id Symbol) float64 {
+	if !id.External() {
+		row, _ := t.get(id)
+		return row.expr.constant
+	}
+	if c, ok := t.vals[id]; ok && !c.stale {
+		return c.val
+	}
+	row, _ := t.get(id)
+	if t.vals == nil {
+		t.vals = make(map[Symbol]cachedVal)
+	}
+	t.vals[id] = cachedVal{val: row.expr.constant}
+	return row.expr.constant
+}
+
+// invalidate marks the cached value of basic stale, should basic be external and have its value
+// cached.
+func (t *tabs) invalidate(basic Symbol) {
+	if !basic.External() || len(t.vals) == 0 {
+		return
+	}
+	if c, ok := t.vals[basic]; ok && !c.stale {
+		c.stale = true
+		t.vals[basic] = c
+	}
+}
+
+// invalidateAll marks all cached values stale.
+func (t *tabs) invalidateAll() {
+	for id, c := range t.vals {
+		c.stale = true
+		t.vals[id] = c
+	}
+}
+
+// copyVals returns a copy of the cache of values read.
+func (t *tabs) copyVals() map[Symbol]cachedVal {
+	if t.vals == nil {
+		return nil
+	}
+	vals := make(map[Symbol]cachedVal, len(t.vals))
+	for id, c := range t.vals {
+		vals[id] = c
+	}
+	return vals
+}
@@ -0,0 +1,106 @@
+package casso
+
+import (
+	"github.com/stretchr/testify/require"
+	"math/rand"
+	"testing"
+)
+
+func TestTabs(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	s := NewSolver()
+
+	symbols := make([]Symbol, 32)
+	for i := range symbols {
+		if i%2 == 0 {
+			symbols[i] = New()
+		} else {
+			symbols[i] = s.next(Slack)
+		}
+	}
+
+	tabs := newTabs(0)
+	rows := make(map[Symbol]float64)
+
+	for i := 0; i < 1000; i++ {
+		symbol := symbols[rng.Intn(len(symbols))]
+		if rng.Intn(3) == 0 {
+			tabs.delete(symbol)
+			delete(rows, symbol)
+		} else {
+			constant := rng.Float64()
+			tabs.set(symbol, Constraint{expr: NewExpr(constant)})
+			rows[symbol] = constant
+		}
+
+		require.Equal(t, len(rows), tabs.len())
+		for _, symbol := range symbols {
+			row, exists := tabs.get(symbol)
+			constant, expected := rows[symbol]
+			require.Equal(t, expected, exists)
+			require.Equal(t, constant, row.expr.constant)
+		}
+	}
+
+	tabs.clear()
+	require.Zero(t, tabs.len())
+	for _, symbol := range symbols {
+		require.False(t, tabs.has(symbol))
+	}
+}
+
+func TestTabsMoveIntoDense(t *testing.T) {
+	s := NewSolver()
+
+	// b is placed into the map, as its id lies past what the dense index may cover for one row, and
+	// is then moved into the place of a once there are two rows, which allow the dense index to
+	// cover it
+
+	a := s.next(Slack)
+	var b Symbol
+	for b.ID() < denseLimit(1) {
+		b = s.next(Slack)
+	}
+	require.Less(t, b.ID(), denseLimit(2))
+
+	tabs := newTabs(0)
+	tabs.set(a, Constraint{expr: NewExpr(1)})
+	tabs.set(b, Constraint{expr: NewExpr(2)})
+
+	tabs.delete(a)
+	row, exists := tabs.get(b)
+	require.True(t, exists)
+	require.EqualValues(t, 2, row.expr.constant)
+
+	tabs.delete(b)
+	require.False(t, tabs.has(b))
+	require.Zero(t, tabs.len())
+}
+
+func TestTabsChurn(t *testing.T) {
+	s := NewSolver()
+
+	x := New()
+	_, err := s.AddConstraint(NewConstraint(GTE, -10, x.T(1)))
+	require.NoError(t, err)
+
+	// churn through constraints, counting the ids of symbols created by the solver far past the
+	// number of rows it holds
+
+	for i := 0; i < 10000; i++ {
+		v := New()
+		marker, err := s.AddConstraint(NewConstraint(EQ, 0, v.T(1), x.T(-1)))
+		require.NoError(t, err)
+		require.NoError(t, s.RemoveConstraint(marker))
+	}
+
+	require.LessOrEqual(t, uint64(len(s.tabs.dense)), denseLimit(2))
+
+	s.Compact()
+	require.LessOrEqual(t, uint64(cap(s.tabs.dense)), denseLimit(s.tabs.len()))
+	require.EqualValues(t, 10, s.Val(x))
+
+	stats := s.MemoryStats()
+	require.GreaterOrEqual(t, stats.Rows, cap(s.tabs.dense)*4)
+}
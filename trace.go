@@ -0,0 +1,8 @@
+package casso
+
+// Trace describes a pivot made by the solver, as reported to the function given to WithTrace.
+type Trace struct {
+	Entry Symbol // symbol entering the basis
+	Exit  Symbol // basic symbol of the row leaving the basis
+	Dual  bool   // whether the pivot restored feasibility rather than optimized the objective
+}
@@ -0,0 +1,63 @@
+package casso
+
+// Tx is a transaction on a solver. Constraints added and removed, edit variables registered and
+// unregistered, and values suggested after a transaction begins may be rolled back altogether,
+// restoring the solver to the state it was in when the transaction began.
+type Tx struct {
+	s        *Solver
+	snapshot *Solver
+}
+
+// Begin starts a transaction by taking a snapshot of the solver via Clone. Constraint markers that
+// are valid when the transaction begins remain valid should it be rolled back.
+func (s *Solver) Begin() *Tx {
+	return &Tx{s: s, snapshot: s.Clone()}
+}
+
+// Commit keeps all operations made since the transaction began, and releases its snapshot.
+func (tx *Tx) Commit() error {
+	if tx.snapshot == nil {
+		return ErrTxDone
+	}
+	tx.snapshot = nil
+	return nil
+}
+
+// Rollback restores the solver to the state it was in when the transaction began. Subscribers and
+// observers are notified of the changes in value caused by the rollback.
+func (tx *Tx) Rollback() error {
+	if tx.snapshot == nil {
+		return ErrTxDone
+	}
+	tx.s.restore(tx.snapshot)
+	tx.snapshot = nil
+	tx.s.publish()
+	return nil
+}
+
+// Transact calls fn within a transaction, rolling the solver back should fn return an error, and
+// committing the transaction otherwise.
+func (s *Solver) Transact(fn func() error) error {
+	tx := s.Begin()
+	if err := fn(); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// restore replaces the state of the solver with that of snapshot, a clone of the solver that is
+// not used afterwards. Subscribers and observers, the values last reported to them and returned by
+// FetchChanges, and the values last read via Val, are kept.
+func (s *Solver) restore(snapshot *Solver) {
+	snapshot.subs, snapshot.observers = s.subs, s.observers
+	snapshot.values, snapshot.fetched = s.values, s.fetched
+	snapshot.tabs.vals = s.tabs.vals // read off rows the snapshot rolls back, and thus stale
+	snapshot.tabs.invalidateAll()
+	snapshot.count = s.count // symbols created since the snapshot may still be held onto
+	snapshot.pooled = s.pooled
+	if snapshot.strict != nil {
+		snapshot.strict = snapshot.strict.clone(s)
+	}
+	*s = *snapshot
+}
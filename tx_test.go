@@ -0,0 +1,64 @@
+package casso_test
+
+import (
+	"errors"
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestTransaction(t *testing.T) {
+	s := casso.NewSolver()
+
+	x := casso.New()
+	y := casso.New()
+
+	base, err := s.AddConstraint(casso.NewConstraint(casso.EQ, 0, y.T(1), x.T(-2)))
+	require.NoError(t, err)
+	require.NoError(t, s.Edit(x, casso.Strong))
+	require.NoError(t, s.Suggest(x, 10))
+
+	changes := make(chan []casso.Change, 8)
+	s.Subscribe(changes)
+
+	tx := s.Begin()
+	require.NoError(t, s.RemoveConstraint(base))
+	require.NoError(t, s.Suggest(x, 20))
+	_, err = s.AddConstraint(y.EQ(5))
+	require.NoError(t, err)
+	require.NoError(t, s.Edit(y, casso.Medium))
+
+	require.EqualValues(t, 20, s.Val(x))
+	require.EqualValues(t, 5, s.Val(y))
+
+	require.NoError(t, tx.Rollback())
+	require.Equal(t, casso.ErrTxDone, tx.Rollback())
+	require.Equal(t, casso.ErrTxDone, tx.Commit())
+
+	require.True(t, s.HasConstraint(base))
+	require.False(t, s.HasEdit(y))
+	suggested, ok := s.Suggested(x)
+	require.True(t, ok)
+	require.EqualValues(t, 10, suggested)
+	require.EqualValues(t, 10, s.Val(x))
+	require.EqualValues(t, 20, s.Val(y))
+
+	var last []casso.Change
+	for len(changes) > 0 {
+		last = <-changes
+	}
+	require.Equal(t, []casso.Change{{Variable: x, Old: 20, New: 10}, {Variable: y, Old: 5, New: 20}}, last)
+
+	// Transact only rolls back should the function fail.
+
+	bad := errors.New("bad")
+	require.Equal(t, bad, s.Transact(func() error {
+		require.NoError(t, s.Suggest(x, 30))
+		return bad
+	}))
+	require.EqualValues(t, 10, s.Val(x))
+
+	require.NoError(t, s.Transact(func() error { return s.Suggest(x, 30) }))
+	require.EqualValues(t, 30, s.Val(x))
+	require.EqualValues(t, 60, s.Val(y))
+}
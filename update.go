@@ -0,0 +1,156 @@
+package casso
+
+import "errors"
+
+// UpdateConstant changes the constant of an installed constraint in place, such as a padding baked
+// into a required constraint, rather than removing and reinstalling the constraint. The rows of the
+// tableau are shifted as Suggest shifts them for edit variables, after which the solver is
+// optimized via the dual simplex method, such that the constraint keeps its marker and the solver
+// is not re-optimized from scratch. Should a required constraint be changed such that it
+// contradicts other required constraints, its constant is restored and ErrUnsatisfiable is
+// returned. Edit variables and stays are changed via Suggest and UpdateStays instead.
+func (s *Solver) UpdateConstant(marker Symbol, constant float64) error {
+	tag, exists := s.tags[marker]
+	if !exists || s.internalMarker(marker) {
+		if s.strict != nil {
+			s.strict.misuse("UpdateConstant(%s, %g): symbol is not a marker of a constraint installed via AddConstraint", marker, constant)
+		}
+		return ErrBadConstraintMarker
+	}
+
+	old := tag.cell.expr.constant
+	if constant == old {
+		return nil
+	}
+
+	if s.watched() {
+		defer s.notify()
+	}
+
+	err := s.shiftConstant(tag, constant)
+	if errors.Is(err, ErrUnsatisfiable) {
+		_ = s.shiftConstant(s.tags[marker], old)
+		return s.constraintError(marker, tag.priority, tag.cell, err)
+	}
+
+	if s.history != nil {
+		s.history.record(op{kind: opUpdateConstant, marker: marker, old: old, new: constant})
+	}
+	if s.byValue != nil {
+		s.onRemoveValue(marker, tag.cell)
+		s.onAddValue(marker, s.tags[marker].cell)
+	}
+	if s.strict != nil {
+		s.strict.onUpdate(marker, tag.priority, s.tags[marker].cell)
+	}
+
+	if err != nil {
+		return s.constraintError(marker, tag.priority, s.tags[marker].cell, err)
+	}
+	return nil
+}
+
+// shiftConstant changes the constant of the constraint of tag to constant by shifting the rows of
+// the tableau, and restores feasibility via the dual simplex method unless the solver was created
+// using WithManualSolve.
+//
+// The marker of a constraint enters its row with a coefficient of -1 for equalities that are not
+// required and for GTE inequalities, which is how edit variables enter theirs, such that the
+// constant is shifted by having suggest shift the value of an edit variable by the negated change
+// in constant. The markers of other constraints enter with a coefficient of 1.
+func (s *Solver) shiftConstant(tag Tag, constant float64) error {
+	delta := constant - tag.cell.expr.constant
+	if tag.cell.op == GTE || (tag.cell.op == EQ && tag.priority < Required) {
+		delta = -delta
+	}
+	s.suggest(Edit{tag: tag}, delta)
+
+	tag.cell = Constraint{op: tag.cell.op, expr: Expr{constant: constant, terms: tag.cell.expr.terms}}
+	s.tags[tag.marker] = tag
+
+	// the dummy marker of a required equality is only basic should the equality be implied by other
+	// required constraints, in which case it may not be changed to hold for any other constant
+
+	if row, basic := s.tabs.get(tag.marker); basic && tag.marker.Dummy() && !s.eqz(row.expr.constant) {
+		return ErrUnsatisfiable
+	}
+
+	if s.opts.manual {
+		s.dirty = true
+		return nil
+	}
+	return s.optimizeDualObjective()
+}
+
+// UpdateCoefficient changes the coefficient of a variable in an installed constraint, such as the
+// ratio of a proportional split that follows a slider, keeping the marker of the constraint. The
+// variable is added to the constraint should it not be referenced by it, and dropped from it should
+// coeff be zero. The constraint is reinstalled in place: its row is removed from the tableau and
+// rebuilt under the same marker and error symbols, after which the solver is optimized. Should the
+// constraint fail to be reinstalled, it is restored as it was and the error is returned.
+func (s *Solver) UpdateCoefficient(marker Symbol, variable Symbol, coeff float64) error {
+	tag, exists := s.tags[marker]
+	if !exists || s.internalMarker(marker) {
+		if s.strict != nil {
+			s.strict.misuse("UpdateCoefficient(%s, %s, %g): symbol is not a marker of a constraint installed via AddConstraint", marker, variable, coeff)
+		}
+		return ErrBadConstraintMarker
+	}
+	if variable.Zero() {
+		return ErrBadTermInConstraint
+	}
+	if s.strict != nil {
+		s.strict.checkExternal("UpdateCoefficient", variable)
+	}
+
+	// the terms of constraints are kept as supplied, such that the variable may be referenced by
+	// several of them; its first term is updated in place and the rest are dropped
+
+	old := 0.0
+	terms := make([]Term, 0, len(tag.cell.expr.terms)+1)
+	updated := false
+	for _, term := range tag.cell.expr.terms {
+		if term.id != variable {
+			terms = append(terms, term)
+			continue
+		}
+		old += term.coeff
+		if !updated && coeff != 0 {
+			terms = append(terms, Term{coeff: coeff, id: variable})
+		}
+		updated = true
+	}
+	if !updated && coeff != 0 {
+		terms = append(terms, Term{coeff: coeff, id: variable})
+	}
+	if coeff == old {
+		return nil
+	}
+
+	if s.watched() {
+		defer s.notify()
+	}
+
+	next := tag
+	next.cell = NewConstraint(tag.cell.op, tag.cell.expr.constant, terms...)
+
+	s.uninstall(tag)
+	if _, err := s.install(next); err != nil {
+		_, _ = s.install(tag)
+		_ = s.optimize()
+		return s.constraintError(marker, tag.priority, next.cell, err)
+	}
+
+	if s.history != nil {
+		s.history.record(op{kind: opUpdateCoefficient, marker: marker, id: variable, old: old, new: coeff})
+	}
+	if s.byValue != nil {
+		s.onRemoveValue(marker, tag.cell)
+		s.onAddValue(marker, next.cell)
+	}
+	if s.strict != nil {
+		s.strict.onUpdate(marker, tag.priority, next.cell)
+	}
+
+	return s.constraintError(marker, tag.priority, next.cell, s.optimize())
+}
@@ -0,0 +1,278 @@
+package casso_test
+
+import (
+	"errors"
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"math/rand"
+	"testing"
+)
+
+func TestUpdateConstant(t *testing.T) {
+	s := casso.NewSolver(casso.WithHistory(4))
+
+	x := casso.New()
+	y := casso.New()
+	z := casso.New()
+
+	_, err := s.AddConstraint(x.GTE(0))
+	require.NoError(t, err)
+	pad, err := s.AddConstraint(casso.NewConstraint(casso.EQ, -10, y.T(1), x.T(-1)))
+	require.NoError(t, err)
+	gap, err := s.AddConstraint(casso.NewConstraint(casso.GTE, -5, z.T(1), y.T(-1)))
+	require.NoError(t, err)
+	_, err = s.AddConstraintWithPriority(casso.Weak, z.EQ(0))
+	require.NoError(t, err)
+	require.NoError(t, s.Edit(x, casso.Strong))
+	require.NoError(t, s.Suggest(x, 5))
+
+	require.EqualValues(t, 15, s.Val(y))
+	require.EqualValues(t, 20, s.Val(z))
+
+	// changing the constants of required equalities and inequalities keeps their markers
+
+	require.NoError(t, s.UpdateConstant(pad, -20))
+	require.EqualValues(t, 25, s.Val(y))
+	require.EqualValues(t, 30, s.Val(z))
+
+	require.NoError(t, s.UpdateConstant(gap, -1))
+	require.EqualValues(t, 25, s.Val(y))
+	require.EqualValues(t, 26, s.Val(z))
+
+	c, ok := s.Constraint(pad)
+	require.True(t, ok)
+	require.EqualValues(t, -20, c.Expr().Constant())
+
+	require.NoError(t, s.Undo())
+	require.EqualValues(t, 30, s.Val(z))
+
+	// markers of edit variables are rejected
+
+	for _, info := range s.Constraints() {
+		if info.Priority == casso.Strong {
+			require.Equal(t, casso.ErrBadConstraintMarker, s.UpdateConstant(info.Marker, 1))
+		}
+	}
+}
+
+func TestUpdateConstantUnsatisfiable(t *testing.T) {
+	s := casso.NewSolver()
+
+	x := casso.New()
+
+	_, err := s.AddConstraint(x.EQ(10))
+	require.NoError(t, err)
+	upper, err := s.AddConstraint(x.LTE(20))
+	require.NoError(t, err)
+	same, err := s.AddConstraint(x.EQ(10))
+	require.NoError(t, err)
+
+	// constants contradicting other required constraints are restored
+
+	for _, marker := range []casso.Symbol{upper, same} {
+		c, _ := s.Constraint(marker)
+
+		err := s.UpdateConstant(marker, -5)
+		require.True(t, errors.Is(err, casso.ErrUnsatisfiable))
+
+		restored, _ := s.Constraint(marker)
+		require.Equal(t, c, restored)
+		require.EqualValues(t, 10, s.Val(x))
+	}
+
+	require.NoError(t, s.UpdateConstant(upper, -15))
+	require.EqualValues(t, 10, s.Val(x))
+}
+
+func TestUpdateConstantRequiredOnly(t *testing.T) {
+	s := casso.NewSolver()
+	x := s.New()
+
+	_, err := s.AddConstraint(x.GTE(5))
+	require.NoError(t, err)
+	lower, err := s.AddConstraint(x.GTE(2))
+	require.NoError(t, err)
+
+	// the objective is empty, such that the slack of x >= 5 enters the basis with no weight in the
+	// objective to restore feasibility once the looser bound is tightened past it
+
+	require.NoError(t, s.UpdateConstant(lower, -8))
+	require.EqualValues(t, 8, s.Val(x))
+}
+
+func TestUpdateConstantNoEntry(t *testing.T) {
+	s := casso.NewSolver()
+	x := s.New()
+
+	_, err := s.AddConstraint(x.LTE(10))
+	require.NoError(t, err)
+	lower, err := s.AddConstraint(x.GTE(0))
+	require.NoError(t, err)
+
+	// raising the lower bound past the upper bound leaves a row with no symbol to enter the basis,
+	// which is reported rather than pivoted on
+
+	err = s.UpdateConstant(lower, -20)
+	require.True(t, errors.Is(err, casso.ErrUnsatisfiable))
+
+	for _, row := range s.Tableau().Rows() {
+		require.False(t, row.Basic.Zero())
+		for _, term := range row.Expr.Terms() {
+			require.False(t, term.Symbol().Zero())
+		}
+		if row.Basic.Restricted() {
+			require.GreaterOrEqual(t, row.Expr.Constant(), 0.0, row.Basic.String())
+		}
+	}
+
+	require.NoError(t, s.UpdateConstant(lower, -5))
+	require.GreaterOrEqual(t, s.Val(x), 5.0)
+	require.LessOrEqual(t, s.Val(x), 10.0)
+}
+
+func TestUpdateConstantMatchesReinstalling(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	type entry struct {
+		priority casso.Priority
+		cell     casso.Constraint
+	}
+
+	ops := []casso.Op{casso.EQ, casso.GTE, casso.LTE}
+	priorities := []casso.Priority{casso.Required, casso.Strong, casso.Medium, casso.Weak}
+
+	vars := make([]casso.Symbol, 6)
+	for i := range vars {
+		vars[i] = casso.New()
+	}
+
+	var entries []entry
+	for _, id := range vars {
+		entries = append(entries, entry{priority: casso.Required, cell: id.GTE(0)}, entry{priority: casso.Required, cell: id.LTE(100)})
+	}
+	for i := 0; i < 12; i++ {
+		a, b := vars[rng.Intn(len(vars))], vars[rng.Intn(len(vars))]
+		if a == b {
+			continue
+		}
+		op, priority := ops[rng.Intn(len(ops))], priorities[1+rng.Intn(len(priorities)-1)]
+		entries = append(entries, entry{priority: priority, cell: casso.NewConstraint(op, float64(rng.Intn(40)-20), a.T(1), b.T(-1))})
+	}
+
+	s := casso.NewSolver()
+	markers := make([]casso.Symbol, len(entries))
+	for i, e := range entries {
+		marker, err := s.AddConstraintWithPriority(e.priority, e.cell)
+		require.NoError(t, err)
+		markers[i] = marker
+	}
+
+	for i := 0; i < 200; i++ {
+		idx := rng.Intn(len(entries))
+		constant := float64(rng.Intn(60) - 30)
+		if entries[idx].priority == casso.Required {
+			constant = -float64(rng.Intn(50)) // lower bounds within [0, 50), upper bounds within [50, 100)
+			if entries[idx].cell.Op() == casso.LTE {
+				constant -= 50
+			}
+		}
+
+		require.NoError(t, s.UpdateConstant(markers[idx], constant))
+		entries[idx].cell = casso.NewConstraint(entries[idx].cell.Op(), constant, entries[idx].cell.Expr().Terms()...)
+
+		fresh := casso.NewSolver()
+		for _, e := range entries {
+			_, err := fresh.AddConstraintWithPriority(e.priority, e.cell)
+			require.NoError(t, err)
+		}
+		require.InDelta(t, fresh.ObjectiveValue(), s.ObjectiveValue(), 1e-6)
+	}
+}
+
+func TestUpdateCoefficient(t *testing.T) {
+	s := casso.NewSolver(casso.WithHistory(4))
+
+	total := casso.New()
+	left := casso.New()
+	right := casso.New()
+
+	split, err := s.AddConstraint(casso.NewConstraint(casso.EQ, 0, left.T(1), total.T(-0.5)))
+	require.NoError(t, err)
+	_, err = s.AddConstraint(casso.NewConstraint(casso.EQ, 0, left.T(1), right.T(1), total.T(-1)))
+	require.NoError(t, err)
+	require.NoError(t, s.Edit(total, casso.Strong))
+	require.NoError(t, s.Suggest(total, 200))
+
+	require.EqualValues(t, 100, s.Val(left))
+	require.EqualValues(t, 100, s.Val(right))
+
+	// the ratio of the split follows updates to its coefficient, keeping its marker
+
+	require.NoError(t, s.UpdateCoefficient(split, total, -0.25))
+	require.EqualValues(t, 50, s.Val(left))
+	require.EqualValues(t, 150, s.Val(right))
+
+	c, ok := s.Constraint(split)
+	require.True(t, ok)
+	require.Equal(t, casso.NewConstraint(casso.EQ, 0, left.T(1), total.T(-0.25)), c)
+
+	require.NoError(t, s.Undo())
+	require.EqualValues(t, 100, s.Val(left))
+	require.NoError(t, s.Redo())
+	require.EqualValues(t, 50, s.Val(left))
+
+	// variables are added to and dropped from constraints
+
+	require.NoError(t, s.UpdateCoefficient(split, right, -1))
+	require.EqualValues(t, 125, s.Val(left))
+	require.EqualValues(t, 75, s.Val(right))
+
+	require.NoError(t, s.UpdateCoefficient(split, total, 0))
+	c, _ = s.Constraint(split)
+	require.Equal(t, casso.NewConstraint(casso.EQ, 0, left.T(1), right.T(-1)), c)
+	require.EqualValues(t, 100, s.Val(left))
+	require.EqualValues(t, 100, s.Val(right))
+
+	require.Equal(t, casso.ErrBadTermInConstraint, s.UpdateCoefficient(split, casso.Symbol(0), 1))
+	require.True(t, errors.Is(s.UpdateCoefficient(casso.New(), left, 1), casso.ErrBadConstraintMarker))
+}
+
+func TestUpdateCoefficientUnsatisfiable(t *testing.T) {
+	s := casso.NewSolver()
+
+	x := casso.New()
+	y := casso.New()
+
+	_, err := s.AddConstraint(x.EQ(10))
+	require.NoError(t, err)
+	_, err = s.AddConstraint(y.EQ(20))
+	require.NoError(t, err)
+	marker, err := s.AddConstraint(casso.NewConstraint(casso.EQ, 0, y.T(1), x.T(-2)))
+	require.NoError(t, err)
+
+	// constraints that contradict others once updated are restored
+
+	err = s.UpdateCoefficient(marker, x, -3)
+	require.True(t, errors.Is(err, casso.ErrBadDummyVariable))
+
+	c, ok := s.Constraint(marker)
+	require.True(t, ok)
+	require.Equal(t, casso.NewConstraint(casso.EQ, 0, y.T(1), x.T(-2)), c)
+	require.EqualValues(t, 10, s.Val(x))
+	require.EqualValues(t, 20, s.Val(y))
+
+	require.NoError(t, s.RemoveConstraint(marker))
+}
+
+func TestUpdateCoefficientStrict(t *testing.T) {
+	s := casso.NewSolver(casso.WithStrict())
+	x, y := s.New(), s.New()
+
+	marker, err := s.AddConstraint(x.EQ(10))
+	require.NoError(t, err)
+
+	// the variable introduced by the update may be read as any other referenced variable
+
+	require.NoError(t, s.UpdateCoefficient(marker, y, -1))
+	require.EqualValues(t, 10, s.Val(x)-s.Val(y))
+}
@@ -0,0 +1,95 @@
+package casso
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RemoveConstraintValue removes a constraint by its value rather than by its marker, for callers
+// that regenerate constraints rather than keep track of their markers. Constraints are compared
+// once normalized, such that the order of their terms, duplicate terms, terms with zero
+// coefficients, and which side of an inequality their terms are written on do not matter. Should
+// several installed constraints be equal to c, the last one installed is removed. Edit variables
+// and stays are never removed by value.
+//
+// The first call to RemoveConstraintValue indexes all installed constraints by value, after which
+// the index is maintained as constraints are added and removed.
+func (s *Solver) RemoveConstraintValue(c Constraint) error {
+	if s.byValue == nil {
+		s.indexByValue()
+	}
+	markers := s.byValue[constraintKey(c)]
+	if len(markers) == 0 {
+		return s.constraintError(zero, 0, c, ErrBadConstraintMarker)
+	}
+	return s.RemoveConstraint(markers[len(markers)-1])
+}
+
+func (s *Solver) indexByValue() {
+	markers := make([]Symbol, 0, len(s.tags))
+	for marker := range s.tags {
+		if !s.internalMarker(marker) {
+			markers = append(markers, marker)
+		}
+	}
+	sort.Slice(markers, func(i, j int) bool { return markers[i] < markers[j] })
+
+	s.byValue = make(map[string][]Symbol, len(markers))
+	for _, marker := range markers {
+		s.onAddValue(marker, s.tags[marker].cell)
+	}
+}
+
+func (s *Solver) onAddValue(marker Symbol, cell Constraint) {
+	key := constraintKey(cell)
+	s.byValue[key] = append(s.byValue[key], marker)
+}
+
+func (s *Solver) onRemoveValue(marker Symbol, cell Constraint) {
+	key := constraintKey(cell)
+	markers := s.byValue[key]
+	for i, other := range markers {
+		if other == marker {
+			markers = append(markers[:i], markers[i+1:]...)
+			break
+		}
+	}
+	if len(markers) == 0 {
+		delete(s.byValue, key)
+		return
+	}
+	s.byValue[key] = markers
+}
+
+// constraintKey returns a key identifying a constraint once normalized: duplicate terms are merged,
+// terms with zero coefficients are dropped, terms are ordered by symbol, inequalities are written as
+// 'expr <= 0', and equalities are written such that their first term has a positive coefficient.
+func constraintKey(cell Constraint) string {
+	expr := cell.expr.canonical()
+
+	op := cell.op
+	if op == GTE {
+		expr.negate()
+		op = LTE
+	}
+	if op == EQ && len(expr.terms) > 0 && expr.terms[0].coeff < 0 {
+		expr.negate()
+	}
+	if expr.constant == 0 {
+		expr.constant = 0 // negative zero
+	}
+
+	var b strings.Builder
+	for _, term := range expr.terms {
+		b.WriteString(strconv.FormatFloat(term.coeff, 'g', -1, 64))
+		b.WriteString("*")
+		b.WriteString(strconv.FormatUint(uint64(term.id), 10)) // String drops the local bit of the id
+		b.WriteString(" + ")
+	}
+	b.WriteString(strconv.FormatFloat(expr.constant, 'g', -1, 64))
+	b.WriteString(" ")
+	b.WriteString(op.String())
+	b.WriteString(" 0")
+	return b.String()
+}
@@ -0,0 +1,67 @@
+package casso_test
+
+import (
+	"errors"
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestRemoveConstraintValue(t *testing.T) {
+	s := casso.NewSolver()
+
+	x := casso.New()
+	y := casso.New()
+
+	a, err := s.AddConstraint(casso.NewConstraint(casso.GTE, -10, x.T(1), y.T(-1)))
+	require.NoError(t, err)
+	b, err := s.AddConstraintWithPriority(casso.Strong, casso.NewConstraint(casso.EQ, 0, x.T(2), y.T(-1)))
+	require.NoError(t, err)
+	require.NoError(t, s.Edit(x, casso.Weak))
+
+	// y + 10 <= x, written with its terms on the other side and in a different order.
+
+	require.NoError(t, s.RemoveConstraintValue(casso.NewConstraint(casso.LTE, 10, y.T(1), x.T(-1))))
+	require.False(t, s.HasConstraint(a))
+
+	// Constraints added after the index is built are indexed too.
+
+	c, err := s.AddConstraint(y.LTE(50))
+	require.NoError(t, err)
+
+	require.True(t, errors.Is(s.RemoveConstraintValue(x.EQ(0)), casso.ErrBadConstraintMarker))
+	require.True(t, s.HasEdit(x))
+
+	require.NoError(t, s.RemoveConstraintValue(casso.NewConstraint(casso.EQ, 0, y.T(1), x.T(-1), x.T(-1), y.T(0))))
+	require.False(t, s.HasConstraint(b))
+	require.True(t, errors.Is(s.RemoveConstraintValue(casso.NewConstraint(casso.EQ, 0, x.T(2), y.T(-1))), casso.ErrBadConstraintMarker))
+
+	require.NoError(t, s.RemoveConstraint(c))
+	require.True(t, errors.Is(s.RemoveConstraintValue(y.LTE(50)), casso.ErrBadConstraintMarker))
+}
+
+func TestRemoveConstraintValueLocal(t *testing.T) {
+	s := casso.NewSolver()
+	g, l := collidingSymbols(s)
+
+	a, err := s.AddConstraint(g.EQ(10))
+	require.NoError(t, err)
+	b, err := s.AddConstraint(l.EQ(10))
+	require.NoError(t, err)
+
+	// the symbols print the same, though they are different variables
+
+	require.NoError(t, s.RemoveConstraintValue(g.EQ(10)))
+	require.False(t, s.HasConstraint(a))
+	require.True(t, s.HasConstraint(b))
+}
+
+// collidingSymbols returns a symbol created via New and a symbol created by s with the same id.
+func collidingSymbols(s *casso.Solver) (casso.Symbol, casso.Symbol) {
+	g := casso.New()
+	l := s.New()
+	for l.ID() < g.ID() {
+		l = s.New()
+	}
+	return g, l
+}
@@ -0,0 +1,54 @@
+package casso
+
+// Variable bundles an external variable with the solver it is solved by, such that application
+// code may build constraints and read solutions without passing symbols and solvers around apart.
+type Variable struct {
+	id Symbol
+	s  *Solver
+}
+
+// NewVariable creates a new external variable solved by s. Should name be non-empty, the variable is
+// named within the solver as it would be by NewNamed.
+func (s *Solver) NewVariable(name string) Variable {
+	v := Variable{id: New(), s: s}
+	if name != "" {
+		s.SetName(v.id, name)
+	}
+	return v
+}
+
+// Variable wraps an existing symbol solved by s.
+func (s *Solver) Variable(id Symbol) Variable { return Variable{id: id, s: s} }
+
+func (v Variable) Symbol() Symbol           { return v.id }
+func (v Variable) Solver() *Solver          { return v.s }
+func (v Variable) Name() string             { return v.s.Name(v.id) }
+func (v Variable) Value() float64           { return v.s.Val(v.id) }
+func (v Variable) T(coeff float64) Term     { return v.id.T(coeff) }
+func (v Variable) Expr() Expr               { return v.id.Expr() }
+func (v Variable) Times(coeff float64) Expr { return v.id.Times(coeff) }
+
+// Plus returns the expression v + o.
+func (v Variable) Plus(o Variable) Expr { return v.id.Plus(o.id) }
+
+// Minus returns the expression v - o.
+func (v Variable) Minus(o Variable) Expr { return v.id.Minus(o.id) }
+
+// EQ returns the constraint v = o.
+func (v Variable) EQ(o Variable) Constraint { return v.Minus(o).EQ(0) }
+
+// GTE returns the constraint v >= o.
+func (v Variable) GTE(o Variable) Constraint { return v.Minus(o).GTE(0) }
+
+// LTE returns the constraint v <= o.
+func (v Variable) LTE(o Variable) Constraint { return v.Minus(o).LTE(0) }
+
+// Edit registers the variable as an edit variable of the given priority. See Solver.Edit.
+func (v Variable) Edit(priority Priority) error { return v.s.Edit(v.id, priority) }
+
+// Suggest suggests a value for the variable, which must be registered as an edit variable. See
+// Solver.Suggest.
+func (v Variable) Suggest(val float64) error { return v.s.Suggest(v.id, val) }
+
+// String returns the name of the variable, or its symbol should it have no name.
+func (v Variable) String() string { return v.s.label(v.id) }
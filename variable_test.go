@@ -0,0 +1,35 @@
+package casso_test
+
+import (
+	"github.com/lithdew/casso"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestVariable(t *testing.T) {
+	s := casso.NewSolver()
+
+	left := s.NewVariable("left")
+	width := s.NewVariable("width")
+	right := s.NewVariable("")
+
+	require.Equal(t, "left", left.Name())
+	require.Equal(t, "left", left.String())
+	require.Equal(t, right.Symbol().String(), right.String())
+
+	_, err := s.AddConstraint(right.EQ(s.Variable(left.Symbol())))
+	require.NoError(t, err)
+	_, err = s.AddConstraint(right.Minus(left).GTE(0))
+	require.NoError(t, err)
+	_, err = s.AddConstraint(left.Plus(width).LTE(100))
+	require.NoError(t, err)
+	_, err = s.AddConstraint(left.Expr().GTE(10))
+	require.NoError(t, err)
+
+	require.NoError(t, width.Edit(casso.Strong))
+	require.NoError(t, width.Suggest(200))
+
+	require.EqualValues(t, 10, left.Value())
+	require.EqualValues(t, 90, width.Value())
+	require.EqualValues(t, 10, right.Value())
+}